@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+)
+
+const entryExtension = ".json"
+
+// FileCache stores JSON encoded values in a directory that persists between
+// runs. Entries are written to a temporary file and renamed into place, so
+// several processes can share the directory without reading partial entries.
+type FileCache struct {
+	dir    string
+	logger logger.Logger
+	now    func() time.Time
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+func NewFileCache(dir string, logger logger.Logger) *FileCache {
+	return &FileCache{
+		dir:    dir,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Get decodes the value stored for the key into value and reports whether
+// the key was found. Entries that cannot be read are treated as missing.
+func (c FileCache) Get(key string, value interface{}) (bool, error) {
+	return c.GetFresh(key, 0, value)
+}
+
+// GetFresh behaves like Get but treats entries stored more than maxAge ago
+// as missing. A maxAge of zero accepts entries of any age.
+func (c FileCache) GetFresh(key string, maxAge time.Duration, value interface{}) (bool, error) {
+	b, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var e entry
+	err = json.Unmarshal(b, &e)
+	if err == nil {
+		err = json.Unmarshal(e.Value, value)
+	}
+	if err != nil {
+		c.logger.Info(fmt.Sprintf("Ignoring unreadable cache entry for key: '%s'", key))
+		return false, nil
+	}
+
+	if maxAge > 0 && c.now().Sub(e.StoredAt) > maxAge {
+		c.logger.Debug(fmt.Sprintf("Ignoring expired cache entry for key: '%s'", key))
+		return false, nil
+	}
+
+	c.logger.Debug(fmt.Sprintf("Found cache entry for key: '%s'", key))
+
+	return true, nil
+}
+
+func (c FileCache) Set(key string, value interface{}) error {
+	v, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(entry{
+		StoredAt: c.now(),
+		Value:    v,
+	})
+	if err != nil {
+		// Untested as it is too hard to force json.Marshal to return an error
+		return err
+	}
+
+	err = os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(c.dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b)
+	if err != nil {
+		// Untested as it is too hard to force a write to a new file to fail
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		// Untested as it is too hard to force closing a new file to fail
+		os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path(key))
+}
+
+// Prune removes entries, and temporary files left behind by interrupted
+// writes, that have not been written for longer than maxAge.
+func (c FileCache) Prune(maxAge time.Duration) error {
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || c.now().Sub(f.ModTime()) <= maxAge {
+			continue
+		}
+
+		if !strings.HasSuffix(f.Name(), entryExtension) && !strings.HasSuffix(f.Name(), ".tmp") {
+			continue
+		}
+
+		// Another process sharing the directory may have removed it first
+		err := os.Remove(filepath.Join(c.dir, f.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c FileCache) path(key string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x%s", sha256.Sum256([]byte(key)), entryExtension))
+}
@@ -0,0 +1,180 @@
+package cache_test
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/cache"
+)
+
+var _ = Describe("FileCache", func() {
+	type entry struct {
+		Name  string
+		Count int
+	}
+
+	var (
+		dir       string
+		fileCache *cache.FileCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "pivnet-resource-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		fileCache = cache.NewFileCache(filepath.Join(dir, "cache"), logshim.NewLogShim(logger, logger, true))
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(dir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns values that were set", func() {
+		err := fileCache.Set("some-key", entry{Name: "some-name", Count: 3})
+		Expect(err).NotTo(HaveOccurred())
+
+		var value entry
+		found, err := fileCache.Get("some-key", &value)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal(entry{Name: "some-name", Count: 3}))
+	})
+
+	It("overwrites existing values", func() {
+		err := fileCache.Set("some-key", entry{Name: "some-name"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = fileCache.Set("some-key", entry{Name: "other-name"})
+		Expect(err).NotTo(HaveOccurred())
+
+		var value entry
+		_, err = fileCache.Get("some-key", &value)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(value.Name).To(Equal("other-name"))
+	})
+
+	It("persists values for new caches in the same directory", func() {
+		err := fileCache.Set("some-key", entry{Name: "some-name"})
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		otherCache := cache.NewFileCache(filepath.Join(dir, "cache"), logshim.NewLogShim(logger, logger, true))
+
+		var value entry
+		found, err := otherCache.Get("some-key", &value)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(found).To(BeTrue())
+		Expect(value.Name).To(Equal("some-name"))
+	})
+
+	Describe("GetFresh", func() {
+		BeforeEach(func() {
+			err := fileCache.Set("some-key", entry{Name: "some-name"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns values set within the max age", func() {
+			var value entry
+			found, err := fileCache.GetFresh("some-key", time.Hour, &value)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(found).To(BeTrue())
+			Expect(value.Name).To(Equal("some-name"))
+		})
+
+		It("reports values set before the max age as not found", func() {
+			time.Sleep(time.Millisecond)
+
+			var value entry
+			found, err := fileCache.GetFresh("some-key", time.Nanosecond, &value)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("Prune", func() {
+		var cacheDir string
+
+		BeforeEach(func() {
+			cacheDir = filepath.Join(dir, "cache")
+
+			err := fileCache.Set("old-key", entry{Name: "old"})
+			Expect(err).NotTo(HaveOccurred())
+
+			files, err := ioutil.ReadDir(cacheDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+
+			old := time.Now().Add(-2 * time.Hour)
+			err = os.Chtimes(filepath.Join(cacheDir, files[0].Name()), old, old)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fileCache.Set("new-key", entry{Name: "new"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes entries written before the max age", func() {
+			err := fileCache.Prune(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			var value entry
+			found, err := fileCache.Get("old-key", &value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			found, err = fileCache.Get("new-key", &value)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the directory does not exist", func() {
+			BeforeEach(func() {
+				err := os.RemoveAll(cacheDir)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not return an error", func() {
+				err := fileCache.Prune(time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when the key has not been set", func() {
+		It("reports that it was not found", func() {
+			var value entry
+			found, err := fileCache.Get("some-key", &value)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when the entry cannot be decoded", func() {
+		BeforeEach(func() {
+			err := fileCache.Set("some-key", "not an entry")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports that it was not found", func() {
+			var value entry
+			found, err := fileCache.Get("some-key", &value)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
@@ -16,26 +17,48 @@ import (
 type filter interface {
 	ReleasesByReleaseType(releases []pivnet.Release, releaseType pivnet.ReleaseType) ([]pivnet.Release, error)
 	ReleasesByVersion(releases []pivnet.Release, version string) ([]pivnet.Release, error)
+	ProductFileKeysByGlobs(productFiles []pivnet.ProductFile, globs []string) ([]pivnet.ProductFile, error)
 }
 
 //counterfeiter:generate --fake-name FakeSorter . sorter
 type sorter interface {
-	SortBySemver([]pivnet.Release) ([]pivnet.Release, error)
-	SortByLastUpdated([]pivnet.Release) ([]pivnet.Release, error)
+	Sort([]pivnet.Release, []concourse.SortBy) ([]pivnet.Release, error)
 }
 
 //counterfeiter:generate --fake-name FakePivnetClient . pivnetClient
 type pivnetClient interface {
 	ReleaseTypes() ([]pivnet.ReleaseType, error)
 	ReleasesForProductSlug(string) ([]pivnet.Release, error)
+	ReleaseUpgradePaths(productSlug string, releaseID int) ([]pivnet.ReleaseUpgradePath, error)
+	UpgradePathSpecifiers(productSlug string, releaseID int) ([]pivnet.UpgradePathSpecifier, error)
+	DependencySpecifiers(productSlug string, releaseID int) ([]pivnet.DependencySpecifier, error)
+	ProductFilesForRelease(productSlug string, releaseID int) ([]pivnet.ProductFile, error)
+	FileGroupsForRelease(productSlug string, releaseID int) ([]pivnet.FileGroup, error)
 }
 
+//counterfeiter:generate --fake-name FakeSpecifierMatcher . specifierMatcher
+type specifierMatcher interface {
+	Matches(specifier string, version string) (bool, error)
+}
+
+//counterfeiter:generate --fake-name FakeCache . cache
+type cache interface {
+	Get(key string, value interface{}) (bool, error)
+	GetFresh(key string, maxAge time.Duration, value interface{}) (bool, error)
+	Set(key string, value interface{}) error
+	Prune(maxAge time.Duration) error
+}
+
+const cachePruneAge = 30 * 24 * time.Hour
+
 type CheckCommand struct {
 	logger        logger.Logger
 	binaryVersion string
 	filter        filter
 	pivnetClient  pivnetClient
 	sort          sorter
+	matcher       specifierMatcher
+	cache         cache
 	logFilePath   string
 }
 
@@ -45,6 +68,8 @@ func NewCheckCommand(
 	filter filter,
 	pivnetClient pivnetClient,
 	sort sorter,
+	matcher specifierMatcher,
+	cache cache,
 	logFilePath string,
 ) *CheckCommand {
 	return &CheckCommand{
@@ -53,6 +78,8 @@ func NewCheckCommand(
 		filter:        filter,
 		pivnetClient:  pivnetClient,
 		sort:          sort,
+		matcher:       matcher,
+		cache:         cache,
 		logFilePath:   logFilePath,
 	}
 }
@@ -65,50 +92,108 @@ func (c *CheckCommand) Run(input concourse.CheckRequest) (concourse.CheckRespons
 		return nil, err
 	}
 
-	releaseType := input.Source.ReleaseType
+	var cacheTTL time.Duration
+	if input.Source.CacheTTL != "" {
+		cacheTTL, err = time.ParseDuration(input.Source.CacheTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	err = c.validateReleaseType(releaseType)
+	err = c.cache.Prune(cachePruneAge)
 	if err != nil {
-		return nil, err
+		c.logger.Info(fmt.Sprintf("Failed to prune cache: %s", err))
+	}
+
+	endpoint := input.Source.Endpoint
+	if endpoint == "" {
+		endpoint = pivnet.DefaultHost
 	}
 
 	productSlug := input.Source.ProductSlug
 
+	// Caching is opt-in, so that new releases are found by the next check
+	var lookupsCache cache
+	if cacheTTL > 0 {
+		lookupsCache = c.cache
+	}
+
+	lookups := newReleaseLookups(c.logger, c.pivnetClient, lookupsCache, endpoint, productSlug)
+
+	releaseTypes := input.Source.ReleaseType
+
+	err = c.validateReleaseTypes(lookups, releaseTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	c.logger.Info("Getting all releases")
-	releases, err := c.pivnetClient.ReleasesForProductSlug(productSlug)
+	releases, err := lookups.Releases(cacheTTL)
 	if err != nil {
 		return nil, err
 	}
 
-	if releaseType != "" {
-		c.logger.Info(fmt.Sprintf("Filtering all releases by release type: '%s'", releaseType))
-		releases, err = c.filter.ReleasesByReleaseType(
-			releases,
-			pivnet.ReleaseType(releaseType),
-		)
+	if len(releaseTypes) > 0 {
+		c.logger.Info(fmt.Sprintf("Filtering all releases by release types: %s", printable(releaseTypes)))
+		releases, err = releasesMatchingAny(releases, releaseTypes, func(rs []pivnet.Release, releaseType string) ([]pivnet.Release, error) {
+			return c.filter.ReleasesByReleaseType(rs, pivnet.ReleaseType(releaseType))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	productVersions := input.Source.ProductVersion
+	if len(productVersions) > 0 {
+		c.logger.Info(fmt.Sprintf("Filtering all releases by product versions: %s", printable(productVersions)))
+		releases, err = releasesMatchingAny(releases, productVersions, c.filter.ReleasesByVersion)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	version := input.Source.ProductVersion
-	if version != "" {
-		c.logger.Info(fmt.Sprintf("Filtering all releases by product version: '%s'", version))
-		releases, err = c.filter.ReleasesByVersion(releases, version)
+	upgradableFrom := input.Source.UpgradableFrom
+	if upgradableFrom != "" {
+		c.logger.Info(fmt.Sprintf("Filtering all releases by upgradable from: '%s'", upgradableFrom))
+		releases, err = c.releasesUpgradableFrom(lookups, releases, upgradableFrom)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if input.Source.SortBy == concourse.SortBySemver {
-		c.logger.Info("Sorting all releases by semver")
-		releases, err = c.sort.SortBySemver(releases)
+	compatibleWith := input.Source.CompatibleWith
+	if len(compatibleWith) > 0 {
+		c.logger.Info(fmt.Sprintf("Filtering all releases by compatible with: %v", compatibleWith))
+		releases, err = c.releasesCompatibleWith(lookups, releases, compatibleWith)
 		if err != nil {
 			return nil, err
 		}
-	} else if input.Source.SortBy == concourse.SortByLastUpdated {
-		c.logger.Info("Sorting all releases by release date")
-		releases, err = c.sort.SortByLastUpdated(releases)
+	}
+
+	requireFiles := input.Source.RequireFiles
+	if len(requireFiles) > 0 {
+		c.logger.Info(fmt.Sprintf("Filtering all releases by required files: %v", requireFiles))
+		releases, err = c.releasesWithFiles(lookups, releases, requireFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sortKeys []concourse.SortBy
+	for _, k := range input.Source.SortBy.Keys() {
+		if !k.Known() {
+			c.logger.Info(fmt.Sprintf(
+				"WARNING: ignoring unknown sort_by key: '%s' - must be one of: none, semver, last_updated, release_date, id",
+				k,
+			))
+			continue
+		}
+		sortKeys = append(sortKeys, k)
+	}
+
+	if len(sortKeys) > 0 && !input.Source.SortBy.Includes(concourse.SortByNone) {
+		c.logger.Info(fmt.Sprintf("Sorting all releases by: %s", sortKeys))
+		releases, err = c.sort.Sort(releases, sortKeys)
 		if err != nil {
 			return nil, err
 		}
@@ -180,30 +265,64 @@ func (c *CheckCommand) removeExistingLogFiles() error {
 	return nil
 }
 
-func (c *CheckCommand) validateReleaseType(releaseType string) error {
-	c.logger.Info(fmt.Sprintf("Validating release type: '%s'", releaseType))
-	releaseTypes, err := c.pivnetClient.ReleaseTypes()
+func (c *CheckCommand) validateReleaseTypes(lookups *releaseLookups, releaseTypes []string) error {
+	c.logger.Info(fmt.Sprintf("Validating release types: %s", printable(releaseTypes)))
+	validReleaseTypes, err := lookups.ReleaseTypes()
 	if err != nil {
 		return err
 	}
 
-	releaseTypesAsStrings := make([]string, len(releaseTypes))
-	for i, r := range releaseTypes {
+	releaseTypesAsStrings := make([]string, len(validReleaseTypes))
+	for i, r := range validReleaseTypes {
 		releaseTypesAsStrings[i] = string(r)
 	}
 
-	if releaseType != "" && !containsString(releaseTypesAsStrings, releaseType) {
-		releaseTypesPrintable := fmt.Sprintf("['%s']", strings.Join(releaseTypesAsStrings, "', '"))
-		return fmt.Errorf(
-			"provided release type: '%s' must be one of: %s",
-			releaseType,
-			releaseTypesPrintable,
-		)
+	for _, releaseType := range releaseTypes {
+		if !containsString(releaseTypesAsStrings, releaseType) {
+			return fmt.Errorf(
+				"provided release type: '%s' must be one of: %s",
+				releaseType,
+				printable(releaseTypesAsStrings),
+			)
+		}
 	}
 
 	return nil
 }
 
+// releasesMatchingAny applies the filter once for each value and returns the
+// releases matched by any of them, in their original order.
+func releasesMatchingAny(
+	releases []pivnet.Release,
+	values []string,
+	filter func([]pivnet.Release, string) ([]pivnet.Release, error),
+) ([]pivnet.Release, error) {
+	matched := map[int]bool{}
+	for _, v := range values {
+		filtered, err := filter(releases, v)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range filtered {
+			matched[r.ID] = true
+		}
+	}
+
+	result := make([]pivnet.Release, 0)
+	for _, r := range releases {
+		if matched[r.ID] {
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
+
+func printable(values []string) string {
+	return fmt.Sprintf("['%s']", strings.Join(values, "', '"))
+}
+
 func containsString(strings []string, str string) bool {
 	for _, s := range strings {
 		if str == s {
@@ -7,6 +7,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
@@ -26,6 +28,8 @@ var _ = Describe("Check", func() {
 		fakeFilter       *checkfakes.FakeFilter
 		fakePivnetClient *checkfakes.FakePivnetClient
 		fakeSorter       *checkfakes.FakeSorter
+		fakeMatcher      *checkfakes.FakeSpecifierMatcher
+		fakeCache        *checkfakes.FakeCache
 
 		checkRequest concourse.CheckRequest
 		checkCommand *check.CheckCommand
@@ -50,6 +54,8 @@ var _ = Describe("Check", func() {
 		fakeFilter = &checkfakes.FakeFilter{}
 		fakePivnetClient = &checkfakes.FakePivnetClient{}
 		fakeSorter = &checkfakes.FakeSorter{}
+		fakeMatcher = &checkfakes.FakeSpecifierMatcher{}
+		fakeCache = &checkfakes.FakeCache{}
 
 		logger := log.New(GinkgoWriter, "", log.LstdFlags)
 		fakeLogger = logshim.NewLogShim(logger, logger, true)
@@ -126,6 +132,8 @@ var _ = Describe("Check", func() {
 			fakeFilter,
 			fakePivnetClient,
 			fakeSorter,
+			fakeMatcher,
+			fakeCache,
 			logFilePath,
 		)
 	})
@@ -265,7 +273,7 @@ var _ = Describe("Check", func() {
 
 	Context("when the release type is specified", func() {
 		BeforeEach(func() {
-			checkRequest.Source.ReleaseType = string(releaseTypes[1])
+			checkRequest.Source.ReleaseType = concourse.StringList{string(releaseTypes[1])}
 
 			filteredReleases = []pivnet.Release{allReleases[1]}
 		})
@@ -280,9 +288,45 @@ var _ = Describe("Check", func() {
 			Expect(response[0].ProductVersion).To(Equal(versionWithFingerprintC))
 		})
 
+		Context("when multiple release types are specified", func() {
+			BeforeEach(func() {
+				checkRequest.Source.ReleaseType = concourse.StringList{
+					string(releaseTypes[2]),
+					string(releaseTypes[0]),
+				}
+
+				checkRequest.Version = concourse.Version{
+					ProductVersion: versionsWithFingerprints[2],
+				}
+			})
+
+			JustBeforeEach(func() {
+				fakeFilter.ReleasesByReleaseTypeStub = func(releases []pivnet.Release, releaseType pivnet.ReleaseType) ([]pivnet.Release, error) {
+					var filtered []pivnet.Release
+					for _, r := range releases {
+						if r.ReleaseType == releaseType {
+							filtered = append(filtered, r)
+						}
+					}
+					return filtered, nil
+				}
+			})
+
+			It("returns the releases with any of the release types in their original order", func() {
+				response, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeFilter.ReleasesByReleaseTypeCallCount()).To(Equal(2))
+
+				Expect(response).To(HaveLen(2))
+				Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[2]))
+				Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+			})
+		})
+
 		Context("when the release type is invalid", func() {
 			BeforeEach(func() {
-				checkRequest.Source.ReleaseType = "not a valid release type"
+				checkRequest.Source.ReleaseType = concourse.StringList{string(releaseTypes[0]), "not a valid release type"}
 			})
 
 			It("returns an error", func() {
@@ -312,13 +356,13 @@ var _ = Describe("Check", func() {
 
 	Context("when the product version is specified", func() {
 		BeforeEach(func() {
-			checkRequest.Source.ReleaseType = string(releaseTypes[1])
+			checkRequest.Source.ReleaseType = concourse.StringList{string(releaseTypes[1])}
 
 			filteredReleases = []pivnet.Release{allReleases[1]}
 		})
 
 		BeforeEach(func() {
-			checkRequest.Source.ProductVersion = "C"
+			checkRequest.Source.ProductVersion = concourse.StringList{"C"}
 		})
 
 		It("returns the newest release with that version without error", func() {
@@ -331,6 +375,41 @@ var _ = Describe("Check", func() {
 			Expect(response[0].ProductVersion).To(Equal(versionWithFingerprintC))
 		})
 
+		Context("when multiple product versions are specified", func() {
+			BeforeEach(func() {
+				checkRequest.Source.ReleaseType = nil
+				checkRequest.Source.ProductVersion = concourse.StringList{`1\.2\..*`, `2\..*`}
+
+				checkRequest.Version = concourse.Version{
+					ProductVersion: versionsWithFingerprints[2],
+				}
+			})
+
+			JustBeforeEach(func() {
+				fakeFilter.ReleasesByVersionStub = func(releases []pivnet.Release, version string) ([]pivnet.Release, error) {
+					switch version {
+					case `1\.2\..*`:
+						return []pivnet.Release{allReleases[2], allReleases[0]}, nil
+					case `2\..*`:
+						return []pivnet.Release{allReleases[1]}, nil
+					}
+					return nil, nil
+				}
+			})
+
+			It("returns the releases matching any of the versions in their original order", func() {
+				response, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeFilter.ReleasesByVersionCallCount()).To(Equal(2))
+
+				Expect(response).To(HaveLen(3))
+				Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[2]))
+				Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[1]))
+				Expect(response[2].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+			})
+		})
+
 		Context("when filtering returns an error", func() {
 			BeforeEach(func() {
 				releasesByVersionErr = fmt.Errorf("some version error")
@@ -363,7 +442,7 @@ var _ = Describe("Check", func() {
 				ProductVersion: versionsWithFingerprints[0], // 1.2.3#time1
 			}
 
-			fakeSorter.SortBySemverReturns(semverOrderedReleases, nil)
+			fakeSorter.SortReturns(semverOrderedReleases, nil)
 		})
 
 		It("returns in ascending semver order", func() {
@@ -375,7 +454,9 @@ var _ = Describe("Check", func() {
 			Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[2]))
 			Expect(response[2].ProductVersion).To(Equal(versionsWithFingerprints[1]))
 
-			Expect(fakeSorter.SortBySemverCallCount()).To(Equal(1))
+			Expect(fakeSorter.SortCallCount()).To(Equal(1))
+			_, keys := fakeSorter.SortArgsForCall(0)
+			Expect(keys).To(Equal([]concourse.SortBy{concourse.SortBySemver}))
 		})
 
 		Context("when sorting by semver returns an error", func() {
@@ -386,7 +467,7 @@ var _ = Describe("Check", func() {
 			BeforeEach(func() {
 				semverErr = errors.New("semver error")
 
-				fakeSorter.SortBySemverReturns(nil, semverErr)
+				fakeSorter.SortReturns(nil, semverErr)
 			})
 
 			It("returns error", func() {
@@ -416,16 +497,18 @@ var _ = Describe("Check", func() {
 				ProductVersion: versionsWithFingerprints[0], // 1.2.3#time1
 			}
 
-			fakeSorter.SortByLastUpdatedReturns(releases, nil)
+			fakeSorter.SortReturns(releases, nil)
 		})
 
 		It("returns invokes sort by update_at on sorter", func() {
-			Expect(fakeSorter.SortByLastUpdatedCallCount()).To(Equal(0))
+			Expect(fakeSorter.SortCallCount()).To(Equal(0))
 
 			_, err := checkCommand.Run(checkRequest)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeSorter.SortByLastUpdatedCallCount()).To(Equal(1))
+			Expect(fakeSorter.SortCallCount()).To(Equal(1))
+			_, keys := fakeSorter.SortArgsForCall(0)
+			Expect(keys).To(Equal([]concourse.SortBy{concourse.SortByLastUpdated}))
 		})
 
 		Context("when sorting by semver returns an error", func() {
@@ -436,7 +519,7 @@ var _ = Describe("Check", func() {
 			BeforeEach(func() {
 				semverErr = errors.New("semver error")
 
-				fakeSorter.SortByLastUpdatedReturns(nil, semverErr)
+				fakeSorter.SortReturns(nil, semverErr)
 			})
 
 			It("returns error", func() {
@@ -447,4 +530,609 @@ var _ = Describe("Check", func() {
 			})
 		})
 	})
+
+	Context("when sorting by several keys", func() {
+		BeforeEach(func() {
+			checkRequest.Source.SortBy = "semver, release_date"
+
+			fakeSorter.SortReturns(allReleases, nil)
+		})
+
+		It("sorts by each key in turn", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSorter.SortCallCount()).To(Equal(1))
+			_, keys := fakeSorter.SortArgsForCall(0)
+			Expect(keys).To(Equal([]concourse.SortBy{
+				concourse.SortBySemver,
+				concourse.SortByReleaseDate,
+			}))
+		})
+	})
+
+	Context("when sort_by has unknown keys", func() {
+		BeforeEach(func() {
+			checkRequest.Source.SortBy = "size, semver"
+
+			fakeSorter.SortReturns(allReleases, nil)
+		})
+
+		It("sorts by the known keys only", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSorter.SortCallCount()).To(Equal(1))
+			_, keys := fakeSorter.SortArgsForCall(0)
+			Expect(keys).To(Equal([]concourse.SortBy{concourse.SortBySemver}))
+		})
+
+		Context("when every key is unknown", func() {
+			BeforeEach(func() {
+				checkRequest.Source.SortBy = "size"
+			})
+
+			It("does not sort releases", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSorter.SortCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when sort_by is none", func() {
+		BeforeEach(func() {
+			checkRequest.Source.SortBy = concourse.SortByNone
+		})
+
+		It("does not sort releases", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSorter.SortCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when upgradable_from is specified", func() {
+		var (
+			upgradePaths          map[int][]pivnet.ReleaseUpgradePath
+			upgradePathSpecifiers map[int][]pivnet.UpgradePathSpecifier
+
+			upgradePathsErr          error
+			upgradePathSpecifiersErr error
+			matchesErr               error
+		)
+
+		BeforeEach(func() {
+			checkRequest.Source.UpgradableFrom = "1.2.2"
+
+			upgradePaths = map[int][]pivnet.ReleaseUpgradePath{
+				1: {{Release: pivnet.UpgradePathRelease{ID: 10, Version: "1.2.2"}}},
+				2: {{Release: pivnet.UpgradePathRelease{ID: 1, Version: "1.2.3"}}},
+			}
+			upgradePathSpecifiers = map[int][]pivnet.UpgradePathSpecifier{
+				3: {{ID: 30, Specifier: "1.2.*"}},
+			}
+
+			upgradePathsErr = nil
+			upgradePathSpecifiersErr = nil
+			matchesErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakePivnetClient.ReleaseUpgradePathsStub = func(productSlug string, releaseID int) ([]pivnet.ReleaseUpgradePath, error) {
+				return upgradePaths[releaseID], upgradePathsErr
+			}
+			fakePivnetClient.UpgradePathSpecifiersStub = func(productSlug string, releaseID int) ([]pivnet.UpgradePathSpecifier, error) {
+				return upgradePathSpecifiers[releaseID], upgradePathSpecifiersErr
+			}
+			fakeMatcher.MatchesStub = func(specifier string, version string) (bool, error) {
+				return specifier == "1.2.*", matchesErr
+			}
+		})
+
+		It("returns only the releases that can be upgraded to from that version", func() {
+			checkRequest.Version = concourse.Version{
+				ProductVersion: versionsWithFingerprints[2], // 1.2.4#time3
+			}
+
+			response, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response).To(HaveLen(2))
+			Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[2]))
+			Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+
+			Expect(fakePivnetClient.ReleaseUpgradePathsCallCount()).To(Equal(3))
+
+			invokedProductSlug, invokedReleaseID := fakePivnetClient.ReleaseUpgradePathsArgsForCall(0)
+			Expect(invokedProductSlug).To(Equal(productSlug))
+			Expect(invokedReleaseID).To(Equal(1))
+
+			invokedSpecifier, invokedVersion := fakeMatcher.MatchesArgsForCall(0)
+			Expect(invokedSpecifier).To(Equal("1.2.*"))
+			Expect(invokedVersion).To(Equal("1.2.2"))
+		})
+
+		It("does not look up upgrade path specifiers for releases with a matching upgrade path", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePivnetClient.UpgradePathSpecifiersCallCount()).To(Equal(2))
+		})
+
+		Context("when no release can be upgraded to from that version", func() {
+			BeforeEach(func() {
+				checkRequest.Source.UpgradableFrom = "0.0.1"
+				upgradePathSpecifiers = nil
+			})
+
+			It("returns an error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(HaveOccurred())
+
+				Expect(err.Error()).To(ContainSubstring("cannot find specified release"))
+			})
+		})
+
+		Context("when getting upgrade paths returns an error", func() {
+			BeforeEach(func() {
+				upgradePathsErr = errors.New("some upgrade paths error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(upgradePathsErr))
+			})
+		})
+
+		Context("when getting upgrade path specifiers returns an error", func() {
+			BeforeEach(func() {
+				upgradePathSpecifiersErr = errors.New("some upgrade path specifiers error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(upgradePathSpecifiersErr))
+			})
+		})
+
+		Context("when matching a specifier returns an error", func() {
+			BeforeEach(func() {
+				matchesErr = errors.New("some matches error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(matchesErr))
+			})
+		})
+	})
+
+	Context("when compatible_with is specified", func() {
+		var (
+			dependencySpecifiers    map[int][]pivnet.DependencySpecifier
+			dependencySpecifiersErr error
+			matchesErr              error
+		)
+
+		BeforeEach(func() {
+			checkRequest.Source.CompatibleWith = map[string]string{
+				"ops-manager": "3.0.5",
+			}
+
+			opsManager := pivnet.Product{Slug: "ops-manager"}
+			dependencySpecifiers = map[int][]pivnet.DependencySpecifier{
+				1: {
+					{ID: 10, Specifier: "2.10.*", Product: opsManager},
+					{ID: 11, Specifier: "~> 3.0", Product: opsManager},
+				},
+				2: {
+					{ID: 20, Specifier: "~> 3.1", Product: opsManager},
+				},
+				3: {
+					{ID: 30, Specifier: "1.*", Product: pivnet.Product{Slug: "other-product"}},
+				},
+			}
+
+			dependencySpecifiersErr = nil
+			matchesErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakePivnetClient.DependencySpecifiersStub = func(productSlug string, releaseID int) ([]pivnet.DependencySpecifier, error) {
+				return dependencySpecifiers[releaseID], dependencySpecifiersErr
+			}
+			fakeMatcher.MatchesStub = func(specifier string, version string) (bool, error) {
+				return specifier == "~> 3.0", matchesErr
+			}
+		})
+
+		It("returns only the releases whose dependency specifiers accept the versions", func() {
+			checkRequest.Version = concourse.Version{
+				ProductVersion: versionsWithFingerprints[2], // 1.2.4#time3
+			}
+
+			response, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response).To(HaveLen(2))
+			Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[2]))
+			Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+
+			Expect(fakePivnetClient.DependencySpecifiersCallCount()).To(Equal(3))
+
+			invokedProductSlug, invokedReleaseID := fakePivnetClient.DependencySpecifiersArgsForCall(0)
+			Expect(invokedProductSlug).To(Equal(productSlug))
+			Expect(invokedReleaseID).To(Equal(1))
+		})
+
+		Context("when getting dependency specifiers returns an error", func() {
+			BeforeEach(func() {
+				dependencySpecifiersErr = errors.New("some dependency specifiers error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(dependencySpecifiersErr))
+			})
+		})
+
+		Context("when matching a specifier returns an error", func() {
+			BeforeEach(func() {
+				matchesErr = errors.New("some matches error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(matchesErr))
+			})
+		})
+	})
+
+	Context("when require_files is specified", func() {
+		var (
+			productFiles    map[int][]pivnet.ProductFile
+			fileGroups      map[int][]pivnet.FileGroup
+			productFilesErr error
+		)
+
+		BeforeEach(func() {
+			checkRequest.Source.RequireFiles = []string{"*.pivotal"}
+
+			productFiles = map[int][]pivnet.ProductFile{
+				1: {{ID: 10, Name: "tile", AWSObjectKey: "product/files/some.pivotal"}},
+				2: {{ID: 20, Name: "docs", AWSObjectKey: "product/files/some.pdf"}},
+			}
+			fileGroups = map[int][]pivnet.FileGroup{
+				3: {
+					{
+						ID: 300,
+						ProductFiles: []pivnet.ProductFile{
+							{ID: 30, Name: "tile", AWSObjectKey: "product/files/other.pivotal"},
+						},
+					},
+				},
+			}
+
+			productFilesErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakePivnetClient.ProductFilesForReleaseStub = func(productSlug string, releaseID int) ([]pivnet.ProductFile, error) {
+				return productFiles[releaseID], productFilesErr
+			}
+			fakePivnetClient.FileGroupsForReleaseStub = func(productSlug string, releaseID int) ([]pivnet.FileGroup, error) {
+				return fileGroups[releaseID], nil
+			}
+			fakeFilter.ProductFileKeysByGlobsStub = func(pfs []pivnet.ProductFile, globs []string) ([]pivnet.ProductFile, error) {
+				var matched []pivnet.ProductFile
+				for _, pf := range pfs {
+					if filepath.Ext(pf.AWSObjectKey) == ".pivotal" {
+						matched = append(matched, pf)
+					}
+				}
+				if len(matched) == 0 {
+					return nil, errors.New("no match for glob(s)")
+				}
+				return matched, nil
+			}
+		})
+
+		It("returns only the releases with a product file matching the globs", func() {
+			checkRequest.Version = concourse.Version{
+				ProductVersion: versionsWithFingerprints[2], // 1.2.4#time3
+			}
+
+			response, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(response).To(HaveLen(2))
+			Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[2]))
+			Expect(response[1].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+
+			_, invokedGlobs := fakeFilter.ProductFileKeysByGlobsArgsForCall(0)
+			Expect(invokedGlobs).To(Equal([]string{"*.pivotal"}))
+		})
+
+		It("caches the product files of each release by when its files were last updated", func() {
+			checkRequest.Source.CacheTTL = "5m"
+
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			values := cachedValues(fakeCache)
+			Expect(keysContaining(values, "/product-files/")).To(HaveLen(3))
+
+			key := fmt.Sprintf("%s/%s/product-files/1/time1", pivnet.DefaultHost, productSlug)
+			Expect(values).To(HaveKeyWithValue(key, []pivnet.ProductFile{
+				{ID: 10, Name: "tile", AWSObjectKey: "product/files/some.pivotal"},
+			}))
+		})
+
+		Context("when the product files of a release are cached", func() {
+			BeforeEach(func() {
+				checkRequest.Source.CacheTTL = "5m"
+			})
+
+			JustBeforeEach(func() {
+				fakeCache.GetStub = func(key string, value interface{}) (bool, error) {
+					if key != fmt.Sprintf("%s/%s/product-files/2/time2", pivnet.DefaultHost, productSlug) {
+						return false, nil
+					}
+
+					*(value.(*[]pivnet.ProductFile)) = []pivnet.ProductFile{
+						{ID: 21, AWSObjectKey: "product/files/cached.pivotal"},
+					}
+					return true, nil
+				}
+			})
+
+			It("uses the cached product files", func() {
+				response, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ProductFilesForReleaseCallCount()).To(Equal(2))
+				Expect(keysContaining(cachedValues(fakeCache), "/product-files/")).To(HaveLen(2))
+
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+			})
+		})
+
+		Context("when reading the cache returns an error", func() {
+			BeforeEach(func() {
+				fakeCache.GetReturns(false, errors.New("some cache error"))
+			})
+
+			It("gets the product files from Pivnet", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ProductFilesForReleaseCallCount()).To(Equal(3))
+			})
+		})
+
+		Context("when getting product files returns an error", func() {
+			BeforeEach(func() {
+				productFilesErr = errors.New("some product files error")
+			})
+
+			It("returns the error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(Equal(productFilesErr))
+			})
+		})
+	})
+
+	Describe("caching", func() {
+		var releasesKey string
+
+		BeforeEach(func() {
+			releasesKey = fmt.Sprintf("%s/%s/releases", pivnet.DefaultHost, productSlug)
+			checkRequest.Source.CacheTTL = "5m"
+		})
+
+		It("prunes old cache entries", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCache.PruneCallCount()).To(Equal(1))
+			Expect(fakeCache.PruneArgsForCall(0)).To(Equal(30 * 24 * time.Hour))
+		})
+
+		It("caches the release types and the releases", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			values := cachedValues(fakeCache)
+			Expect(values).To(HaveKeyWithValue(pivnet.DefaultHost+"/release-types", releaseTypes))
+			Expect(values).To(HaveKeyWithValue(releasesKey, allReleases))
+		})
+
+		It("reads the releases from the cache if they were cached within cache_ttl", func() {
+			_, err := checkCommand.Run(checkRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			for i := 0; i < fakeCache.GetFreshCallCount(); i++ {
+				key, maxAge, _ := fakeCache.GetFreshArgsForCall(i)
+				if key == releasesKey {
+					found = true
+					Expect(maxAge).To(Equal(5 * time.Minute))
+				}
+			}
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the releases are cached", func() {
+			BeforeEach(func() {
+				fakeCache.GetFreshStub = func(key string, maxAge time.Duration, value interface{}) (bool, error) {
+					if key != releasesKey {
+						return false, nil
+					}
+
+					*(value.(*[]pivnet.Release)) = []pivnet.Release{allReleases[1]}
+					return true, nil
+				}
+			})
+
+			It("does not get the releases from Pivnet", func() {
+				response, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ReleasesForProductSlugCallCount()).To(Equal(0))
+
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[1]))
+			})
+		})
+
+		Context("when cache_ttl is not provided", func() {
+			BeforeEach(func() {
+				checkRequest.Source.CacheTTL = ""
+			})
+
+			It("does not read or write the cache", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ReleaseTypesCallCount()).To(Equal(1))
+				Expect(fakePivnetClient.ReleasesForProductSlugCallCount()).To(Equal(1))
+				Expect(fakeCache.GetCallCount()).To(Equal(0))
+				Expect(fakeCache.GetFreshCallCount()).To(Equal(0))
+				Expect(fakeCache.SetCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when cache_ttl is zero", func() {
+			BeforeEach(func() {
+				checkRequest.Source.CacheTTL = "0s"
+			})
+
+			It("does not read or write the cache", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ReleasesForProductSlugCallCount()).To(Equal(1))
+				Expect(fakeCache.GetFreshCallCount()).To(Equal(0))
+				Expect(fakeCache.SetCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when cache_ttl is invalid", func() {
+			BeforeEach(func() {
+				checkRequest.Source.CacheTTL = "not a duration"
+			})
+
+			It("returns an error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when reading and writing the cache returns errors", func() {
+			BeforeEach(func() {
+				fakeCache.GetFreshReturns(false, errors.New("some read error"))
+				fakeCache.SetReturns(errors.New("some write error"))
+				fakeCache.PruneReturns(errors.New("some prune error"))
+			})
+
+			It("gets everything from Pivnet without error", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakePivnetClient.ReleaseTypesCallCount()).To(Equal(1))
+				Expect(fakePivnetClient.ReleasesForProductSlugCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when filtering needs per-release lookups", func() {
+			BeforeEach(func() {
+				checkRequest.Source.UpgradableFrom = "1.2.2"
+
+				for i := range allReleases {
+					allReleases[i].UpdatedAt = fmt.Sprintf("updated-%d", allReleases[i].ID)
+				}
+				allReleases[2].UpdatedAt = ""
+
+				fakePivnetClient.ReleaseUpgradePathsStub = func(productSlug string, releaseID int) ([]pivnet.ReleaseUpgradePath, error) {
+					if releaseID != 1 {
+						return nil, nil
+					}
+					return []pivnet.ReleaseUpgradePath{
+						{Release: pivnet.UpgradePathRelease{Version: "1.2.2"}},
+					}, nil
+				}
+			})
+
+			It("caches them by when each release was last updated", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				values := cachedValues(fakeCache)
+				Expect(values).To(HaveKey(fmt.Sprintf("%s/%s/upgrade-paths/1/updated-1", pivnet.DefaultHost, productSlug)))
+				Expect(values).To(HaveKey(fmt.Sprintf("%s/%s/upgrade-paths/2/updated-2", pivnet.DefaultHost, productSlug)))
+				Expect(keysContaining(values, "/upgrade-paths/3/")).To(BeEmpty())
+			})
+
+			Context("when the lookups for a release are cached", func() {
+				BeforeEach(func() {
+					fakeCache.GetStub = func(key string, value interface{}) (bool, error) {
+						if key != fmt.Sprintf("%s/%s/upgrade-paths/1/updated-1", pivnet.DefaultHost, productSlug) {
+							return false, nil
+						}
+
+						*(value.(*[]pivnet.ReleaseUpgradePath)) = []pivnet.ReleaseUpgradePath{
+							{Release: pivnet.UpgradePathRelease{Version: "1.2.2"}},
+						}
+						return true, nil
+					}
+				})
+
+				It("only looks up the other releases", func() {
+					response, err := checkCommand.Run(checkRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePivnetClient.ReleaseUpgradePathsCallCount()).To(Equal(2))
+
+					Expect(response).To(HaveLen(1))
+					Expect(response[0].ProductVersion).To(Equal(versionsWithFingerprints[0]))
+				})
+			})
+		})
+
+		Context("when an endpoint is provided", func() {
+			BeforeEach(func() {
+				checkRequest.Source.Endpoint = "https://example.com"
+			})
+
+			It("scopes the cache keys by the endpoint", func() {
+				_, err := checkCommand.Run(checkRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cachedValues(fakeCache)).To(HaveKey("https://example.com/" + productSlug + "/releases"))
+			})
+		})
+	})
 })
+
+func cachedValues(fakeCache *checkfakes.FakeCache) map[string]interface{} {
+	values := map[string]interface{}{}
+	for i := 0; i < fakeCache.SetCallCount(); i++ {
+		key, value := fakeCache.SetArgsForCall(i)
+		values[key] = value
+	}
+	return values
+}
+
+func keysContaining(values map[string]interface{}, substring string) []string {
+	var keys []string
+	for k := range values {
+		if strings.Contains(k, substring) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,348 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package checkfakes
+
+import (
+	"sync"
+	"time"
+)
+
+type FakeCache struct {
+	GetStub        func(string, interface{}) (bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
+	getReturns struct {
+		result1 bool
+		result2 error
+	}
+	getReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GetFreshStub        func(string, time.Duration, interface{}) (bool, error)
+	getFreshMutex       sync.RWMutex
+	getFreshArgsForCall []struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 interface{}
+	}
+	getFreshReturns struct {
+		result1 bool
+		result2 error
+	}
+	getFreshReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PruneStub        func(time.Duration) error
+	pruneMutex       sync.RWMutex
+	pruneArgsForCall []struct {
+		arg1 time.Duration
+	}
+	pruneReturns struct {
+		result1 error
+	}
+	pruneReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetStub        func(string, interface{}) error
+	setMutex       sync.RWMutex
+	setArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
+	setReturns struct {
+		result1 error
+	}
+	setReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCache) Get(arg1 string, arg2 interface{}) (bool, error) {
+	fake.getMutex.Lock()
+	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.GetStub
+	fakeReturns := fake.getReturns
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
+	fake.getMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCache) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeCache) GetCalls(stub func(string, interface{}) (bool, error)) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = stub
+}
+
+func (fake *FakeCache) GetArgsForCall(i int) (string, interface{}) {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	argsForCall := fake.getArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCache) GetReturns(result1 bool, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) GetReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.getMutex.Lock()
+	defer fake.getMutex.Unlock()
+	fake.GetStub = nil
+	if fake.getReturnsOnCall == nil {
+		fake.getReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.getReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) GetFresh(arg1 string, arg2 time.Duration, arg3 interface{}) (bool, error) {
+	fake.getFreshMutex.Lock()
+	ret, specificReturn := fake.getFreshReturnsOnCall[len(fake.getFreshArgsForCall)]
+	fake.getFreshArgsForCall = append(fake.getFreshArgsForCall, struct {
+		arg1 string
+		arg2 time.Duration
+		arg3 interface{}
+	}{arg1, arg2, arg3})
+	stub := fake.GetFreshStub
+	fakeReturns := fake.getFreshReturns
+	fake.recordInvocation("GetFresh", []interface{}{arg1, arg2, arg3})
+	fake.getFreshMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCache) GetFreshCallCount() int {
+	fake.getFreshMutex.RLock()
+	defer fake.getFreshMutex.RUnlock()
+	return len(fake.getFreshArgsForCall)
+}
+
+func (fake *FakeCache) GetFreshCalls(stub func(string, time.Duration, interface{}) (bool, error)) {
+	fake.getFreshMutex.Lock()
+	defer fake.getFreshMutex.Unlock()
+	fake.GetFreshStub = stub
+}
+
+func (fake *FakeCache) GetFreshArgsForCall(i int) (string, time.Duration, interface{}) {
+	fake.getFreshMutex.RLock()
+	defer fake.getFreshMutex.RUnlock()
+	argsForCall := fake.getFreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCache) GetFreshReturns(result1 bool, result2 error) {
+	fake.getFreshMutex.Lock()
+	defer fake.getFreshMutex.Unlock()
+	fake.GetFreshStub = nil
+	fake.getFreshReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) GetFreshReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.getFreshMutex.Lock()
+	defer fake.getFreshMutex.Unlock()
+	fake.GetFreshStub = nil
+	if fake.getFreshReturnsOnCall == nil {
+		fake.getFreshReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.getFreshReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) Prune(arg1 time.Duration) error {
+	fake.pruneMutex.Lock()
+	ret, specificReturn := fake.pruneReturnsOnCall[len(fake.pruneArgsForCall)]
+	fake.pruneArgsForCall = append(fake.pruneArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	stub := fake.PruneStub
+	fakeReturns := fake.pruneReturns
+	fake.recordInvocation("Prune", []interface{}{arg1})
+	fake.pruneMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCache) PruneCallCount() int {
+	fake.pruneMutex.RLock()
+	defer fake.pruneMutex.RUnlock()
+	return len(fake.pruneArgsForCall)
+}
+
+func (fake *FakeCache) PruneCalls(stub func(time.Duration) error) {
+	fake.pruneMutex.Lock()
+	defer fake.pruneMutex.Unlock()
+	fake.PruneStub = stub
+}
+
+func (fake *FakeCache) PruneArgsForCall(i int) time.Duration {
+	fake.pruneMutex.RLock()
+	defer fake.pruneMutex.RUnlock()
+	argsForCall := fake.pruneArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCache) PruneReturns(result1 error) {
+	fake.pruneMutex.Lock()
+	defer fake.pruneMutex.Unlock()
+	fake.PruneStub = nil
+	fake.pruneReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCache) PruneReturnsOnCall(i int, result1 error) {
+	fake.pruneMutex.Lock()
+	defer fake.pruneMutex.Unlock()
+	fake.PruneStub = nil
+	if fake.pruneReturnsOnCall == nil {
+		fake.pruneReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pruneReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCache) Set(arg1 string, arg2 interface{}) error {
+	fake.setMutex.Lock()
+	ret, specificReturn := fake.setReturnsOnCall[len(fake.setArgsForCall)]
+	fake.setArgsForCall = append(fake.setArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	stub := fake.SetStub
+	fakeReturns := fake.setReturns
+	fake.recordInvocation("Set", []interface{}{arg1, arg2})
+	fake.setMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCache) SetCallCount() int {
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	return len(fake.setArgsForCall)
+}
+
+func (fake *FakeCache) SetCalls(stub func(string, interface{}) error) {
+	fake.setMutex.Lock()
+	defer fake.setMutex.Unlock()
+	fake.SetStub = stub
+}
+
+func (fake *FakeCache) SetArgsForCall(i int) (string, interface{}) {
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	argsForCall := fake.setArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCache) SetReturns(result1 error) {
+	fake.setMutex.Lock()
+	defer fake.setMutex.Unlock()
+	fake.SetStub = nil
+	fake.setReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCache) SetReturnsOnCall(i int, result1 error) {
+	fake.setMutex.Lock()
+	defer fake.setMutex.Unlock()
+	fake.SetStub = nil
+	if fake.setReturnsOnCall == nil {
+		fake.setReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.getFreshMutex.RLock()
+	defer fake.getFreshMutex.RUnlock()
+	fake.pruneMutex.RLock()
+	defer fake.pruneMutex.RUnlock()
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
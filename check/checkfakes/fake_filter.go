@@ -8,6 +8,20 @@ import (
 )
 
 type FakeFilter struct {
+	ProductFileKeysByGlobsStub        func([]pivnet.ProductFile, []string) ([]pivnet.ProductFile, error)
+	productFileKeysByGlobsMutex       sync.RWMutex
+	productFileKeysByGlobsArgsForCall []struct {
+		arg1 []pivnet.ProductFile
+		arg2 []string
+	}
+	productFileKeysByGlobsReturns struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	productFileKeysByGlobsReturnsOnCall map[int]struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
 	ReleasesByReleaseTypeStub        func([]pivnet.Release, pivnet.ReleaseType) ([]pivnet.Release, error)
 	releasesByReleaseTypeMutex       sync.RWMutex
 	releasesByReleaseTypeArgsForCall []struct {
@@ -40,6 +54,81 @@ type FakeFilter struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeFilter) ProductFileKeysByGlobs(arg1 []pivnet.ProductFile, arg2 []string) ([]pivnet.ProductFile, error) {
+	var arg1Copy []pivnet.ProductFile
+	if arg1 != nil {
+		arg1Copy = make([]pivnet.ProductFile, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.productFileKeysByGlobsMutex.Lock()
+	ret, specificReturn := fake.productFileKeysByGlobsReturnsOnCall[len(fake.productFileKeysByGlobsArgsForCall)]
+	fake.productFileKeysByGlobsArgsForCall = append(fake.productFileKeysByGlobsArgsForCall, struct {
+		arg1 []pivnet.ProductFile
+		arg2 []string
+	}{arg1Copy, arg2Copy})
+	stub := fake.ProductFileKeysByGlobsStub
+	fakeReturns := fake.productFileKeysByGlobsReturns
+	fake.recordInvocation("ProductFileKeysByGlobs", []interface{}{arg1Copy, arg2Copy})
+	fake.productFileKeysByGlobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFilter) ProductFileKeysByGlobsCallCount() int {
+	fake.productFileKeysByGlobsMutex.RLock()
+	defer fake.productFileKeysByGlobsMutex.RUnlock()
+	return len(fake.productFileKeysByGlobsArgsForCall)
+}
+
+func (fake *FakeFilter) ProductFileKeysByGlobsCalls(stub func([]pivnet.ProductFile, []string) ([]pivnet.ProductFile, error)) {
+	fake.productFileKeysByGlobsMutex.Lock()
+	defer fake.productFileKeysByGlobsMutex.Unlock()
+	fake.ProductFileKeysByGlobsStub = stub
+}
+
+func (fake *FakeFilter) ProductFileKeysByGlobsArgsForCall(i int) ([]pivnet.ProductFile, []string) {
+	fake.productFileKeysByGlobsMutex.RLock()
+	defer fake.productFileKeysByGlobsMutex.RUnlock()
+	argsForCall := fake.productFileKeysByGlobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFilter) ProductFileKeysByGlobsReturns(result1 []pivnet.ProductFile, result2 error) {
+	fake.productFileKeysByGlobsMutex.Lock()
+	defer fake.productFileKeysByGlobsMutex.Unlock()
+	fake.ProductFileKeysByGlobsStub = nil
+	fake.productFileKeysByGlobsReturns = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFilter) ProductFileKeysByGlobsReturnsOnCall(i int, result1 []pivnet.ProductFile, result2 error) {
+	fake.productFileKeysByGlobsMutex.Lock()
+	defer fake.productFileKeysByGlobsMutex.Unlock()
+	fake.ProductFileKeysByGlobsStub = nil
+	if fake.productFileKeysByGlobsReturnsOnCall == nil {
+		fake.productFileKeysByGlobsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ProductFile
+			result2 error
+		})
+	}
+	fake.productFileKeysByGlobsReturnsOnCall[i] = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeFilter) ReleasesByReleaseType(arg1 []pivnet.Release, arg2 pivnet.ReleaseType) ([]pivnet.Release, error) {
 	var arg1Copy []pivnet.Release
 	if arg1 != nil {
@@ -183,6 +272,8 @@ func (fake *FakeFilter) ReleasesByVersionReturnsOnCall(i int, result1 []pivnet.R
 func (fake *FakeFilter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.productFileKeysByGlobsMutex.RLock()
+	defer fake.productFileKeysByGlobsMutex.RUnlock()
 	fake.releasesByReleaseTypeMutex.RLock()
 	defer fake.releasesByReleaseTypeMutex.RUnlock()
 	fake.releasesByVersionMutex.RLock()
@@ -8,6 +8,48 @@ import (
 )
 
 type FakePivnetClient struct {
+	DependencySpecifiersStub        func(string, int) ([]pivnet.DependencySpecifier, error)
+	dependencySpecifiersMutex       sync.RWMutex
+	dependencySpecifiersArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	dependencySpecifiersReturns struct {
+		result1 []pivnet.DependencySpecifier
+		result2 error
+	}
+	dependencySpecifiersReturnsOnCall map[int]struct {
+		result1 []pivnet.DependencySpecifier
+		result2 error
+	}
+	FileGroupsForReleaseStub        func(string, int) ([]pivnet.FileGroup, error)
+	fileGroupsForReleaseMutex       sync.RWMutex
+	fileGroupsForReleaseArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	fileGroupsForReleaseReturns struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	fileGroupsForReleaseReturnsOnCall map[int]struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	ProductFilesForReleaseStub        func(string, int) ([]pivnet.ProductFile, error)
+	productFilesForReleaseMutex       sync.RWMutex
+	productFilesForReleaseArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	productFilesForReleaseReturns struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	productFilesForReleaseReturnsOnCall map[int]struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
 	ReleaseTypesStub        func() ([]pivnet.ReleaseType, error)
 	releaseTypesMutex       sync.RWMutex
 	releaseTypesArgsForCall []struct {
@@ -20,6 +62,20 @@ type FakePivnetClient struct {
 		result1 []pivnet.ReleaseType
 		result2 error
 	}
+	ReleaseUpgradePathsStub        func(string, int) ([]pivnet.ReleaseUpgradePath, error)
+	releaseUpgradePathsMutex       sync.RWMutex
+	releaseUpgradePathsArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	releaseUpgradePathsReturns struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}
+	releaseUpgradePathsReturnsOnCall map[int]struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}
 	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
 	releasesForProductSlugMutex       sync.RWMutex
 	releasesForProductSlugArgsForCall []struct {
@@ -33,10 +89,219 @@ type FakePivnetClient struct {
 		result1 []pivnet.Release
 		result2 error
 	}
+	UpgradePathSpecifiersStub        func(string, int) ([]pivnet.UpgradePathSpecifier, error)
+	upgradePathSpecifiersMutex       sync.RWMutex
+	upgradePathSpecifiersArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	upgradePathSpecifiersReturns struct {
+		result1 []pivnet.UpgradePathSpecifier
+		result2 error
+	}
+	upgradePathSpecifiersReturnsOnCall map[int]struct {
+		result1 []pivnet.UpgradePathSpecifier
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePivnetClient) DependencySpecifiers(arg1 string, arg2 int) ([]pivnet.DependencySpecifier, error) {
+	fake.dependencySpecifiersMutex.Lock()
+	ret, specificReturn := fake.dependencySpecifiersReturnsOnCall[len(fake.dependencySpecifiersArgsForCall)]
+	fake.dependencySpecifiersArgsForCall = append(fake.dependencySpecifiersArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.DependencySpecifiersStub
+	fakeReturns := fake.dependencySpecifiersReturns
+	fake.recordInvocation("DependencySpecifiers", []interface{}{arg1, arg2})
+	fake.dependencySpecifiersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) DependencySpecifiersCallCount() int {
+	fake.dependencySpecifiersMutex.RLock()
+	defer fake.dependencySpecifiersMutex.RUnlock()
+	return len(fake.dependencySpecifiersArgsForCall)
+}
+
+func (fake *FakePivnetClient) DependencySpecifiersCalls(stub func(string, int) ([]pivnet.DependencySpecifier, error)) {
+	fake.dependencySpecifiersMutex.Lock()
+	defer fake.dependencySpecifiersMutex.Unlock()
+	fake.DependencySpecifiersStub = stub
+}
+
+func (fake *FakePivnetClient) DependencySpecifiersArgsForCall(i int) (string, int) {
+	fake.dependencySpecifiersMutex.RLock()
+	defer fake.dependencySpecifiersMutex.RUnlock()
+	argsForCall := fake.dependencySpecifiersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) DependencySpecifiersReturns(result1 []pivnet.DependencySpecifier, result2 error) {
+	fake.dependencySpecifiersMutex.Lock()
+	defer fake.dependencySpecifiersMutex.Unlock()
+	fake.DependencySpecifiersStub = nil
+	fake.dependencySpecifiersReturns = struct {
+		result1 []pivnet.DependencySpecifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) DependencySpecifiersReturnsOnCall(i int, result1 []pivnet.DependencySpecifier, result2 error) {
+	fake.dependencySpecifiersMutex.Lock()
+	defer fake.dependencySpecifiersMutex.Unlock()
+	fake.DependencySpecifiersStub = nil
+	if fake.dependencySpecifiersReturnsOnCall == nil {
+		fake.dependencySpecifiersReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.DependencySpecifier
+			result2 error
+		})
+	}
+	fake.dependencySpecifiersReturnsOnCall[i] = struct {
+		result1 []pivnet.DependencySpecifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) FileGroupsForRelease(arg1 string, arg2 int) ([]pivnet.FileGroup, error) {
+	fake.fileGroupsForReleaseMutex.Lock()
+	ret, specificReturn := fake.fileGroupsForReleaseReturnsOnCall[len(fake.fileGroupsForReleaseArgsForCall)]
+	fake.fileGroupsForReleaseArgsForCall = append(fake.fileGroupsForReleaseArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.FileGroupsForReleaseStub
+	fakeReturns := fake.fileGroupsForReleaseReturns
+	fake.recordInvocation("FileGroupsForRelease", []interface{}{arg1, arg2})
+	fake.fileGroupsForReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) FileGroupsForReleaseCallCount() int {
+	fake.fileGroupsForReleaseMutex.RLock()
+	defer fake.fileGroupsForReleaseMutex.RUnlock()
+	return len(fake.fileGroupsForReleaseArgsForCall)
+}
+
+func (fake *FakePivnetClient) FileGroupsForReleaseCalls(stub func(string, int) ([]pivnet.FileGroup, error)) {
+	fake.fileGroupsForReleaseMutex.Lock()
+	defer fake.fileGroupsForReleaseMutex.Unlock()
+	fake.FileGroupsForReleaseStub = stub
+}
+
+func (fake *FakePivnetClient) FileGroupsForReleaseArgsForCall(i int) (string, int) {
+	fake.fileGroupsForReleaseMutex.RLock()
+	defer fake.fileGroupsForReleaseMutex.RUnlock()
+	argsForCall := fake.fileGroupsForReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) FileGroupsForReleaseReturns(result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsForReleaseMutex.Lock()
+	defer fake.fileGroupsForReleaseMutex.Unlock()
+	fake.FileGroupsForReleaseStub = nil
+	fake.fileGroupsForReleaseReturns = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) FileGroupsForReleaseReturnsOnCall(i int, result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsForReleaseMutex.Lock()
+	defer fake.fileGroupsForReleaseMutex.Unlock()
+	fake.FileGroupsForReleaseStub = nil
+	if fake.fileGroupsForReleaseReturnsOnCall == nil {
+		fake.fileGroupsForReleaseReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.FileGroup
+			result2 error
+		})
+	}
+	fake.fileGroupsForReleaseReturnsOnCall[i] = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ProductFilesForRelease(arg1 string, arg2 int) ([]pivnet.ProductFile, error) {
+	fake.productFilesForReleaseMutex.Lock()
+	ret, specificReturn := fake.productFilesForReleaseReturnsOnCall[len(fake.productFilesForReleaseArgsForCall)]
+	fake.productFilesForReleaseArgsForCall = append(fake.productFilesForReleaseArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ProductFilesForReleaseStub
+	fakeReturns := fake.productFilesForReleaseReturns
+	fake.recordInvocation("ProductFilesForRelease", []interface{}{arg1, arg2})
+	fake.productFilesForReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) ProductFilesForReleaseCallCount() int {
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
+	return len(fake.productFilesForReleaseArgsForCall)
+}
+
+func (fake *FakePivnetClient) ProductFilesForReleaseCalls(stub func(string, int) ([]pivnet.ProductFile, error)) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = stub
+}
+
+func (fake *FakePivnetClient) ProductFilesForReleaseArgsForCall(i int) (string, int) {
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
+	argsForCall := fake.productFilesForReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) ProductFilesForReleaseReturns(result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = nil
+	fake.productFilesForReleaseReturns = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ProductFilesForReleaseReturnsOnCall(i int, result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = nil
+	if fake.productFilesForReleaseReturnsOnCall == nil {
+		fake.productFilesForReleaseReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ProductFile
+			result2 error
+		})
+	}
+	fake.productFilesForReleaseReturnsOnCall[i] = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePivnetClient) ReleaseTypes() ([]pivnet.ReleaseType, error) {
 	fake.releaseTypesMutex.Lock()
 	ret, specificReturn := fake.releaseTypesReturnsOnCall[len(fake.releaseTypesArgsForCall)]
@@ -93,6 +358,71 @@ func (fake *FakePivnetClient) ReleaseTypesReturnsOnCall(i int, result1 []pivnet.
 	}{result1, result2}
 }
 
+func (fake *FakePivnetClient) ReleaseUpgradePaths(arg1 string, arg2 int) ([]pivnet.ReleaseUpgradePath, error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	ret, specificReturn := fake.releaseUpgradePathsReturnsOnCall[len(fake.releaseUpgradePathsArgsForCall)]
+	fake.releaseUpgradePathsArgsForCall = append(fake.releaseUpgradePathsArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ReleaseUpgradePathsStub
+	fakeReturns := fake.releaseUpgradePathsReturns
+	fake.recordInvocation("ReleaseUpgradePaths", []interface{}{arg1, arg2})
+	fake.releaseUpgradePathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsCallCount() int {
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
+	return len(fake.releaseUpgradePathsArgsForCall)
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsCalls(stub func(string, int) ([]pivnet.ReleaseUpgradePath, error)) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = stub
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsArgsForCall(i int) (string, int) {
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
+	argsForCall := fake.releaseUpgradePathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsReturns(result1 []pivnet.ReleaseUpgradePath, result2 error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = nil
+	fake.releaseUpgradePathsReturns = struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsReturnsOnCall(i int, result1 []pivnet.ReleaseUpgradePath, result2 error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = nil
+	if fake.releaseUpgradePathsReturnsOnCall == nil {
+		fake.releaseUpgradePathsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ReleaseUpgradePath
+			result2 error
+		})
+	}
+	fake.releaseUpgradePathsReturnsOnCall[i] = struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePivnetClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
 	fake.releasesForProductSlugMutex.Lock()
 	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
@@ -157,13 +487,88 @@ func (fake *FakePivnetClient) ReleasesForProductSlugReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakePivnetClient) UpgradePathSpecifiers(arg1 string, arg2 int) ([]pivnet.UpgradePathSpecifier, error) {
+	fake.upgradePathSpecifiersMutex.Lock()
+	ret, specificReturn := fake.upgradePathSpecifiersReturnsOnCall[len(fake.upgradePathSpecifiersArgsForCall)]
+	fake.upgradePathSpecifiersArgsForCall = append(fake.upgradePathSpecifiersArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.UpgradePathSpecifiersStub
+	fakeReturns := fake.upgradePathSpecifiersReturns
+	fake.recordInvocation("UpgradePathSpecifiers", []interface{}{arg1, arg2})
+	fake.upgradePathSpecifiersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) UpgradePathSpecifiersCallCount() int {
+	fake.upgradePathSpecifiersMutex.RLock()
+	defer fake.upgradePathSpecifiersMutex.RUnlock()
+	return len(fake.upgradePathSpecifiersArgsForCall)
+}
+
+func (fake *FakePivnetClient) UpgradePathSpecifiersCalls(stub func(string, int) ([]pivnet.UpgradePathSpecifier, error)) {
+	fake.upgradePathSpecifiersMutex.Lock()
+	defer fake.upgradePathSpecifiersMutex.Unlock()
+	fake.UpgradePathSpecifiersStub = stub
+}
+
+func (fake *FakePivnetClient) UpgradePathSpecifiersArgsForCall(i int) (string, int) {
+	fake.upgradePathSpecifiersMutex.RLock()
+	defer fake.upgradePathSpecifiersMutex.RUnlock()
+	argsForCall := fake.upgradePathSpecifiersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) UpgradePathSpecifiersReturns(result1 []pivnet.UpgradePathSpecifier, result2 error) {
+	fake.upgradePathSpecifiersMutex.Lock()
+	defer fake.upgradePathSpecifiersMutex.Unlock()
+	fake.UpgradePathSpecifiersStub = nil
+	fake.upgradePathSpecifiersReturns = struct {
+		result1 []pivnet.UpgradePathSpecifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) UpgradePathSpecifiersReturnsOnCall(i int, result1 []pivnet.UpgradePathSpecifier, result2 error) {
+	fake.upgradePathSpecifiersMutex.Lock()
+	defer fake.upgradePathSpecifiersMutex.Unlock()
+	fake.UpgradePathSpecifiersStub = nil
+	if fake.upgradePathSpecifiersReturnsOnCall == nil {
+		fake.upgradePathSpecifiersReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.UpgradePathSpecifier
+			result2 error
+		})
+	}
+	fake.upgradePathSpecifiersReturnsOnCall[i] = struct {
+		result1 []pivnet.UpgradePathSpecifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePivnetClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.dependencySpecifiersMutex.RLock()
+	defer fake.dependencySpecifiersMutex.RUnlock()
+	fake.fileGroupsForReleaseMutex.RLock()
+	defer fake.fileGroupsForReleaseMutex.RUnlock()
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
 	fake.releaseTypesMutex.RLock()
 	defer fake.releaseTypesMutex.RUnlock()
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
 	fake.releasesForProductSlugMutex.RLock()
 	defer fake.releasesForProductSlugMutex.RUnlock()
+	fake.upgradePathSpecifiersMutex.RLock()
+	defer fake.upgradePathSpecifiersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
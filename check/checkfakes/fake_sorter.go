@@ -5,32 +5,21 @@ import (
 	"sync"
 
 	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
 )
 
 type FakeSorter struct {
-	SortByLastUpdatedStub        func([]pivnet.Release) ([]pivnet.Release, error)
-	sortByLastUpdatedMutex       sync.RWMutex
-	sortByLastUpdatedArgsForCall []struct {
+	SortStub        func([]pivnet.Release, []concourse.SortBy) ([]pivnet.Release, error)
+	sortMutex       sync.RWMutex
+	sortArgsForCall []struct {
 		arg1 []pivnet.Release
+		arg2 []concourse.SortBy
 	}
-	sortByLastUpdatedReturns struct {
+	sortReturns struct {
 		result1 []pivnet.Release
 		result2 error
 	}
-	sortByLastUpdatedReturnsOnCall map[int]struct {
-		result1 []pivnet.Release
-		result2 error
-	}
-	SortBySemverStub        func([]pivnet.Release) ([]pivnet.Release, error)
-	sortBySemverMutex       sync.RWMutex
-	sortBySemverArgsForCall []struct {
-		arg1 []pivnet.Release
-	}
-	sortBySemverReturns struct {
-		result1 []pivnet.Release
-		result2 error
-	}
-	sortBySemverReturnsOnCall map[int]struct {
+	sortReturnsOnCall map[int]struct {
 		result1 []pivnet.Release
 		result2 error
 	}
@@ -38,92 +27,29 @@ type FakeSorter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeSorter) SortByLastUpdated(arg1 []pivnet.Release) ([]pivnet.Release, error) {
+func (fake *FakeSorter) Sort(arg1 []pivnet.Release, arg2 []concourse.SortBy) ([]pivnet.Release, error) {
 	var arg1Copy []pivnet.Release
 	if arg1 != nil {
 		arg1Copy = make([]pivnet.Release, len(arg1))
 		copy(arg1Copy, arg1)
 	}
-	fake.sortByLastUpdatedMutex.Lock()
-	ret, specificReturn := fake.sortByLastUpdatedReturnsOnCall[len(fake.sortByLastUpdatedArgsForCall)]
-	fake.sortByLastUpdatedArgsForCall = append(fake.sortByLastUpdatedArgsForCall, struct {
-		arg1 []pivnet.Release
-	}{arg1Copy})
-	stub := fake.SortByLastUpdatedStub
-	fakeReturns := fake.sortByLastUpdatedReturns
-	fake.recordInvocation("SortByLastUpdated", []interface{}{arg1Copy})
-	fake.sortByLastUpdatedMutex.Unlock()
-	if stub != nil {
-		return stub(arg1)
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *FakeSorter) SortByLastUpdatedCallCount() int {
-	fake.sortByLastUpdatedMutex.RLock()
-	defer fake.sortByLastUpdatedMutex.RUnlock()
-	return len(fake.sortByLastUpdatedArgsForCall)
-}
-
-func (fake *FakeSorter) SortByLastUpdatedCalls(stub func([]pivnet.Release) ([]pivnet.Release, error)) {
-	fake.sortByLastUpdatedMutex.Lock()
-	defer fake.sortByLastUpdatedMutex.Unlock()
-	fake.SortByLastUpdatedStub = stub
-}
-
-func (fake *FakeSorter) SortByLastUpdatedArgsForCall(i int) []pivnet.Release {
-	fake.sortByLastUpdatedMutex.RLock()
-	defer fake.sortByLastUpdatedMutex.RUnlock()
-	argsForCall := fake.sortByLastUpdatedArgsForCall[i]
-	return argsForCall.arg1
-}
-
-func (fake *FakeSorter) SortByLastUpdatedReturns(result1 []pivnet.Release, result2 error) {
-	fake.sortByLastUpdatedMutex.Lock()
-	defer fake.sortByLastUpdatedMutex.Unlock()
-	fake.SortByLastUpdatedStub = nil
-	fake.sortByLastUpdatedReturns = struct {
-		result1 []pivnet.Release
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeSorter) SortByLastUpdatedReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
-	fake.sortByLastUpdatedMutex.Lock()
-	defer fake.sortByLastUpdatedMutex.Unlock()
-	fake.SortByLastUpdatedStub = nil
-	if fake.sortByLastUpdatedReturnsOnCall == nil {
-		fake.sortByLastUpdatedReturnsOnCall = make(map[int]struct {
-			result1 []pivnet.Release
-			result2 error
-		})
-	}
-	fake.sortByLastUpdatedReturnsOnCall[i] = struct {
-		result1 []pivnet.Release
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeSorter) SortBySemver(arg1 []pivnet.Release) ([]pivnet.Release, error) {
-	var arg1Copy []pivnet.Release
-	if arg1 != nil {
-		arg1Copy = make([]pivnet.Release, len(arg1))
-		copy(arg1Copy, arg1)
+	var arg2Copy []concourse.SortBy
+	if arg2 != nil {
+		arg2Copy = make([]concourse.SortBy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
-	fake.sortBySemverMutex.Lock()
-	ret, specificReturn := fake.sortBySemverReturnsOnCall[len(fake.sortBySemverArgsForCall)]
-	fake.sortBySemverArgsForCall = append(fake.sortBySemverArgsForCall, struct {
+	fake.sortMutex.Lock()
+	ret, specificReturn := fake.sortReturnsOnCall[len(fake.sortArgsForCall)]
+	fake.sortArgsForCall = append(fake.sortArgsForCall, struct {
 		arg1 []pivnet.Release
-	}{arg1Copy})
-	stub := fake.SortBySemverStub
-	fakeReturns := fake.sortBySemverReturns
-	fake.recordInvocation("SortBySemver", []interface{}{arg1Copy})
-	fake.sortBySemverMutex.Unlock()
+		arg2 []concourse.SortBy
+	}{arg1Copy, arg2Copy})
+	stub := fake.SortStub
+	fakeReturns := fake.sortReturns
+	fake.recordInvocation("Sort", []interface{}{arg1Copy, arg2Copy})
+	fake.sortMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -131,46 +57,46 @@ func (fake *FakeSorter) SortBySemver(arg1 []pivnet.Release) ([]pivnet.Release, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeSorter) SortBySemverCallCount() int {
-	fake.sortBySemverMutex.RLock()
-	defer fake.sortBySemverMutex.RUnlock()
-	return len(fake.sortBySemverArgsForCall)
+func (fake *FakeSorter) SortCallCount() int {
+	fake.sortMutex.RLock()
+	defer fake.sortMutex.RUnlock()
+	return len(fake.sortArgsForCall)
 }
 
-func (fake *FakeSorter) SortBySemverCalls(stub func([]pivnet.Release) ([]pivnet.Release, error)) {
-	fake.sortBySemverMutex.Lock()
-	defer fake.sortBySemverMutex.Unlock()
-	fake.SortBySemverStub = stub
+func (fake *FakeSorter) SortCalls(stub func([]pivnet.Release, []concourse.SortBy) ([]pivnet.Release, error)) {
+	fake.sortMutex.Lock()
+	defer fake.sortMutex.Unlock()
+	fake.SortStub = stub
 }
 
-func (fake *FakeSorter) SortBySemverArgsForCall(i int) []pivnet.Release {
-	fake.sortBySemverMutex.RLock()
-	defer fake.sortBySemverMutex.RUnlock()
-	argsForCall := fake.sortBySemverArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeSorter) SortArgsForCall(i int) ([]pivnet.Release, []concourse.SortBy) {
+	fake.sortMutex.RLock()
+	defer fake.sortMutex.RUnlock()
+	argsForCall := fake.sortArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeSorter) SortBySemverReturns(result1 []pivnet.Release, result2 error) {
-	fake.sortBySemverMutex.Lock()
-	defer fake.sortBySemverMutex.Unlock()
-	fake.SortBySemverStub = nil
-	fake.sortBySemverReturns = struct {
+func (fake *FakeSorter) SortReturns(result1 []pivnet.Release, result2 error) {
+	fake.sortMutex.Lock()
+	defer fake.sortMutex.Unlock()
+	fake.SortStub = nil
+	fake.sortReturns = struct {
 		result1 []pivnet.Release
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeSorter) SortBySemverReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
-	fake.sortBySemverMutex.Lock()
-	defer fake.sortBySemverMutex.Unlock()
-	fake.SortBySemverStub = nil
-	if fake.sortBySemverReturnsOnCall == nil {
-		fake.sortBySemverReturnsOnCall = make(map[int]struct {
+func (fake *FakeSorter) SortReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.sortMutex.Lock()
+	defer fake.sortMutex.Unlock()
+	fake.SortStub = nil
+	if fake.sortReturnsOnCall == nil {
+		fake.sortReturnsOnCall = make(map[int]struct {
 			result1 []pivnet.Release
 			result2 error
 		})
 	}
-	fake.sortBySemverReturnsOnCall[i] = struct {
+	fake.sortReturnsOnCall[i] = struct {
 		result1 []pivnet.Release
 		result2 error
 	}{result1, result2}
@@ -179,10 +105,8 @@ func (fake *FakeSorter) SortBySemverReturnsOnCall(i int, result1 []pivnet.Releas
 func (fake *FakeSorter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.sortByLastUpdatedMutex.RLock()
-	defer fake.sortByLastUpdatedMutex.RUnlock()
-	fake.sortBySemverMutex.RLock()
-	defer fake.sortBySemverMutex.RUnlock()
+	fake.sortMutex.RLock()
+	defer fake.sortMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
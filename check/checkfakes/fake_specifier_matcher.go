@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package checkfakes
+
+import (
+	"sync"
+)
+
+type FakeSpecifierMatcher struct {
+	MatchesStub        func(string, string) (bool, error)
+	matchesMutex       sync.RWMutex
+	matchesArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	matchesReturns struct {
+		result1 bool
+		result2 error
+	}
+	matchesReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSpecifierMatcher) Matches(arg1 string, arg2 string) (bool, error) {
+	fake.matchesMutex.Lock()
+	ret, specificReturn := fake.matchesReturnsOnCall[len(fake.matchesArgsForCall)]
+	fake.matchesArgsForCall = append(fake.matchesArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.MatchesStub
+	fakeReturns := fake.matchesReturns
+	fake.recordInvocation("Matches", []interface{}{arg1, arg2})
+	fake.matchesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpecifierMatcher) MatchesCallCount() int {
+	fake.matchesMutex.RLock()
+	defer fake.matchesMutex.RUnlock()
+	return len(fake.matchesArgsForCall)
+}
+
+func (fake *FakeSpecifierMatcher) MatchesCalls(stub func(string, string) (bool, error)) {
+	fake.matchesMutex.Lock()
+	defer fake.matchesMutex.Unlock()
+	fake.MatchesStub = stub
+}
+
+func (fake *FakeSpecifierMatcher) MatchesArgsForCall(i int) (string, string) {
+	fake.matchesMutex.RLock()
+	defer fake.matchesMutex.RUnlock()
+	argsForCall := fake.matchesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpecifierMatcher) MatchesReturns(result1 bool, result2 error) {
+	fake.matchesMutex.Lock()
+	defer fake.matchesMutex.Unlock()
+	fake.MatchesStub = nil
+	fake.matchesReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpecifierMatcher) MatchesReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.matchesMutex.Lock()
+	defer fake.matchesMutex.Unlock()
+	fake.MatchesStub = nil
+	if fake.matchesReturnsOnCall == nil {
+		fake.matchesReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.matchesReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpecifierMatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.matchesMutex.RLock()
+	defer fake.matchesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSpecifierMatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,172 @@
+package check
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+)
+
+// releasesUpgradableFrom returns the releases that can be upgraded to from
+// the provided version, according to either their upgrade paths or their
+// upgrade path specifiers.
+func (c *CheckCommand) releasesUpgradableFrom(
+	lookups *releaseLookups,
+	releases []pivnet.Release,
+	version string,
+) ([]pivnet.Release, error) {
+	filtered := make([]pivnet.Release, 0)
+
+	for _, r := range releases {
+		upgradable, err := c.upgradableFrom(lookups, r, version)
+		if err != nil {
+			return nil, err
+		}
+
+		if upgradable {
+			filtered = append(filtered, r)
+		} else {
+			c.logger.Debug(fmt.Sprintf(
+				"Release '%s' cannot be upgraded to from version: '%s'",
+				r.Version,
+				version,
+			))
+		}
+	}
+
+	return filtered, nil
+}
+
+func (c *CheckCommand) upgradableFrom(lookups *releaseLookups, release pivnet.Release, version string) (bool, error) {
+	upgradePaths, err := lookups.ReleaseUpgradePaths(release)
+	if err != nil {
+		return false, err
+	}
+
+	for _, u := range upgradePaths {
+		if u.Release.Version == version {
+			return true, nil
+		}
+	}
+
+	upgradePathSpecifiers, err := lookups.UpgradePathSpecifiers(release)
+	if err != nil {
+		return false, err
+	}
+
+	for _, u := range upgradePathSpecifiers {
+		matches, err := c.matcher.Matches(u.Specifier, version)
+		if err != nil {
+			return false, err
+		}
+
+		if matches {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// releasesCompatibleWith returns the releases whose dependency specifiers
+// accept the provided version of each dependent product. A release with
+// several specifiers for the same product accepts a version if any of them
+// match it. Releases that do not declare a dependency on a product are
+// compatible with any version of it.
+func (c *CheckCommand) releasesCompatibleWith(
+	lookups *releaseLookups,
+	releases []pivnet.Release,
+	compatibleWith map[string]string,
+) ([]pivnet.Release, error) {
+	filtered := make([]pivnet.Release, 0)
+
+	for _, r := range releases {
+		compatible, err := c.compatibleWith(lookups, r, compatibleWith)
+		if err != nil {
+			return nil, err
+		}
+
+		if compatible {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (c *CheckCommand) compatibleWith(
+	lookups *releaseLookups,
+	release pivnet.Release,
+	compatibleWith map[string]string,
+) (bool, error) {
+	dependencySpecifiers, err := lookups.DependencySpecifiers(release)
+	if err != nil {
+		return false, err
+	}
+
+	specifiersBySlug := map[string][]string{}
+	for _, d := range dependencySpecifiers {
+		specifiersBySlug[d.Product.Slug] = append(specifiersBySlug[d.Product.Slug], d.Specifier)
+	}
+
+	for productSlug, version := range compatibleWith {
+		specifiers, ok := specifiersBySlug[productSlug]
+		if !ok {
+			continue
+		}
+
+		accepted := false
+		for _, s := range specifiers {
+			matches, err := c.matcher.Matches(s, version)
+			if err != nil {
+				return false, err
+			}
+
+			if matches {
+				accepted = true
+				break
+			}
+		}
+
+		if !accepted {
+			c.logger.Debug(fmt.Sprintf(
+				"Release '%s' is not compatible with '%s' version: '%s' - requires one of: %v",
+				release.Version,
+				productSlug,
+				version,
+				specifiers,
+			))
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// releasesWithFiles returns the releases with at least one product file,
+// either directly or in a file group, matching the globs.
+func (c *CheckCommand) releasesWithFiles(
+	lookups *releaseLookups,
+	releases []pivnet.Release,
+	globs []string,
+) ([]pivnet.Release, error) {
+	filtered := make([]pivnet.Release, 0)
+
+	for _, r := range releases {
+		productFiles, err := lookups.ProductFiles(r)
+		if err != nil {
+			return nil, err
+		}
+
+		// The globs are validated before check runs, so the only error returned
+		// here is that no product file matched.
+		matched, err := c.filter.ProductFileKeysByGlobs(productFiles, globs)
+		if err != nil || len(matched) == 0 {
+			c.logger.Debug(fmt.Sprintf("Release '%s' has no files matching: %v", r.Version, globs))
+			continue
+		}
+
+		filtered = append(filtered, r)
+	}
+
+	return filtered, nil
+}
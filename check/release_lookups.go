@@ -0,0 +1,255 @@
+package check
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+)
+
+const releaseTypesTTL = 24 * time.Hour
+
+// releaseLookups memoizes the requests made during a check so that each is
+// made at most once, and keeps their results in the persistent cache between
+// checks. Cache keys are scoped by endpoint and product slug.
+//
+// Release types and the list of releases are cached for a fixed time, after
+// which the list is fetched again in full, as Pivnet cannot list only the
+// releases updated since a given time. Only lookups for a single release are
+// incremental: they are cached until the release's updated_at changes, so
+// after the list is refreshed they are only repeated for releases that have
+// changed. Nothing is cached between checks when the cache is nil.
+type releaseLookups struct {
+	logger       logger.Logger
+	pivnetClient pivnetClient
+	cache        cache
+	endpoint     string
+	productSlug  string
+
+	upgradePaths          map[int][]pivnet.ReleaseUpgradePath
+	upgradePathSpecifiers map[int][]pivnet.UpgradePathSpecifier
+	dependencySpecifiers  map[int][]pivnet.DependencySpecifier
+	productFiles          map[int][]pivnet.ProductFile
+}
+
+func newReleaseLookups(
+	logger logger.Logger,
+	pivnetClient pivnetClient,
+	cache cache,
+	endpoint string,
+	productSlug string,
+) *releaseLookups {
+	return &releaseLookups{
+		logger:                logger,
+		pivnetClient:          pivnetClient,
+		cache:                 cache,
+		endpoint:              endpoint,
+		productSlug:           productSlug,
+		upgradePaths:          map[int][]pivnet.ReleaseUpgradePath{},
+		upgradePathSpecifiers: map[int][]pivnet.UpgradePathSpecifier{},
+		dependencySpecifiers:  map[int][]pivnet.DependencySpecifier{},
+		productFiles:          map[int][]pivnet.ProductFile{},
+	}
+}
+
+func (l *releaseLookups) ReleaseTypes() ([]pivnet.ReleaseType, error) {
+	key := fmt.Sprintf("%s/release-types", l.endpoint)
+
+	var releaseTypes []pivnet.ReleaseType
+	if l.getFresh(key, releaseTypesTTL, &releaseTypes) {
+		return releaseTypes, nil
+	}
+
+	releaseTypes, err := l.pivnetClient.ReleaseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	l.set(key, releaseTypes)
+	return releaseTypes, nil
+}
+
+// Releases returns every release of the product. The list is fetched in full
+// whenever the cached one is older than ttl, and a ttl of zero disables
+// caching it.
+func (l *releaseLookups) Releases(ttl time.Duration) ([]pivnet.Release, error) {
+	key := fmt.Sprintf("%s/%s/releases", l.endpoint, l.productSlug)
+
+	var releases []pivnet.Release
+	if ttl > 0 && l.getFresh(key, ttl, &releases) {
+		return releases, nil
+	}
+
+	releases, err := l.pivnetClient.ReleasesForProductSlug(l.productSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		l.set(key, releases)
+	}
+	return releases, nil
+}
+
+func (l *releaseLookups) ReleaseUpgradePaths(release pivnet.Release) ([]pivnet.ReleaseUpgradePath, error) {
+	if v, ok := l.upgradePaths[release.ID]; ok {
+		return v, nil
+	}
+
+	key := l.releaseKey("upgrade-paths", release.ID, release.UpdatedAt)
+
+	var v []pivnet.ReleaseUpgradePath
+	if !l.get(key, &v) {
+		var err error
+		v, err = l.pivnetClient.ReleaseUpgradePaths(l.productSlug, release.ID)
+		if err != nil {
+			return nil, err
+		}
+		l.set(key, v)
+	}
+
+	l.upgradePaths[release.ID] = v
+	return v, nil
+}
+
+func (l *releaseLookups) UpgradePathSpecifiers(release pivnet.Release) ([]pivnet.UpgradePathSpecifier, error) {
+	if v, ok := l.upgradePathSpecifiers[release.ID]; ok {
+		return v, nil
+	}
+
+	key := l.releaseKey("upgrade-path-specifiers", release.ID, release.UpdatedAt)
+
+	var v []pivnet.UpgradePathSpecifier
+	if !l.get(key, &v) {
+		var err error
+		v, err = l.pivnetClient.UpgradePathSpecifiers(l.productSlug, release.ID)
+		if err != nil {
+			return nil, err
+		}
+		l.set(key, v)
+	}
+
+	l.upgradePathSpecifiers[release.ID] = v
+	return v, nil
+}
+
+func (l *releaseLookups) DependencySpecifiers(release pivnet.Release) ([]pivnet.DependencySpecifier, error) {
+	if v, ok := l.dependencySpecifiers[release.ID]; ok {
+		return v, nil
+	}
+
+	key := l.releaseKey("dependency-specifiers", release.ID, release.UpdatedAt)
+
+	var v []pivnet.DependencySpecifier
+	if !l.get(key, &v) {
+		var err error
+		v, err = l.pivnetClient.DependencySpecifiers(l.productSlug, release.ID)
+		if err != nil {
+			return nil, err
+		}
+		l.set(key, v)
+	}
+
+	l.dependencySpecifiers[release.ID] = v
+	return v, nil
+}
+
+// ProductFiles returns the product files of the release, including those in
+// its file groups. They are cached until the release's software files are
+// next updated.
+func (l *releaseLookups) ProductFiles(release pivnet.Release) ([]pivnet.ProductFile, error) {
+	if v, ok := l.productFiles[release.ID]; ok {
+		return v, nil
+	}
+
+	key := l.releaseKey("product-files", release.ID, release.SoftwareFilesUpdatedAt)
+
+	var productFiles []pivnet.ProductFile
+	if l.get(key, &productFiles) {
+		l.productFiles[release.ID] = productFiles
+		return productFiles, nil
+	}
+
+	releaseProductFiles, err := l.pivnetClient.ProductFilesForRelease(l.productSlug, release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileGroups, err := l.pivnetClient.FileGroupsForRelease(l.productSlug, release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	all := releaseProductFiles
+	for _, fg := range fileGroups {
+		all = append(all, fg.ProductFiles...)
+	}
+
+	// Only what is needed to match globs is kept, to keep cache entries small.
+	productFiles = make([]pivnet.ProductFile, len(all))
+	for i, pf := range all {
+		productFiles[i] = pivnet.ProductFile{
+			ID:           pf.ID,
+			Name:         pf.Name,
+			AWSObjectKey: pf.AWSObjectKey,
+		}
+	}
+
+	l.productFiles[release.ID] = productFiles
+	l.set(key, productFiles)
+
+	return productFiles, nil
+}
+
+// releaseKey returns the cache key for a lookup of a single release, or an
+// empty key if the release does not say when it was updated.
+func (l *releaseLookups) releaseKey(kind string, releaseID int, updatedAt string) string {
+	if updatedAt == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%d/%s", l.endpoint, l.productSlug, kind, releaseID, updatedAt)
+}
+
+// The cache only ever speeds up a check, so failing to read or write it is
+// logged rather than returned.
+
+func (l *releaseLookups) get(key string, value interface{}) bool {
+	if l.cache == nil || key == "" {
+		return false
+	}
+
+	found, err := l.cache.Get(key, value)
+	if err != nil {
+		l.logger.Info(fmt.Sprintf("Failed to read cache entry '%s': %s", key, err))
+		return false
+	}
+
+	return found
+}
+
+func (l *releaseLookups) getFresh(key string, ttl time.Duration, value interface{}) bool {
+	if l.cache == nil {
+		return false
+	}
+
+	found, err := l.cache.GetFresh(key, ttl, value)
+	if err != nil {
+		l.logger.Info(fmt.Sprintf("Failed to read cache entry '%s': %s", key, err))
+		return false
+	}
+
+	return found
+}
+
+func (l *releaseLookups) set(key string, value interface{}) {
+	if l.cache == nil || key == "" {
+		return
+	}
+
+	err := l.cache.Set(key, value)
+	if err != nil {
+		l.logger.Info(fmt.Sprintf("Failed to write cache entry '%s': %s", key, err))
+	}
+}
@@ -2,21 +2,15 @@ package main
 
 import (
 	"encoding/json"
-	"github.com/pivotal-cf/go-pivnet/v7"
-	"github.com/pivotal-cf/go-pivnet/v7/logger"
-	"github.com/pivotal-cf/go-pivnet/v7/logshim"
-	"github.com/pivotal-cf/pivnet-resource/v3/check"
-	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
-	"github.com/pivotal-cf/pivnet-resource/v3/filter"
-	"github.com/pivotal-cf/pivnet-resource/v3/gp"
-	"github.com/pivotal-cf/pivnet-resource/v3/semver"
-	"github.com/pivotal-cf/pivnet-resource/v3/sorter"
-	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
-	"github.com/pivotal-cf/pivnet-resource/v3/validator"
-	"github.com/robdimsdale/sanitizer"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/runner"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
 )
 
 var (
@@ -24,15 +18,14 @@ var (
 	version string
 )
 
-type AuthResp struct {
-	Token string `json: "token"`
-}
-
 func main() {
 	if version == "" {
 		version = "dev"
 	}
 
+	registry := redact.NewRegistry()
+	log.SetOutput(registry.Writer(os.Stderr))
+
 	var input concourse.CheckRequest
 
 	logFile, err := ioutil.TempFile("", "pivnet-check.log")
@@ -49,48 +42,12 @@ func main() {
 		log.Fatalf("Exiting with error: %s", err)
 	}
 
-	sanitized := concourse.SanitizedSource(input.Source)
-	logger.SetOutput(sanitizer.NewSanitizer(sanitized, logFile))
-
-	verbose := false
-	ls := logshim.NewLogShim(logger, logger, verbose)
-
-	err = validator.NewCheckValidator(input).Validate()
-	if err != nil {
-		log.Fatalf("Exiting with error: %s", err)
-	}
-
-	var endpoint string
-	if input.Source.Endpoint != "" {
-		endpoint = input.Source.Endpoint
-	} else {
-		endpoint = pivnet.DefaultHost
-	}
-
-	apiToken := input.Source.APIToken
-	token := pivnet.NewAccessTokenOrLegacyToken(apiToken, endpoint, input.Source.SkipSSLValidation, "Pivnet Resource")
-
-	client := NewPivnetClientWithToken(
-		token,
-		endpoint,
-		input.Source.SkipSSLValidation,
-		useragent.UserAgent(version, "check", input.Source.ProductSlug),
-		ls,
-	)
+	r := runner.NewRunner(version, logger, logFile, ui.NewUIPrinter(registry.Writer(os.Stderr)), registry)
 
-	f := filter.NewFilter(ls)
+	// The cache is kept next to the log file, in the check container.
+	cacheDir := filepath.Join(filepath.Dir(logFile.Name()), "pivnet-resource-cache")
 
-	semverConverter := semver.NewSemverConverter(ls)
-	s := sorter.NewSorter(ls, semverConverter)
-
-	response, err := check.NewCheckCommand(
-		ls,
-		version,
-		f,
-		client,
-		s,
-		logFile.Name(),
-	).Run(input)
+	response, err := r.Check(input, logFile.Name(), cacheDir)
 	if err != nil {
 		log.Fatalf("Exiting with error: %s", err)
 	}
@@ -100,17 +57,3 @@ func main() {
 		log.Fatalf("Exiting with error: %s", err)
 	}
 }
-
-func NewPivnetClientWithToken(token pivnet.AccessTokenOrLegacyToken, host string, skipSSLValidation bool, userAgent string, logger logger.Logger) *gp.Client {
-	clientConfig := pivnet.ClientConfig{
-		Host:              host,
-		UserAgent:         userAgent,
-		SkipSSLValidation: skipSSLValidation,
-	}
-
-	return gp.NewClient(
-		token,
-		clientConfig,
-		logger,
-	)
-}
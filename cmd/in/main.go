@@ -6,21 +6,10 @@ import (
 	"os"
 
 	"github.com/fatih/color"
-	"github.com/pivotal-cf/go-pivnet/v7"
-	"github.com/pivotal-cf/go-pivnet/v7/logger"
-	"github.com/pivotal-cf/go-pivnet/v7/logshim"
-	"github.com/pivotal-cf/go-pivnet/v7/md5sum"
-	"github.com/pivotal-cf/go-pivnet/v7/sha256sum"
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
-	"github.com/pivotal-cf/pivnet-resource/v3/downloader"
-	"github.com/pivotal-cf/pivnet-resource/v3/filter"
-	"github.com/pivotal-cf/pivnet-resource/v3/gp"
-	"github.com/pivotal-cf/pivnet-resource/v3/in"
-	"github.com/pivotal-cf/pivnet-resource/v3/in/filesystem"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/runner"
 	"github.com/pivotal-cf/pivnet-resource/v3/ui"
-	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
-	"github.com/pivotal-cf/pivnet-resource/v3/validator"
-	"github.com/robdimsdale/sanitizer"
 )
 
 var (
@@ -35,8 +24,11 @@ func main() {
 
 	color.NoColor = false
 
+	registry := redact.NewRegistry()
+	log.SetOutput(registry.Writer(os.Stderr))
+
 	logWriter := os.Stderr
-	uiPrinter := ui.NewUIPrinter(logWriter)
+	uiPrinter := ui.NewUIPrinter(registry.Writer(logWriter))
 
 	logger := log.New(logWriter, "", log.LstdFlags)
 
@@ -59,69 +51,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	sanitized := concourse.SanitizedSource(input.Source)
-	logger.SetOutput(sanitizer.NewSanitizer(sanitized, logWriter))
-
-	verbose := input.Source.Verbose
-	ls := logshim.NewLogShim(logger, logger, verbose)
-
-	ls.Debug("Verbose output enabled")
-	logger.Printf("Creating download directory: %s", downloadDir)
-
-	err = os.MkdirAll(downloadDir, os.ModePerm)
-	if err != nil {
-		uiPrinter.PrintErrorln(err)
-		os.Exit(1)
-	}
+	r := runner.NewRunner(version, logger, logWriter, uiPrinter, registry)
 
-	err = validator.NewInValidator(input).Validate()
-	if err != nil {
-		uiPrinter.PrintErrorln(err)
-		os.Exit(1)
-	}
-
-	var endpoint string
-	if input.Source.Endpoint != "" {
-		endpoint = input.Source.Endpoint
-	} else {
-		endpoint = pivnet.DefaultHost
-	}
-
-	apiToken := input.Source.APIToken
-	token := pivnet.NewAccessTokenOrLegacyToken(apiToken, endpoint, input.Source.SkipSSLValidation, "Pivnet Resource")
-
-	if len(apiToken) < 20 {
-		uiPrinter.PrintDeprecationln("The use of static Pivnet API tokens is deprecated and will be removed. Please see https://network.pivotal.io/docs/api#how-to-authenticate for details.")
-	}
-
-	client := NewPivnetClientWithToken(
-		token,
-		endpoint,
-		input.Source.SkipSSLValidation,
-		useragent.UserAgent(version, "get", input.Source.ProductSlug),
-		ls,
-	)
-
-	d := downloader.NewDownloader(client, downloadDir, ls, logWriter)
-
-	fs := sha256sum.NewFileSummer()
-	md5fs := md5sum.NewFileSummer()
-
-	f := filter.NewFilter(ls)
-
-	fileWriter := filesystem.NewFileWriter(downloadDir, ls)
-	archive := &in.Archive{}
-
-	response, err := in.NewInCommand(
-		ls,
-		client,
-		f,
-		d,
-		fs,
-		md5fs,
-		fileWriter,
-		archive,
-	).Run(input)
+	response, err := r.In(input, downloadDir)
 	if err != nil {
 		uiPrinter.PrintErrorln(err)
 		os.Exit(1)
@@ -133,17 +65,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func NewPivnetClientWithToken(token pivnet.AccessTokenService, host string, skipSSLValidation bool, userAgent string, logger logger.Logger) *gp.Client {
-	clientConfig := pivnet.ClientConfig{
-		Host:              host,
-		UserAgent:         userAgent,
-		SkipSSLValidation: skipSSLValidation,
-	}
-
-	return gp.NewClient(
-		token,
-		clientConfig,
-		logger,
-	)
-}
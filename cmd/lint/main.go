@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
+)
+
+// lint checks metadata files for put without a pipeline, reporting every
+// problem with its line and column.
+func main() {
+	uiPrinter := ui.NewUIPrinter(os.Stderr)
+
+	if len(os.Args) < 2 {
+		uiPrinter.PrintErrorlnf(
+			"not enough args - usage: %s <metadata file>... | %s --schema",
+			os.Args[0],
+			os.Args[0],
+		)
+		os.Exit(1)
+	}
+
+	if os.Args[1] == "--schema" {
+		os.Stdout.Write(metadata.Schema)
+		return
+	}
+
+	failed := false
+	for _, metadataFilepath := range os.Args[1:] {
+		contents, err := ioutil.ReadFile(metadataFilepath)
+		if err != nil {
+			uiPrinter.PrintErrorlnf("%s could not be read: %s", metadataFilepath, err.Error())
+			failed = true
+			continue
+		}
+
+		_, deprecations, err := metadata.Lint(contents)
+		for _, deprecation := range deprecations {
+			uiPrinter.PrintDeprecationln(fmt.Sprintf("%s: %s", metadataFilepath, deprecation))
+		}
+
+		if problems, ok := err.(metadata.Problems); ok {
+			for _, problem := range problems {
+				uiPrinter.PrintErrorlnf("%s: %s", metadataFilepath, problem)
+			}
+			failed = true
+			continue
+		}
+
+		if err != nil {
+			uiPrinter.PrintErrorlnf("%s could not be parsed: %s", metadataFilepath, err.Error())
+			failed = true
+			continue
+		}
+
+		fmt.Printf("%s: OK\n", metadataFilepath)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
+	"gopkg.in/yaml.v2"
+)
+
+// migrate rewrites a metadata file for put into the current format, printing
+// the resulting YAML to stdout and every change made to stderr.
+func main() {
+	uiPrinter := ui.NewUIPrinter(os.Stderr)
+
+	args := os.Args[1:]
+
+	dropReadOnly := false
+	if len(args) > 0 && args[0] == "--drop-read-only" {
+		dropReadOnly = true
+		args = args[1:]
+	}
+
+	if len(args) != 1 {
+		uiPrinter.PrintErrorlnf(
+			"wrong number of args - usage: %s [--drop-read-only] <metadata file>",
+			os.Args[0],
+		)
+		os.Exit(1)
+	}
+
+	metadataFilepath := args[0]
+	contents, err := ioutil.ReadFile(metadataFilepath)
+	if err != nil {
+		uiPrinter.PrintErrorlnf("%s could not be read: %s", metadataFilepath, err.Error())
+		os.Exit(1)
+	}
+
+	m, err := metadata.Decode(contents)
+	if err != nil {
+		uiPrinter.PrintErrorlnf("%s could not be parsed: %s", metadataFilepath, err.Error())
+		os.Exit(1)
+	}
+
+	m, notes, err := m.Migrate(dropReadOnly)
+	if err != nil {
+		uiPrinter.PrintErrorlnf("%s: %s", metadataFilepath, err.Error())
+		os.Exit(1)
+	}
+
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", metadataFilepath, note)
+	}
+
+	migrated, err := yaml.Marshal(m)
+	if err != nil {
+		uiPrinter.PrintErrorln(err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(migrated)
+}
@@ -2,34 +2,15 @@ package main
 
 import (
 	"encoding/json"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
-	"time"
-
-	"gopkg.in/yaml.v2"
 
 	"github.com/fatih/color"
-	"github.com/pivotal-cf/go-pivnet/v7"
-	"github.com/pivotal-cf/go-pivnet/v7/logger"
-	"github.com/pivotal-cf/go-pivnet/v7/logshim"
-	"github.com/pivotal-cf/go-pivnet/v7/md5sum"
-	"github.com/pivotal-cf/go-pivnet/v7/sha256sum"
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
-	"github.com/pivotal-cf/pivnet-resource/v3/filter"
-	"github.com/pivotal-cf/pivnet-resource/v3/globs"
-	"github.com/pivotal-cf/pivnet-resource/v3/gp"
-	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
-	"github.com/pivotal-cf/pivnet-resource/v3/out"
-	"github.com/pivotal-cf/pivnet-resource/v3/out/release"
-	"github.com/pivotal-cf/pivnet-resource/v3/s3"
-	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/runner"
 	"github.com/pivotal-cf/pivnet-resource/v3/ui"
-	"github.com/pivotal-cf/pivnet-resource/v3/uploader"
-	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
-	"github.com/pivotal-cf/pivnet-resource/v3/validator"
-	"github.com/robdimsdale/sanitizer"
 )
 
 var (
@@ -44,8 +25,11 @@ func main() {
 
 	color.NoColor = false
 
+	registry := redact.NewRegistry()
+	log.SetOutput(registry.Writer(os.Stderr))
+
 	logWriter := os.Stderr
-	uiPrinter := ui.NewUIPrinter(logWriter)
+	uiPrinter := ui.NewUIPrinter(registry.Writer(logWriter))
 
 	logger := log.New(logWriter, "", log.LstdFlags|log.Lmicroseconds)
 
@@ -74,226 +58,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	var m metadata.Metadata
-	if input.Params.MetadataFile == "" {
-		uiPrinter.PrintErrorlnf("params.metadata_file must be provided")
-		os.Exit(1)
-	}
-
-	metadataFilepath := filepath.Join(sourcesDir, input.Params.MetadataFile)
-	metadataBytes, err := ioutil.ReadFile(metadataFilepath)
-	if err != nil {
-		uiPrinter.PrintErrorlnf("params.metadata_file could not be read: %s", err.Error())
-		os.Exit(1)
-	}
-
-	err = yaml.Unmarshal(metadataBytes, &m)
-	if err != nil {
-		uiPrinter.PrintErrorlnf("params.metadata_file could not be parsed: %s", err.Error())
-		os.Exit(1)
-	}
-
-	deprecations, err := m.Validate()
-	if err != nil {
-		uiPrinter.PrintErrorlnf("params.metadata_file is invalid: %s", err.Error())
-		os.Exit(1)
-	}
-
-	sanitized := concourse.SanitizedSource(input.Source)
-	logger.SetOutput(sanitizer.NewSanitizer(sanitized, logWriter))
-
-	verbose := input.Source.Verbose
-	ls := logshim.NewLogShim(logger, logger, verbose)
-	ls.Debug("Verbose output enabled")
-
-	var endpoint string
-	if input.Source.Endpoint != "" {
-		endpoint = input.Source.Endpoint
-	} else {
-		endpoint = pivnet.DefaultHost
-	}
-
-	apiToken := input.Source.APIToken
-	token := pivnet.NewAccessTokenOrLegacyToken(apiToken, endpoint, input.Source.SkipSSLValidation, "Pivnet Resource")
-
-	if len(apiToken) < 20 {
-		uiPrinter.PrintDeprecationln("The use of static Pivnet API tokens is deprecated and will be removed. Please see https://network.pivotal.io/docs/api#how-to-authenticate for details.")
-	}
-
-	client := NewPivnetClientWithToken(
-		token,
-		endpoint,
-		input.Source.SkipSSLValidation,
-		useragent.UserAgent(version, "put", input.Source.ProductSlug),
-		ls,
-	)
-
-	federationToken, err := client.GetFederationToken(input.Source.ProductSlug)
-	if err != nil {
-		uiPrinter.PrintErrorlnf("Unable to generate Federation Token")
-		os.Exit(1)
-	}
-
-	s3Client := s3.NewClient(s3.NewClientConfig{
-		AccessKeyID:       federationToken.AccessKeyID,
-		SecretAccessKey:   federationToken.SecretAccessKey,
-		SessionToken:      federationToken.SessionToken,
-		RegionName:        federationToken.Region,
-		Bucket:            federationToken.Bucket,
-		Stderr:            os.Stderr,
-		Logger:            ls,
-		SkipSSLValidation: input.Source.SkipSSLValidation,
-		FileSizeGetter:    s3.FileSizeGetter{},
-	})
-
-	prefixFetcher := uploader.NewPrefixFetcher(client, input.Source.ProductSlug)
-	filePrefix, err := prefixFetcher.GetPrefix()
-	if err != nil {
-		uiPrinter.PrintErrorlnf("Could not find product prefix")
-		os.Exit(1)
-	}
-
-	uploaderClient := uploader.NewClient(uploader.Config{
-		FilepathPrefix: filePrefix,
-		SourcesDir:     sourcesDir,
-		Transport:      s3Client,
-	})
-
-	globber := globs.NewGlobber(globs.GlobberConfig{
-		FileGlob:   input.Params.FileGlob,
-		SourcesDir: sourcesDir,
-		Logger:     ls,
-	})
-
-	skipUpload := input.Params.FileGlob == ""
-
-	for _, deprecation := range deprecations {
-		uiPrinter.PrintDeprecationln(deprecation)
-	}
-
-	validation := validator.NewOutValidator(input)
-	semverConverter := semver.NewSemverConverter(ls)
-	sha256Summer := sha256sum.NewFileSummer()
-	md5summer := md5sum.NewFileSummer()
-
-	f := filter.NewFilter(ls)
-
-	releaseCreator := release.NewReleaseCreator(
-		client,
-		semverConverter,
-		ls,
-		m,
-		input.Params,
-		input.Source,
-		sourcesDir,
-		input.Source.ProductSlug,
-	)
-
-	releaseFinder := release.NewReleaseFinder(
-		client,
-		input.Source.ProductSlug,
-	)
+	r := runner.NewRunner(version, logger, logWriter, uiPrinter, registry)
 
-	asyncTimeout := 1 * time.Hour
-	pollFrequency := 5 * time.Second
-	releaseUploader := release.NewReleaseUploader(
-		uploaderClient,
-		client,
-		ls,
-		sha256Summer,
-		md5summer,
-		m,
-		sourcesDir,
-		input.Source.ProductSlug,
-		asyncTimeout,
-		pollFrequency,
-		input.Params.SkipProductFilePolling,
-	)
-
-	releaseUserGroupsUpdater := release.NewUserGroupsUpdater(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-	)
-
-	releaseFileGroupsAdder := release.NewReleaseFileGroupsAdder(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-	)
-
-	releaseArtifactReferencesAdder := release.NewReleaseArtifactReferencesAdder(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-		5*time.Second,
-		time.Hour,
-	)
-
-	releaseDependenciesAdder := release.NewReleaseDependenciesAdder(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-	)
-
-	dependencySpecifiersCreator := release.NewDependencySpecifiersCreator(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-	)
-
-	releaseUpgradePathsAdder := release.NewReleaseUpgradePathsAdder(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-		f,
-	)
-
-	upgradePathSpecifiersCreator := release.NewUpgradePathSpecifiersCreator(
-		ls,
-		client,
-		m,
-		input.Source.ProductSlug,
-	)
-
-	releaseFinalizer := release.NewFinalizer(
-		client,
-		ls,
-		input.Params,
-		m,
-		sourcesDir,
-		input.Source.ProductSlug,
-	)
-
-	outCmd := out.NewOutCommand(out.OutCommandConfig{
-		Logger:                         ls,
-		OutDir:                         outDir,
-		SourcesDir:                     sourcesDir,
-		GlobClient:                     globber,
-		Validation:                     validation,
-		Creator:                        releaseCreator,
-		Finder:                         releaseFinder,
-		Uploader:                       releaseUploader,
-		UserGroupsUpdater:              releaseUserGroupsUpdater,
-		ReleaseFileGroupsAdder:         releaseFileGroupsAdder,
-		ReleaseArtifactReferencesAdder: releaseArtifactReferencesAdder,
-		ReleaseDependenciesAdder:       releaseDependenciesAdder,
-		DependencySpecifiersCreator:    dependencySpecifiersCreator,
-		ReleaseUpgradePathsAdder:       releaseUpgradePathsAdder,
-		UpgradePathSpecifiersCreator:   upgradePathSpecifiersCreator,
-		Finalizer:                      releaseFinalizer,
-		M:                              m,
-		SkipUpload:                     skipUpload,
-		FilesOnly:                      m.ExistingRelease != nil,
-	})
-
-	response, err := outCmd.Run(input)
+	response, err := r.Out(input, sourcesDir, outDir)
 	if err != nil {
 		uiPrinter.PrintErrorln(err)
 		os.Exit(1)
@@ -305,17 +72,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-func NewPivnetClientWithToken(token pivnet.AccessTokenOrLegacyToken, host string, skipSSLValidation bool, userAgent string, logger logger.Logger) *gp.Client {
-	clientConfig := pivnet.ClientConfig{
-		Host:              host,
-		UserAgent:         userAgent,
-		SkipSSLValidation: skipSSLValidation,
-	}
-
-	return gp.NewClient(
-		token,
-		clientConfig,
-		logger,
-	)
-}
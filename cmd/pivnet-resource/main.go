@@ -0,0 +1,323 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/runner"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
+)
+
+var (
+	// version is deliberately left uninitialized so it can be set at compile-time
+	version string
+)
+
+const usage = `usage: %[1]s check [flags]
+       %[1]s get [flags] <download directory>
+       %[1]s put [flags] <sources directory>
+
+Runs check, get or put outside of Concourse. Source and params are read from
+the file given with -config, which has the same structure as the JSON sent by
+Concourse, and are overridden by flags. The API token defaults to
+$PIVNET_API_TOKEN.
+
+Run '%[1]s <command> -h' for the flags of a command.
+`
+
+// pivnet-resource runs check, get and put from the command line, wired the
+// same way as the Concourse entrypoints.
+func main() {
+	if version == "" {
+		version = "dev"
+	}
+
+	registry := redact.NewRegistry()
+	log.SetOutput(registry.Writer(os.Stderr))
+
+	uiPrinter := ui.NewUIPrinter(registry.Writer(os.Stderr))
+
+	err := run(os.Args, uiPrinter, registry)
+	if err != nil {
+		uiPrinter.PrintErrorln(err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, uiPrinter *ui.UIPrinter, registry *redact.Registry) error {
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, usage, filepath.Base(args[0]))
+		return fmt.Errorf("a command must be provided")
+	}
+
+	var command func(runner.Runner, []string) error
+	switch args[1] {
+	case "check":
+		command = check
+	case "get", "in":
+		command = get
+	case "put", "out":
+		command = put
+	case "-h", "-help", "--help", "help":
+		fmt.Fprintf(os.Stderr, usage, filepath.Base(args[0]))
+		return nil
+	default:
+		return fmt.Errorf("unknown command '%s' - must be one of: check, get, put", args[1])
+	}
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	logger.Printf("PivNet Resource version: %s", version)
+
+	err := command(runner.NewRunner(version, logger, os.Stderr, uiPrinter, registry), args[2:])
+	if err == flag.ErrHelp {
+		return nil
+	}
+	return err
+}
+
+func check(r runner.Runner, args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	config := fs.String("config", "", "YAML or JSON file with 'source' and optionally 'version'")
+	productVersion := fs.String("version", "", "version to check from")
+	cacheDir := fs.String("cache-dir", "", "directory of the cache kept when cache_ttl is set (default <user cache directory>/pivnet-resource)")
+	sourceFlags := addSourceFlags(fs)
+	jsonOutput := fs.Bool("json", false, "print the response as JSON, as sent to Concourse")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		return fmt.Errorf("unexpected args: %s", strings.Join(fs.Args(), " "))
+	}
+
+	var input concourse.CheckRequest
+	if *config != "" {
+		err = runner.ReadRequest(*config, &input)
+		if err != nil {
+			return err
+		}
+	}
+
+	sourceFlags.apply(fs, &input.Source)
+	if isSet(fs, "version") {
+		input.Version.ProductVersion = *productVersion
+	}
+
+	// check removes other log files next to its own, so it is given a
+	// directory of its own.
+	logDir, err := ioutil.TempDir("", "pivnet-resource-check")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(logDir)
+
+	// Unlike the log file, the cache is kept between runs. Without a user
+	// cache directory it is kept for this run only, as failing to cache never
+	// fails a check.
+	if *cacheDir == "" {
+		*cacheDir = filepath.Join(logDir, "pivnet-resource-cache")
+
+		userCacheDir, err := os.UserCacheDir()
+		if err == nil {
+			*cacheDir = filepath.Join(userCacheDir, "pivnet-resource")
+		}
+	}
+
+	response, err := r.Check(input, filepath.Join(logDir, "pivnet-check.log"), *cacheDir)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(os.Stdout).Encode(response)
+	}
+
+	for _, v := range response {
+		fmt.Println(v.ProductVersion)
+	}
+	return nil
+}
+
+func get(r runner.Runner, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	config := fs.String("config", "", "YAML or JSON file with 'source', 'version' and 'params'")
+	productVersion := fs.String("version", "", "version to get, e.g. '1.2.3' or '1.2.3#<fingerprint>'")
+	var globs stringsFlag
+	fs.Var(&globs, "glob", "glob of the product files to download (can be repeated)")
+	unpack := fs.Bool("unpack", false, "unpack the downloaded files")
+	sourceFlags := addSourceFlags(fs)
+	jsonOutput := fs.Bool("json", false, "print the response as JSON, as sent to Concourse")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("a download directory must be provided")
+	}
+
+	var input concourse.InRequest
+	if *config != "" {
+		err = runner.ReadRequest(*config, &input)
+		if err != nil {
+			return err
+		}
+	}
+
+	sourceFlags.apply(fs, &input.Source)
+	if isSet(fs, "version") {
+		input.Version.ProductVersion = *productVersion
+	}
+	if isSet(fs, "glob") {
+		input.Params.Globs = globs
+	}
+	if isSet(fs, "unpack") {
+		input.Params.Unpack = *unpack
+	}
+
+	response, err := r.In(input, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(os.Stdout).Encode(response)
+	}
+
+	printResponse(input.Source.ProductSlug, response.Version, response.Metadata)
+	return nil
+}
+
+func put(r runner.Runner, args []string) error {
+	fs := flag.NewFlagSet("put", flag.ContinueOnError)
+	config := fs.String("config", "", "YAML or JSON file with 'source' and 'params'")
+	fileGlob := fs.String("file-glob", "", "glob of the files to upload, relative to the sources directory")
+	metadataFile := fs.String("metadata-file", "", "metadata file, relative to the sources directory")
+	sourceFlags := addSourceFlags(fs)
+	jsonOutput := fs.Bool("json", false, "print the response as JSON, as sent to Concourse")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("a sources directory must be provided")
+	}
+
+	var input concourse.OutRequest
+	if *config != "" {
+		err = runner.ReadRequest(*config, &input)
+		if err != nil {
+			return err
+		}
+	}
+
+	sourceFlags.apply(fs, &input.Source)
+	if isSet(fs, "file-glob") {
+		input.Params.FileGlob = *fileGlob
+	}
+	if isSet(fs, "metadata-file") {
+		input.Params.MetadataFile = *metadataFile
+	}
+
+	outDir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	if err != nil {
+		return err
+	}
+
+	response, err := r.Out(input, fs.Arg(0), outDir)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return json.NewEncoder(os.Stdout).Encode(response)
+	}
+
+	printResponse(input.Source.ProductSlug, response.Version, response.Metadata)
+	return nil
+}
+
+type sourceFlags struct {
+	productSlug       *string
+	apiToken          *string
+	endpoint          *string
+	productVersion    *string
+	skipSSLValidation *bool
+	verbose           *bool
+}
+
+func addSourceFlags(fs *flag.FlagSet) sourceFlags {
+	return sourceFlags{
+		productSlug:       fs.String("product-slug", "", "slug of the product"),
+		apiToken:          fs.String("api-token", "", "Pivnet API token (default $PIVNET_API_TOKEN)"),
+		endpoint:          fs.String("endpoint", "", "Pivnet endpoint"),
+		productVersion:    fs.String("product-version", "", "regex of the product versions"),
+		skipSSLValidation: fs.Bool("skip-ssl-verification", false, "skip SSL verification"),
+		verbose:           fs.Bool("verbose", false, "enable verbose output"),
+	}
+}
+
+func (f sourceFlags) apply(fs *flag.FlagSet, source *concourse.Source) {
+	if isSet(fs, "product-slug") {
+		source.ProductSlug = *f.productSlug
+	}
+	if isSet(fs, "api-token") {
+		source.APIToken = *f.apiToken
+	}
+	if source.APIToken == "" {
+		source.APIToken = os.Getenv("PIVNET_API_TOKEN")
+	}
+	if isSet(fs, "endpoint") {
+		source.Endpoint = *f.endpoint
+	}
+	if isSet(fs, "product-version") {
+		source.ProductVersion = concourse.StringList{*f.productVersion}
+	}
+	if isSet(fs, "skip-ssl-verification") {
+		source.SkipSSLValidation = *f.skipSSLValidation
+	}
+	if isSet(fs, "verbose") {
+		source.Verbose = *f.verbose
+	}
+}
+
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func printResponse(productSlug string, v concourse.Version, metadata []concourse.Metadata) {
+	fmt.Printf("%s %s\n", productSlug, v.ProductVersion)
+	for _, m := range metadata {
+		fmt.Printf("  %s: %s\n", m.Name, m.Value)
+	}
+}
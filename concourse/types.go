@@ -1,23 +1,116 @@
 package concourse
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type SortBy string
 
 const (
 	SortByNone        SortBy = "none"
 	SortBySemver      SortBy = "semver"
 	SortByLastUpdated SortBy = "last_updated"
+	SortByReleaseDate SortBy = "release_date"
+	SortByID          SortBy = "id"
+)
+
+// Keys returns the keys of a sort_by that lists several keys separated by
+// commas, e.g. 'semver,release_date'.
+func (s SortBy) Keys() []SortBy {
+	var keys []SortBy
+	for _, k := range strings.Split(string(s), ",") {
+		k = strings.TrimSpace(k)
+		if k != "" {
+			keys = append(keys, SortBy(k))
+		}
+	}
+	return keys
+}
+
+// Known returns whether a single sort key is one of the supported keys.
+func (s SortBy) Known() bool {
+	switch s {
+	case SortByNone, SortBySemver, SortByLastUpdated, SortByReleaseDate, SortByID:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s SortBy) Includes(key SortBy) bool {
+	for _, k := range s.Keys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+type VersionNormalization string
+
+const (
+	VersionNormalizationStrict   VersionNormalization = "strict"
+	VersionNormalizationLenient  VersionNormalization = "lenient"
+	VersionNormalizationFourPart VersionNormalization = "four_part"
+	VersionNormalizationPattern  VersionNormalization = "pattern"
 )
 
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
+)
+
+// StringList is a list of strings that can also be provided as a single
+// string.
+type StringList []string
+
+func (l *StringList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			*l = nil
+		} else {
+			*l = StringList{s}
+		}
+		return nil
+	}
+
+	var list []string
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+
+	*l = list
+	return nil
+}
+
 type Source struct {
-	APIToken          string `json:"api_token"`
-	ProductSlug       string `json:"product_slug"`
-	ProductVersion    string `json:"product_version"`
-	Endpoint          string `json:"endpoint"`
-	ReleaseType       string `json:"release_type"`
-	SortBy            SortBy `json:"sort_by"`
-	SkipSSLValidation bool   `json:"skip_ssl_verification"`
-	CopyMetadata      bool   `json:"copy_metadata"`
-	Verbose           bool   `json:"verbose"`
+	APIToken             string               `json:"api_token"`
+	ProductSlug          string               `json:"product_slug"`
+	ProductVersion       StringList           `json:"product_version"`
+	Endpoint             string               `json:"endpoint"`
+	ReleaseType          StringList           `json:"release_type"`
+	SortBy               SortBy               `json:"sort_by"`
+	UpgradableFrom       string               `json:"upgradable_from"`
+	CompatibleWith       map[string]string    `json:"compatible_with"`
+	RequireFiles         []string             `json:"require_files"`
+	CacheTTL             string               `json:"cache_ttl"`
+	VersionNormalization VersionNormalization `json:"version_normalization"`
+	VersionPattern       string               `json:"version_pattern"`
+	SkipSSLValidation    bool                 `json:"skip_ssl_verification"`
+	CopyMetadata         bool                 `json:"copy_metadata"`
+	Verbose              bool                 `json:"verbose"`
+	LogFormat            LogFormat            `json:"log_format"`
+	CACerts              string               `json:"ca_certs"`
+	ClientCert           string               `json:"client_cert"`
+	ClientKey            string               `json:"client_key"`
+	HTTPProxy            string               `json:"http_proxy"`
+	HTTPSProxy           string               `json:"https_proxy"`
+	NoProxy              string               `json:"no_proxy"`
 }
 
 type CheckRequest struct {
@@ -38,8 +131,25 @@ type InRequest struct {
 }
 
 type InParams struct {
-	Globs  []string `json:"globs"`
-	Unpack bool     `json:"unpack"`
+	Globs             []string            `json:"globs"`
+	Unpack            bool                `json:"unpack"`
+	FetchDependencies bool                `json:"fetch_dependencies"`
+	DependencyGlobs   map[string][]string `json:"dependency_globs"`
+	UpgradeGraph      bool                `json:"upgrade_graph"`
+	UpgradePathFrom   string              `json:"upgrade_path_from"`
+	UpgradePathTo     string              `json:"upgrade_path_to"`
+	Bundle            []BundleProduct     `json:"bundle"`
+	OutputFormat      string              `json:"output_format"`
+	StemcellIaaS      string              `json:"stemcell_iaas"`
+	InspectTiles      bool                `json:"inspect_tiles"`
+}
+
+const OutputFormatOm = "om"
+
+type BundleProduct struct {
+	ProductSlug string   `json:"product_slug"`
+	Version     string   `json:"version"`
+	Globs       []string `json:"globs"`
 }
 
 type InResponse struct {
@@ -62,6 +172,12 @@ type OutParams struct {
 	MetadataFile           string `json:"metadata_file"`
 	SkipProductFilePolling bool   `json:"skip_product_file_polling"`
 	Override               bool   `json:"override"`
+	VerifyTileVersion      bool   `json:"verify_tile_version"`
+	DeriveMetadata         bool   `json:"derive_metadata"`
+	VersionFile            string `json:"version_file"`
+	RenderMetadataFile     bool   `json:"render_metadata_file"`
+	MigrateMetadata        bool   `json:"migrate_metadata"`
+	DropReadOnlyMetadata   bool   `json:"drop_read_only_metadata"`
 }
 
 type OutResponse struct {
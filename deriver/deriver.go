@@ -0,0 +1,306 @@
+package deriver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+)
+
+const (
+	fileTypeSoftware          = "Software"
+	fileTypeDocumentation     = "Documentation"
+	fileTypeOpenSourceLicense = "Open Source License"
+)
+
+//counterfeiter:generate --fake-name FakeTileInspector . tileInspector
+type tileInspector interface {
+	Inspect(tilePath string) (tile.Tile, error)
+}
+
+type Deriver struct {
+	logger      logger.Logger
+	inspector   tileInspector
+	sourcesDir  string
+	versionFile string
+}
+
+func NewDeriver(
+	logger logger.Logger,
+	inspector tileInspector,
+	sourcesDir string,
+	versionFile string,
+) *Deriver {
+	return &Deriver{
+		logger:      logger,
+		inspector:   inspector,
+		sourcesDir:  sourcesDir,
+		versionFile: versionFile,
+	}
+}
+
+// Derive returns the metadata with the values it does not provide filled in
+// from the files to be uploaded:
+//
+// The release version is read from the version file, or else from the
+// product_version of the tiles to be uploaded.
+//
+// Every file to be uploaded gets a product file entry, whose file_type and
+// platforms are inferred from its name, and whose file_version is the release
+// version.
+//
+// A dependency specifier on the stemcell line required by each tile is added,
+// unless one is already present for that stemcell.
+//
+// Values provided in the metadata are never replaced.
+func (d Deriver) Derive(m metadata.Metadata, exactGlobs []string) (metadata.Metadata, error) {
+	tiles, err := d.inspectTiles(exactGlobs)
+	if err != nil {
+		return metadata.Metadata{}, err
+	}
+
+	if m.Release != nil {
+		release := *m.Release
+		m.Release = &release
+
+		if release.Version == "" {
+			version, err := d.deriveVersion(tiles)
+			if err != nil {
+				return metadata.Metadata{}, err
+			}
+			m.Release.Version = version
+		}
+	}
+
+	m.ProductFiles = d.deriveProductFiles(m, exactGlobs)
+	m.DependencySpecifiers = d.deriveStemcellSpecifiers(m.DependencySpecifiers, tiles)
+
+	return m, nil
+}
+
+type inspectedTile struct {
+	file string
+	tile tile.Tile
+}
+
+func (d Deriver) inspectTiles(exactGlobs []string) ([]inspectedTile, error) {
+	var tiles []inspectedTile
+	for _, exactGlob := range exactGlobs {
+		if !strings.HasSuffix(exactGlob, ".pivotal") {
+			continue
+		}
+
+		d.logger.Info(fmt.Sprintf("Deriving metadata from tile: '%s'", exactGlob))
+
+		t, err := d.inspector.Inspect(filepath.Join(d.sourcesDir, exactGlob))
+		if err != nil {
+			return nil, err
+		}
+
+		tiles = append(tiles, inspectedTile{file: exactGlob, tile: t})
+	}
+
+	return tiles, nil
+}
+
+func (d Deriver) deriveVersion(tiles []inspectedTile) (string, error) {
+	if d.versionFile != "" {
+		b, err := ioutil.ReadFile(filepath.Join(d.sourcesDir, d.versionFile))
+		if err != nil {
+			return "", fmt.Errorf("version_file could not be read: %s", err)
+		}
+
+		version := strings.TrimSpace(string(b))
+		if version == "" {
+			return "", fmt.Errorf("version_file: '%s' is empty", d.versionFile)
+		}
+
+		d.logger.Info(fmt.Sprintf("Using version: '%s' from version_file: '%s'", version, d.versionFile))
+		return version, nil
+	}
+
+	var version string
+	for _, t := range tiles {
+		if t.tile.ProductVersion == "" {
+			continue
+		}
+
+		if version != "" && t.tile.ProductVersion != version {
+			return "", fmt.Errorf(
+				"cannot derive version: tiles have different product_version: '%s' and '%s'",
+				version,
+				t.tile.ProductVersion,
+			)
+		}
+		version = t.tile.ProductVersion
+	}
+
+	if version == "" {
+		return "", fmt.Errorf("cannot derive version: no version_file was provided and no tile has a product_version")
+	}
+
+	d.logger.Info(fmt.Sprintf("Using version: '%s' from tile product_version", version))
+	return version, nil
+}
+
+func (d Deriver) deriveProductFiles(m metadata.Metadata, exactGlobs []string) []metadata.ProductFile {
+	productFiles := make([]metadata.ProductFile, len(m.ProductFiles))
+	copy(productFiles, m.ProductFiles)
+
+	for _, exactGlob := range exactGlobs {
+		i := indexOfFile(productFiles, exactGlob)
+		if i < 0 {
+			productFiles = append(productFiles, metadata.ProductFile{File: exactGlob})
+			i = len(productFiles) - 1
+		}
+
+		pf := &productFiles[i]
+
+		if pf.FileType == "" {
+			pf.FileType = FileType(exactGlob)
+		}
+
+		if len(pf.Platforms) == 0 {
+			pf.Platforms = Platforms(exactGlob)
+		}
+
+		if pf.FileVersion == "" && m.Release != nil {
+			pf.FileVersion = m.Release.Version
+		}
+	}
+
+	return productFiles
+}
+
+func (d Deriver) deriveStemcellSpecifiers(
+	specifiers []metadata.DependencySpecifier,
+	tiles []inspectedTile,
+) []metadata.DependencySpecifier {
+	derived := make([]metadata.DependencySpecifier, len(specifiers))
+	copy(derived, specifiers)
+
+	for _, t := range tiles {
+		criteria := t.tile.StemcellCriteria
+		if criteria.OS == "" || criteria.Version == "" {
+			continue
+		}
+
+		productSlug := StemcellProductSlug(criteria.OS)
+		if hasSpecifierFor(derived, productSlug) {
+			continue
+		}
+
+		specifier := "~>" + criteria.Version
+
+		d.logger.Info(fmt.Sprintf(
+			"Adding dependency specifier: '%s' on product: '%s' required by tile: '%s'",
+			specifier,
+			productSlug,
+			t.file,
+		))
+
+		derived = append(derived, metadata.DependencySpecifier{
+			ProductSlug: productSlug,
+			Specifier:   specifier,
+		})
+	}
+
+	return derived
+}
+
+// FileType infers the Tanzu Network file type of a file from its name.
+func FileType(file string) string {
+	base := strings.ToLower(filepath.Base(file))
+
+	for _, token := range tokens(base) {
+		if token == "osl" || token == "license" || token == "licenses" {
+			return fileTypeOpenSourceLicense
+		}
+	}
+
+	switch filepath.Ext(base) {
+	case ".pdf", ".html", ".htm", ".md", ".txt":
+		return fileTypeDocumentation
+	}
+
+	return fileTypeSoftware
+}
+
+// Platforms infers the platforms a file is built for from its name, e.g.
+// 'cli-linux-amd64.tgz' or 'installer.exe'. Tiles and files with no platform
+// in their name have no platforms.
+func Platforms(file string) []string {
+	base := strings.ToLower(filepath.Base(file))
+
+	var linux, windows, mac bool
+	switch filepath.Ext(base) {
+	case ".exe", ".msi":
+		windows = true
+	case ".dmg", ".pkg":
+		mac = true
+	case ".deb", ".rpm":
+		linux = true
+	}
+
+	for _, token := range tokens(base) {
+		switch token {
+		case "linux":
+			linux = true
+		case "windows", "win", "win32", "win64":
+			windows = true
+		case "darwin", "mac", "macos", "osx":
+			mac = true
+		}
+	}
+
+	var platforms []string
+	if linux {
+		platforms = append(platforms, "Linux")
+	}
+	if windows {
+		platforms = append(platforms, "Windows")
+	}
+	if mac {
+		platforms = append(platforms, "Mac")
+	}
+
+	return platforms
+}
+
+// StemcellProductSlug returns the slug of the Tanzu Network product that
+// publishes stemcells for the OS named in a tile's stemcell_criteria.
+func StemcellProductSlug(os string) string {
+	if strings.HasPrefix(os, "windows") {
+		return "stemcells-windows-server"
+	}
+
+	return "stemcells-" + os
+}
+
+func tokens(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+}
+
+func indexOfFile(productFiles []metadata.ProductFile, file string) int {
+	for i, pf := range productFiles {
+		if pf.File == file {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasSpecifierFor(specifiers []metadata.DependencySpecifier, productSlug string) bool {
+	for _, s := range specifiers {
+		if s.ProductSlug == productSlug {
+			return true
+		}
+	}
+	return false
+}
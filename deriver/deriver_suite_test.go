@@ -0,0 +1,13 @@
+package deriver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDeriver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Deriver Suite")
+}
@@ -0,0 +1,267 @@
+package deriver_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/deriver"
+	"github.com/pivotal-cf/pivnet-resource/v3/deriver/deriverfakes"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deriver", func() {
+	var (
+		tempDir string
+
+		fakeInspector *deriverfakes.FakeTileInspector
+
+		versionFile string
+		exactGlobs  []string
+		mdata       metadata.Metadata
+
+		d *deriver.Deriver
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeInspector = &deriverfakes.FakeTileInspector{}
+		fakeInspector.InspectReturns(tile.Tile{
+			Name:           "some-tile",
+			ProductVersion: "2.10.3",
+			StemcellCriteria: tile.StemcellCriteria{
+				OS:      "ubuntu-jammy",
+				Version: "1.123",
+			},
+		}, nil)
+
+		versionFile = ""
+		exactGlobs = []string{"some-tile.pivotal", "cli-linux-amd64.tgz"}
+		mdata = metadata.Metadata{
+			Release: &metadata.Release{
+				ReleaseType: "All-In-One",
+				EULASlug:    "some-eula",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		d = deriver.NewDeriver(
+			logshim.NewLogShim(logger, logger, true),
+			fakeInspector,
+			tempDir,
+			versionFile,
+		)
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("derives the version, product files and stemcell dependency from the files", func() {
+		derived, err := d.Derive(mdata, exactGlobs)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeInspector.InspectCallCount()).To(Equal(1))
+		Expect(fakeInspector.InspectArgsForCall(0)).To(Equal(filepath.Join(tempDir, "some-tile.pivotal")))
+
+		Expect(derived.Release.Version).To(Equal("2.10.3"))
+		Expect(derived.ProductFiles).To(Equal([]metadata.ProductFile{
+			{
+				File:        "some-tile.pivotal",
+				FileType:    "Software",
+				FileVersion: "2.10.3",
+			},
+			{
+				File:        "cli-linux-amd64.tgz",
+				FileType:    "Software",
+				FileVersion: "2.10.3",
+				Platforms:   []string{"Linux"},
+			},
+		}))
+		Expect(derived.DependencySpecifiers).To(Equal([]metadata.DependencySpecifier{
+			{ProductSlug: "stemcells-ubuntu-jammy", Specifier: "~>1.123"},
+		}))
+	})
+
+	It("does not modify the provided metadata", func() {
+		_, err := d.Derive(mdata, exactGlobs)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mdata.Release.Version).To(BeEmpty())
+		Expect(mdata.ProductFiles).To(BeEmpty())
+	})
+
+	Context("when values are provided in the metadata", func() {
+		BeforeEach(func() {
+			mdata.Release.Version = "2.10.3-rc.1"
+			mdata.ProductFiles = []metadata.ProductFile{
+				{
+					File:        "cli-linux-amd64.tgz",
+					Description: "some description",
+					FileType:    "Documentation",
+					FileVersion: "1.0.0",
+					Platforms:   []string{"Windows"},
+				},
+			}
+			mdata.DependencySpecifiers = []metadata.DependencySpecifier{
+				{ProductSlug: "stemcells-ubuntu-jammy", Specifier: "1.200.*"},
+			}
+		})
+
+		It("keeps the provided values", func() {
+			derived, err := d.Derive(mdata, exactGlobs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(derived.Release.Version).To(Equal("2.10.3-rc.1"))
+			Expect(derived.ProductFiles).To(Equal([]metadata.ProductFile{
+				{
+					File:        "cli-linux-amd64.tgz",
+					Description: "some description",
+					FileType:    "Documentation",
+					FileVersion: "1.0.0",
+					Platforms:   []string{"Windows"},
+				},
+				{
+					File:        "some-tile.pivotal",
+					FileType:    "Software",
+					FileVersion: "2.10.3-rc.1",
+				},
+			}))
+			Expect(derived.DependencySpecifiers).To(Equal([]metadata.DependencySpecifier{
+				{ProductSlug: "stemcells-ubuntu-jammy", Specifier: "1.200.*"},
+			}))
+		})
+	})
+
+	Context("when a version file is provided", func() {
+		BeforeEach(func() {
+			versionFile = "version/version"
+
+			err := os.MkdirAll(filepath.Join(tempDir, "version"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(tempDir, versionFile), []byte("3.0.0\n"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reads the version from the file", func() {
+			derived, err := d.Derive(mdata, exactGlobs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(derived.Release.Version).To(Equal("3.0.0"))
+		})
+
+		Context("when the version file does not exist", func() {
+			BeforeEach(func() {
+				versionFile = "missing"
+			})
+
+			It("returns an error", func() {
+				_, err := d.Derive(mdata, exactGlobs)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("version_file could not be read"))
+			})
+		})
+	})
+
+	Context("when there is no tile and no version file", func() {
+		BeforeEach(func() {
+			exactGlobs = []string{"cli-linux-amd64.tgz"}
+		})
+
+		It("returns an error", func() {
+			_, err := d.Derive(mdata, exactGlobs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot derive version"))
+		})
+	})
+
+	Context("when tiles have different versions", func() {
+		BeforeEach(func() {
+			exactGlobs = []string{"a.pivotal", "b.pivotal"}
+			fakeInspector.InspectReturnsOnCall(1, tile.Tile{ProductVersion: "2.11.0"}, nil)
+		})
+
+		It("returns an error", func() {
+			_, err := d.Derive(mdata, exactGlobs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tiles have different product_version: '2.10.3' and '2.11.0'"))
+		})
+	})
+
+	Context("when a tile cannot be inspected", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("inspect error")
+			fakeInspector.InspectReturns(tile.Tile{}, expectedErr)
+		})
+
+		It("returns the error", func() {
+			_, err := d.Derive(mdata, exactGlobs)
+			Expect(err).To(Equal(expectedErr))
+		})
+	})
+
+	Context("when adding files to an existing release", func() {
+		BeforeEach(func() {
+			mdata = metadata.Metadata{
+				ExistingRelease: &metadata.ExistingRelease{ID: 1234},
+			}
+		})
+
+		It("derives product files without a file version", func() {
+			derived, err := d.Derive(mdata, exactGlobs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(derived.Release).To(BeNil())
+			Expect(derived.ProductFiles).To(HaveLen(2))
+			Expect(derived.ProductFiles[0].FileVersion).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("FileType",
+		func(file string, expected string) {
+			Expect(deriver.FileType(file)).To(Equal(expected))
+		},
+		Entry("tile", "dir/product-1.2.3.pivotal", "Software"),
+		Entry("binary", "cli-linux-amd64", "Software"),
+		Entry("documentation", "docs/README.pdf", "Documentation"),
+		Entry("license", "open_source_license_product-1.2.3.txt", "Open Source License"),
+		Entry("osl", "product-osl-1.2.3.txt", "Open Source License"),
+	)
+
+	DescribeTable("Platforms",
+		func(file string, expected []string) {
+			Expect(deriver.Platforms(file)).To(Equal(expected))
+		},
+		Entry("tile", "product-1.2.3.pivotal", nil),
+		Entry("linux", "cli-linux-amd64.tgz", []string{"Linux"}),
+		Entry("windows executable", "installer.exe", []string{"Windows"}),
+		Entry("windows name", "cli_windows_amd64.zip", []string{"Windows"}),
+		Entry("mac", "cli-darwin-arm64.tgz", []string{"Mac"}),
+		Entry("linux in path only", "linux/cli.tgz", nil),
+	)
+
+	DescribeTable("StemcellProductSlug",
+		func(os string, expected string) {
+			Expect(deriver.StemcellProductSlug(os)).To(Equal(expected))
+		},
+		Entry("ubuntu", "ubuntu-jammy", "stemcells-ubuntu-jammy"),
+		Entry("windows", "windows2019", "stemcells-windows-server"),
+	)
+})
@@ -43,9 +43,22 @@ func (d Downloader) Download(
 	productSlug string,
 	releaseID int,
 ) ([]string, error) {
+	return d.DownloadToSubdirectory("", pfs, productSlug, releaseID)
+}
+
+// DownloadToSubdirectory downloads the product files into the provided
+// subdirectory of the download directory.
+func (d Downloader) DownloadToSubdirectory(
+	subdirectory string,
+	pfs []pivnet.ProductFile,
+	productSlug string,
+	releaseID int,
+) ([]string, error) {
+	dir := filepath.Join(d.downloadDir, subdirectory)
+
 	d.logger.Debug("Ensuring download directory exists")
 
-	err := os.MkdirAll(d.downloadDir, os.ModePerm)
+	err := os.MkdirAll(dir, os.ModePerm)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +68,7 @@ func (d Downloader) Download(
 		parts := strings.Split(pf.AWSObjectKey, "/")
 		fileName := parts[len(parts)-1]
 
-		downloadPath := filepath.Join(d.downloadDir, fileName)
+		downloadPath := filepath.Join(dir, fileName)
 
 		d.logger.Debug(fmt.Sprintf("Creating file: '%s'", downloadPath))
 		file, err := os.Create(downloadPath)
@@ -175,4 +175,29 @@ var _ = Describe("Downloader", func() {
 			})
 		})
 	})
+
+	Describe("DownloadToSubdirectory", func() {
+		var productFiles []pivnet.ProductFile
+
+		BeforeEach(func() {
+			productFiles = []pivnet.ProductFile{
+				{
+					ID:           1337,
+					Name:         "pf-0",
+					AWSObjectKey: "bucket/path/file-0",
+				},
+			}
+		})
+
+		It("downloads the product files into the subdirectory", func() {
+			filepaths, err := d.DownloadToSubdirectory("dependencies/some-product/1.2.3", productFiles, "some-product", 1234)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.DownloadProductFileCallCount()).To(Equal(1))
+
+			expectedFilepath := filepath.Join(dir, "dependencies", "some-product", "1.2.3", "file-0")
+			Expect(filepaths).To(Equal([]string{expectedFilepath}))
+			Expect(expectedFilepath).To(BeAnExistingFile())
+		})
+	})
 })
@@ -12,7 +12,9 @@ require (
 	github.com/onsi/gomega v1.26.0
 	github.com/pivotal-cf/go-pivnet/v7 v7.0.1
 	github.com/robdimsdale/sanitizer v0.0.0-20160522134901-ab2334cb7539
+	golang.org/x/net v0.5.0
 	gopkg.in/yaml.v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -37,7 +39,6 @@ require (
 	github.com/smartystreets/goconvey v0.0.0-20170825221426-e5b2b7c91115 // indirect
 	github.com/stretchr/testify v0.0.0-20171018052257-2aa2c176b9da // indirect
 	golang.org/x/mod v0.7.0 // indirect
-	golang.org/x/net v0.5.0 // indirect
 	golang.org/x/sync v0.1.0 // indirect
 	golang.org/x/sys v0.4.0 // indirect
 	golang.org/x/text v0.6.0 // indirect
@@ -47,5 +48,4 @@ require (
 	gopkg.in/h2non/filetype.v1 v1.0.5 // indirect
 	gopkg.in/ini.v1 v1.39.0 // indirect
 	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,25 +1,78 @@
 package gp
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/download"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 )
 
+// concurrentDownloads is the number of ranges a product file is downloaded
+// in, as in go-pivnet.
+const concurrentDownloads = 10
+
 type Client struct {
-	client pivnet.Client
+	client         pivnet.Client
+	downloadClient *http.Client
+	logger         logger.Logger
 }
 
 func NewClient(token pivnet.AccessTokenService, config pivnet.ClientConfig, logger logger.Logger) *Client {
 	return &Client{
 		client: pivnet.NewClient(token, config, logger),
+		downloadClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.SkipSSLValidation,
+				},
+				Proxy: http.ProxyFromEnvironment,
+			},
+		},
+		logger: logger,
 	}
 }
 
+// UseTransport replaces the transport of every request made to the Pivnet API
+// and of downloads. Transports added with AddHeader and WrapTransport before
+// are discarded.
+func (c Client) UseTransport(t http.RoundTripper) {
+	c.client.HTTP.Transport = t
+	c.downloadClient.Transport = t
+}
+
+// AddHeader adds a header to every request made to the Pivnet API.
+func (c Client) AddHeader(name string, value string) {
+	c.WrapTransport(func(t http.RoundTripper) http.RoundTripper {
+		return headerTransport{
+			name:      name,
+			value:     value,
+			transport: t,
+		}
+	})
+}
+
+// WrapTransport wraps the transport of every request made to the Pivnet API.
+func (c Client) WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) {
+	c.client.HTTP.Transport = wrap(c.client.HTTP.Transport)
+}
+
+type headerTransport struct {
+	name      string
+	value     string
+	transport http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.value)
+	return t.transport.RoundTrip(req)
+}
+
 func (c Client) GetFederationToken(productSlug string) (pivnet.FederationToken, error) {
 	return c.client.FederationToken.GenerateFederationToken(productSlug)
 }
@@ -94,6 +147,10 @@ func (c Client) UserGroups(productSlug string, releaseID int) ([]pivnet.UserGrou
 	return c.client.UserGroups.ListForRelease(productSlug, releaseID)
 }
 
+func (c Client) ListUserGroups() ([]pivnet.UserGroup, error) {
+	return c.client.UserGroups.List()
+}
+
 func (c Client) AcceptEULA(productSlug string, releaseID int) error {
 	return c.client.EULA.Accept(productSlug, releaseID)
 }
@@ -142,12 +199,47 @@ func (c Client) AddToFileGroup(productSlug string, fileGroupID int, productFileI
 	return c.client.ProductFiles.AddToFileGroup(productSlug, fileGroupID, productFileID)
 }
 
+func (c Client) RemoveFromFileGroup(productSlug string, fileGroupID int, productFileID int) error {
+	return c.client.ProductFiles.RemoveFromFileGroup(productSlug, fileGroupID, productFileID)
+}
+
+func (c Client) FileGroups(productSlug string) ([]pivnet.FileGroup, error) {
+	return c.client.FileGroups.List(productSlug)
+}
+
 func (c Client) AddFileGroup(productSlug string, releaseID int, fileGroupID int) error {
 	return c.client.FileGroups.AddToRelease(productSlug, releaseID, fileGroupID)
 }
 
+// DownloadProductFile does what ProductFiles.DownloadForRelease does, with a
+// downloader that uses the transport of the client.
 func (c Client) DownloadProductFile(writer *download.FileInfo, productSlug string, releaseID int, productFileID int, progressWriter io.Writer) error {
-	return c.client.ProductFiles.DownloadForRelease(writer, productSlug, releaseID, productFileID, progressWriter)
+	pf, err := c.client.ProductFiles.GetForRelease(productSlug, releaseID, productFileID)
+	if err != nil {
+		return fmt.Errorf("GetForRelease: %s", err)
+	}
+
+	downloadLink, err := pf.DownloadLink()
+	if err != nil {
+		return fmt.Errorf("DownloadLink: %s", err)
+	}
+
+	c.logger.Debug("Downloading file", logger.Data{"downloadLink": downloadLink})
+
+	downloader := download.Client{
+		HTTPClient: c.downloadClient,
+		Ranger:     download.NewRanger(concurrentDownloads),
+		Bar:        download.NewBar(),
+		Logger:     c.logger,
+		Timeout:    30 * time.Second,
+	}
+
+	err = downloader.Get(writer, pivnet.NewProductFileLinkFetcher(downloadLink, c.client), progressWriter)
+	if err != nil {
+		return fmt.Errorf("Downloader.Get: %s", err)
+	}
+
+	return nil
 }
 
 func (c Client) FileGroupsForRelease(productSlug string, releaseID int) ([]pivnet.FileGroup, error) {
@@ -178,6 +270,14 @@ func (c Client) AddArtifactReference(productSlug string, releaseID int, artifact
 	return c.client.ArtifactReferences.AddToRelease(productSlug, releaseID, artifactReferenceID)
 }
 
+func (c Client) UpdateArtifactReference(productSlug string, artifactReference pivnet.ArtifactReference) (pivnet.ArtifactReference, error) {
+	return c.client.ArtifactReferences.Update(productSlug, artifactReference)
+}
+
+func (c Client) RemoveArtifactReference(productSlug string, releaseID int, artifactReferenceID int) error {
+	return c.client.ArtifactReferences.RemoveFromRelease(productSlug, releaseID, artifactReferenceID)
+}
+
 func (c Client) DeleteArtifactReference(productSlug string, artifactReferenceID int) (pivnet.ArtifactReference, error) {
 	return c.client.ArtifactReferences.Delete(productSlug, artifactReferenceID)
 }
@@ -0,0 +1,78 @@
+package gp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+)
+
+const legacyAPITokenLength = 20
+
+// AccessTokenOrLegacyToken is pivnet.AccessTokenOrLegacyToken with the HTTP
+// client the exchange is made with, so that it uses the same CA certificates,
+// client certificate and proxies as every other request. A failed exchange is
+// returned rather than panicking.
+type AccessTokenOrLegacyToken struct {
+	token      string
+	host       string
+	httpClient *http.Client
+	userAgent  string
+}
+
+// NewAccessTokenOrLegacyToken returns a token service that exchanges token
+// for an access token on host using transport. Legacy API tokens are used as
+// they are.
+func NewAccessTokenOrLegacyToken(token string, host string, transport http.RoundTripper, userAgent string) AccessTokenOrLegacyToken {
+	return AccessTokenOrLegacyToken{
+		token: token,
+		host:  host,
+		httpClient: &http.Client{
+			Timeout:   60 * time.Second,
+			Transport: transport,
+		},
+		userAgent: userAgent,
+	}
+}
+
+func (o AccessTokenOrLegacyToken) AccessToken() (string, error) {
+	if len(o.token) <= legacyAPITokenLength {
+		return o.token, nil
+	}
+
+	b, err := json.Marshal(pivnet.AuthBody{RefreshToken: o.token})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal API token request body: %s", err)
+	}
+
+	req, err := http.NewRequest("POST", o.host+"/api/v2/authentication/access_tokens", bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("failed to construct API token request: %s", err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	if o.userAgent != "" {
+		req.Header.Add("User-Agent", o.userAgent)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("API token request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch API token - received status %v", resp.StatusCode)
+	}
+
+	var response pivnet.AuthResp
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode API token response: %s", err)
+	}
+
+	return response.Token, nil
+}
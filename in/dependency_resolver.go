@@ -0,0 +1,112 @@
+package in
+
+import (
+	"fmt"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+)
+
+//counterfeiter:generate --fake-name FakeDependencyResolverClient . dependencyResolverClient
+type dependencyResolverClient interface {
+	ReleasesForProductSlug(productSlug string) ([]pivnet.Release, error)
+}
+
+//counterfeiter:generate --fake-name FakeSpecifierMatcher . specifierMatcher
+type specifierMatcher interface {
+	ReleasesMatching(releases []pivnet.Release, specifier string) ([]pivnet.Release, error)
+}
+
+//counterfeiter:generate --fake-name FakeSorter . sorter
+type sorter interface {
+	SortBySemver([]pivnet.Release) ([]pivnet.Release, error)
+}
+
+type ResolvedDependency struct {
+	ProductSlug string
+	Specifier   string
+	Release     pivnet.Release
+}
+
+type DependencyResolver struct {
+	logger       logger.Logger
+	pivnetClient dependencyResolverClient
+	matcher      specifierMatcher
+	sorter       sorter
+}
+
+func NewDependencyResolver(
+	logger logger.Logger,
+	pivnetClient dependencyResolverClient,
+	matcher specifierMatcher,
+	sorter sorter,
+) *DependencyResolver {
+	return &DependencyResolver{
+		logger:       logger,
+		pivnetClient: pivnetClient,
+		matcher:      matcher,
+		sorter:       sorter,
+	}
+}
+
+// Resolve returns the newest release of the dependent product matching each
+// dependency specifier. Releases are ordered by semver; if no matching
+// release is valid semver the first match returned by Pivnet is used.
+func (r DependencyResolver) Resolve(dependencySpecifiers []pivnet.DependencySpecifier) ([]ResolvedDependency, error) {
+	releasesBySlug := map[string][]pivnet.Release{}
+
+	resolved := make([]ResolvedDependency, 0, len(dependencySpecifiers))
+	for _, d := range dependencySpecifiers {
+		productSlug := d.Product.Slug
+
+		releases, ok := releasesBySlug[productSlug]
+		if !ok {
+			r.logger.Info(fmt.Sprintf("Getting releases for dependent product: '%s'", productSlug))
+
+			var err error
+			releases, err = r.pivnetClient.ReleasesForProductSlug(productSlug)
+			if err != nil {
+				return nil, err
+			}
+			releasesBySlug[productSlug] = releases
+		}
+
+		matching, err := r.matcher.ReleasesMatching(releases, d.Specifier)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(matching) == 0 {
+			return nil, fmt.Errorf(
+				"no release of dependent product '%s' matches specifier: '%s'",
+				productSlug,
+				d.Specifier,
+			)
+		}
+
+		sorted, err := r.sorter.SortBySemver(matching)
+		if err != nil {
+			return nil, err
+		}
+
+		newest := matching[0]
+		if len(sorted) > 0 {
+			newest = sorted[0]
+		}
+
+		r.logger.Info(fmt.Sprintf(
+			"Resolved dependency '%s' with specifier '%s' to version: '%s'",
+			productSlug,
+			d.Specifier,
+			newest.Version,
+		))
+
+		resolved = append(resolved, ResolvedDependency{
+			ProductSlug: productSlug,
+			Specifier:   d.Specifier,
+			Release:     newest,
+		})
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,171 @@
+package in_test
+
+import (
+	"fmt"
+	"log"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/in"
+	"github.com/pivotal-cf/pivnet-resource/v3/in/infakes"
+)
+
+var _ = Describe("DependencyResolver", func() {
+	var (
+		fakePivnetClient *infakes.FakeDependencyResolverClient
+		fakeMatcher      *infakes.FakeSpecifierMatcher
+		fakeSorter       *infakes.FakeSorter
+
+		releases             []pivnet.Release
+		matchingReleases     []pivnet.Release
+		sortedReleases       []pivnet.Release
+		dependencySpecifiers []pivnet.DependencySpecifier
+
+		releasesErr error
+		matchingErr error
+		sortErr     error
+
+		resolver *in.DependencyResolver
+	)
+
+	BeforeEach(func() {
+		fakePivnetClient = &infakes.FakeDependencyResolverClient{}
+		fakeMatcher = &infakes.FakeSpecifierMatcher{}
+		fakeSorter = &infakes.FakeSorter{}
+
+		releases = []pivnet.Release{
+			{ID: 1, Version: "1.2.0"},
+			{ID: 2, Version: "1.2.3"},
+			{ID: 3, Version: "1.3.0"},
+		}
+		matchingReleases = []pivnet.Release{releases[0], releases[1]}
+		sortedReleases = []pivnet.Release{releases[1], releases[0]}
+
+		dependencySpecifiers = []pivnet.DependencySpecifier{
+			{
+				ID:        10,
+				Specifier: "1.2.*",
+				Product:   pivnet.Product{Slug: "some-product"},
+			},
+		}
+
+		releasesErr = nil
+		matchingErr = nil
+		sortErr = nil
+
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		resolver = in.NewDependencyResolver(
+			logshim.NewLogShim(logger, logger, true),
+			fakePivnetClient,
+			fakeMatcher,
+			fakeSorter,
+		)
+	})
+
+	JustBeforeEach(func() {
+		fakePivnetClient.ReleasesForProductSlugReturns(releases, releasesErr)
+		fakeMatcher.ReleasesMatchingReturns(matchingReleases, matchingErr)
+		fakeSorter.SortBySemverReturns(sortedReleases, sortErr)
+	})
+
+	It("resolves each specifier to the newest matching release", func() {
+		resolved, err := resolver.Resolve(dependencySpecifiers)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resolved).To(Equal([]in.ResolvedDependency{
+			{
+				ProductSlug: "some-product",
+				Specifier:   "1.2.*",
+				Release:     releases[1],
+			},
+		}))
+
+		Expect(fakePivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal("some-product"))
+
+		invokedReleases, invokedSpecifier := fakeMatcher.ReleasesMatchingArgsForCall(0)
+		Expect(invokedReleases).To(Equal(releases))
+		Expect(invokedSpecifier).To(Equal("1.2.*"))
+
+		Expect(fakeSorter.SortBySemverArgsForCall(0)).To(Equal(matchingReleases))
+	})
+
+	Context("when multiple specifiers are for the same product", func() {
+		BeforeEach(func() {
+			dependencySpecifiers = append(dependencySpecifiers, pivnet.DependencySpecifier{
+				ID:        11,
+				Specifier: "~> 1.2",
+				Product:   pivnet.Product{Slug: "some-product"},
+			})
+		})
+
+		It("only gets the releases of the product once", func() {
+			resolved, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resolved).To(HaveLen(2))
+			Expect(fakePivnetClient.ReleasesForProductSlugCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when none of the matching releases are semver", func() {
+		BeforeEach(func() {
+			sortedReleases = []pivnet.Release{}
+		})
+
+		It("resolves to the first matching release", func() {
+			resolved, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resolved[0].Release).To(Equal(releases[0]))
+		})
+	})
+
+	Context("when no release matches the specifier", func() {
+		BeforeEach(func() {
+			matchingReleases = []pivnet.Release{}
+		})
+
+		It("returns an error", func() {
+			_, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("some-product"))
+			Expect(err.Error()).To(ContainSubstring("1.2.*"))
+		})
+	})
+
+	Context("when getting releases returns an error", func() {
+		BeforeEach(func() {
+			releasesErr = fmt.Errorf("some releases error")
+		})
+
+		It("returns the error", func() {
+			_, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).To(Equal(releasesErr))
+		})
+	})
+
+	Context("when matching releases returns an error", func() {
+		BeforeEach(func() {
+			matchingErr = fmt.Errorf("some matching error")
+		})
+
+		It("returns the error", func() {
+			_, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).To(Equal(matchingErr))
+		})
+	})
+
+	Context("when sorting returns an error", func() {
+		BeforeEach(func() {
+			sortErr = fmt.Errorf("some sort error")
+		})
+
+		It("returns the error", func() {
+			_, err := resolver.Resolve(dependencySpecifiers)
+			Expect(err).To(Equal(sortErr))
+		})
+	})
+})
@@ -13,6 +13,7 @@ import (
 	"github.com/pivotal-cf/go-pivnet/v7/logshim"
 	"github.com/pivotal-cf/pivnet-resource/v3/in/filesystem"
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -101,4 +102,177 @@ var _ = Describe("FileWriter", func() {
 			Expect(unmarshalledMetadata).To(Equal(inputMetadata))
 		})
 	})
+
+	Describe("WriteUpgradeGraphFiles", func() {
+		var graph upgradegraph.Graph
+
+		BeforeEach(func() {
+			graph = upgradegraph.Graph{
+				ProductSlug: "some-product",
+				Releases: []upgradegraph.Release{
+					{ID: 2, Version: "1.1.0"},
+					{ID: 1, Version: "1.0.0"},
+				},
+				UpgradePaths: []upgradegraph.Edge{
+					{From: "1.0.0", To: "1.1.0"},
+				},
+			}
+		})
+
+		It("writes the upgrade graph in json and dot formats", func() {
+			err := fileWriter.WriteUpgradeGraphFiles(graph, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := ioutil.ReadFile(filepath.Join(downloadDir, "upgrade_graph.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var unmarshalledGraph upgradegraph.Graph
+			err = json.Unmarshal(b, &unmarshalledGraph)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unmarshalledGraph).To(Equal(graph))
+
+			b, err = ioutil.ReadFile(filepath.Join(downloadDir, "upgrade_graph.dot"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(graph.DOT(nil)))
+
+			Expect(filepath.Join(downloadDir, "upgrade_path.json")).NotTo(BeAnExistingFile())
+		})
+
+		Context("when an upgrade path is provided", func() {
+			It("writes the upgrade path", func() {
+				err := fileWriter.WriteUpgradeGraphFiles(graph, []string{"1.0.0", "1.1.0"})
+				Expect(err).NotTo(HaveOccurred())
+
+				b, err := ioutil.ReadFile(filepath.Join(downloadDir, "upgrade_path.json"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(b).To(MatchJSON(`["1.0.0","1.1.0"]`))
+			})
+		})
+	})
+
+	Describe("WriteDependencyLockFile", func() {
+		It("writes the dependency lock file in yaml format", func() {
+			inputLock := metadata.DependencyLock{
+				Dependencies: []metadata.LockedDependency{
+					{
+						ProductSlug: "some-product",
+						Specifier:   "1.2.*",
+						ReleaseID:   1234,
+						Version:     "1.2.3",
+						Directory:   "dependencies/some-product/1.2.3",
+						Files: []metadata.LockedFile{
+							{
+								ID:     2345,
+								Name:   "some file",
+								Path:   "dependencies/some-product/1.2.3/some-file",
+								SHA256: "some-sha256",
+							},
+						},
+					},
+				},
+			}
+
+			err := fileWriter.WriteDependencyLockFile(inputLock)
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := ioutil.ReadFile(filepath.Join(downloadDir, "dependencies.lock.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var unmarshalledLock metadata.DependencyLock
+			err = yaml.Unmarshal(b, &unmarshalledLock)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(unmarshalledLock).To(Equal(inputLock))
+		})
+	})
+
+	Describe("WriteBundleManifestFile", func() {
+		It("writes the bundle manifest in yaml format", func() {
+			inputManifest := metadata.BundleManifest{
+				Products: []metadata.BundledProduct{
+					{
+						ProductSlug: "stemcells",
+						Version:     "621.5",
+						Directory:   "bundle/stemcells/621.5",
+						Metadata: metadata.Metadata{
+							Release: &metadata.Release{
+								ID:      1234,
+								Version: "621.5",
+							},
+						},
+						Files: []metadata.LockedFile{
+							{
+								ID:     2345,
+								Name:   "some file",
+								Path:   "bundle/stemcells/621.5/some-file",
+								SHA256: "some-sha256",
+							},
+						},
+					},
+				},
+			}
+
+			err := fileWriter.WriteBundleManifestFile(inputManifest)
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := ioutil.ReadFile(filepath.Join(downloadDir, "bundle.yaml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var unmarshalledManifest metadata.BundleManifest
+			err = yaml.Unmarshal(b, &unmarshalledManifest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(unmarshalledManifest).To(Equal(inputManifest))
+		})
+	})
+
+	Describe("WriteOmFiles", func() {
+		var (
+			downloadFile metadata.OmDownloadFile
+		)
+
+		BeforeEach(func() {
+			downloadFile = metadata.OmDownloadFile{
+				ProductPath:     "some-tile.pivotal",
+				ProductSlug:     "some-product",
+				ProductVersion:  "1.2.3",
+				ProductSHA256:   "some-sha256",
+				StemcellPath:    "dependencies/stemcells/621.5/some-stemcell.tgz",
+				StemcellVersion: "621.5",
+			}
+		})
+
+		It("writes the download file in json format and the stemcell assignment in yaml format", func() {
+			err := fileWriter.WriteOmFiles(downloadFile, &metadata.OmStemcellAssignment{
+				Product:  "some-product",
+				Stemcell: "621.5",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := ioutil.ReadFile(filepath.Join(downloadDir, "download-file.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(MatchJSON(`{
+				"product_path": "some-tile.pivotal",
+				"product_slug": "some-product",
+				"product_version": "1.2.3",
+				"product_sha256": "some-sha256",
+				"stemcell_path": "dependencies/stemcells/621.5/some-stemcell.tgz",
+				"stemcell_version": "621.5"
+			}`))
+
+			b, err = ioutil.ReadFile(filepath.Join(downloadDir, "assign-stemcell.yml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(MatchYAML("product: some-product\nstemcell: \"621.5\"\n"))
+		})
+
+		Context("when there is no stemcell assignment", func() {
+			It("does not write the stemcell assignment", func() {
+				err := fileWriter.WriteOmFiles(downloadFile, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = os.Stat(filepath.Join(downloadDir, "assign-stemcell.yml"))
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
 })
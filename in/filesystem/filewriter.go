@@ -8,6 +8,7 @@ import (
 
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
 	"gopkg.in/yaml.v2"
 )
 
@@ -74,3 +75,120 @@ func (w FileWriter) WriteVersionFile(version string) error {
 
 	return nil
 }
+
+func (w FileWriter) WriteDependencyLockFile(lock metadata.DependencyLock) error {
+	lockFilepath := filepath.Join(w.downloadDir, "dependencies.lock.yaml")
+	w.logger.Debug("Writing dependency lock file")
+
+	yamlLock, err := yaml.Marshal(lock)
+	if err != nil {
+		// Untested as it is too hard to force yaml.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(lockFilepath, yamlLock, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	return nil
+}
+
+func (w FileWriter) WriteBundleManifestFile(manifest metadata.BundleManifest) error {
+	manifestFilepath := filepath.Join(w.downloadDir, "bundle.yaml")
+	w.logger.Debug("Writing bundle manifest file")
+
+	yamlManifest, err := yaml.Marshal(manifest)
+	if err != nil {
+		// Untested as it is too hard to force yaml.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(manifestFilepath, yamlManifest, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	return nil
+}
+
+// WriteOmFiles writes download-file.json and, if a stemcell assignment is
+// provided, assign-stemcell.yml.
+func (w FileWriter) WriteOmFiles(downloadFile metadata.OmDownloadFile, assignment *metadata.OmStemcellAssignment) error {
+	w.logger.Debug("Writing om files")
+
+	jsonDownloadFile, err := json.Marshal(downloadFile)
+	if err != nil {
+		// Untested as it is too hard to force json.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(w.downloadDir, "download-file.json"), jsonDownloadFile, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	if assignment == nil {
+		return nil
+	}
+
+	yamlAssignment, err := yaml.Marshal(assignment)
+	if err != nil {
+		// Untested as it is too hard to force yaml.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(w.downloadDir, "assign-stemcell.yml"), yamlAssignment, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	return nil
+}
+
+// WriteUpgradeGraphFiles writes the upgrade graph as JSON and DOT. If an
+// upgrade path is provided it is highlighted in the DOT file and written to
+// its own JSON file.
+func (w FileWriter) WriteUpgradeGraphFiles(graph upgradegraph.Graph, upgradePath []string) error {
+	w.logger.Debug("Writing upgrade graph files")
+
+	jsonGraph, err := json.Marshal(graph)
+	if err != nil {
+		// Untested as it is too hard to force json.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(w.downloadDir, "upgrade_graph.json"), jsonGraph, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(w.downloadDir, "upgrade_graph.dot"), []byte(graph.DOT(upgradePath)), os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	if upgradePath == nil {
+		return nil
+	}
+
+	jsonPath, err := json.Marshal(upgradePath)
+	if err != nil {
+		// Untested as it is too hard to force json.Marshal to return an error
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(w.downloadDir, "upgrade_path.json"), jsonPath, os.ModePerm)
+	if err != nil {
+		// Untested as it is too hard to force io.WriteFile to return an error
+		return err
+	}
+
+	return nil
+}
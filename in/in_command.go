@@ -8,7 +8,10 @@ import (
 	pivnet "github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/jsonlog"
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
 	"github.com/pivotal-cf/pivnet-resource/v3/versions"
 )
 
@@ -23,6 +26,7 @@ type filterer interface {
 //counterfeiter:generate --fake-name FakeDownloader . downloader
 type downloader interface {
 	Download(productFiles []pivnet.ProductFile, productSlug string, releaseID int) ([]string, error)
+	DownloadToSubdirectory(subdirectory string, productFiles []pivnet.ProductFile, productSlug string, releaseID int) ([]string, error)
 }
 
 //counterfeiter:generate --fake-name FakeFileSummer . fileSummer
@@ -35,6 +39,26 @@ type fileWriter interface {
 	WriteMetadataJSONFile(mdata metadata.Metadata) error
 	WriteMetadataYAMLFile(mdata metadata.Metadata) error
 	WriteVersionFile(versionWithFingerprint string) error
+	WriteDependencyLockFile(lock metadata.DependencyLock) error
+	WriteUpgradeGraphFiles(graph upgradegraph.Graph, upgradePath []string) error
+	WriteBundleManifestFile(manifest metadata.BundleManifest) error
+	WriteOmFiles(downloadFile metadata.OmDownloadFile, assignment *metadata.OmStemcellAssignment) error
+}
+
+//counterfeiter:generate --fake-name FakeDependencyResolver . dependencyResolver
+type dependencyResolver interface {
+	Resolve(dependencySpecifiers []pivnet.DependencySpecifier) ([]ResolvedDependency, error)
+}
+
+//counterfeiter:generate --fake-name FakeUpgradeGraphBuilder . upgradeGraphBuilder
+type upgradeGraphBuilder interface {
+	Build(productSlug string) (upgradegraph.Graph, error)
+	ShortestPath(graph upgradegraph.Graph, from string, to string) ([]string, error)
+}
+
+//counterfeiter:generate --fake-name FakeTileInspector . tileInspector
+type tileInspector interface {
+	Inspect(tilePath string) (tile.Tile, error)
 }
 
 //counterfeiter:generate --fake-name FakePivnetClient . pivnetClient
@@ -58,15 +82,18 @@ type archive interface {
 }
 
 type InCommand struct {
-	logger           logger.Logger
-	downloadDir      string
-	pivnetClient     pivnetClient
-	filter           filterer
-	downloader       downloader
-	sha256FileSummer fileSummer
-	md5FileSummer    fileSummer
-	fileWriter       fileWriter
-	archive          archive
+	logger              logger.Logger
+	downloadDir         string
+	pivnetClient        pivnetClient
+	filter              filterer
+	downloader          downloader
+	sha256FileSummer    fileSummer
+	md5FileSummer       fileSummer
+	fileWriter          fileWriter
+	archive             archive
+	dependencyResolver  dependencyResolver
+	upgradeGraphBuilder upgradeGraphBuilder
+	tileInspector       tileInspector
 }
 
 func NewInCommand(
@@ -78,16 +105,22 @@ func NewInCommand(
 	md5FileSummer fileSummer,
 	fileWriter fileWriter,
 	archive archive,
+	dependencyResolver dependencyResolver,
+	upgradeGraphBuilder upgradeGraphBuilder,
+	tileInspector tileInspector,
 ) *InCommand {
 	return &InCommand{
-		logger:           logger,
-		pivnetClient:     pivnetClient,
-		filter:           filter,
-		downloader:       downloader,
-		sha256FileSummer: sha256FileSummer,
-		md5FileSummer:    md5FileSummer,
-		fileWriter:       fileWriter,
-		archive:          archive,
+		logger:              logger,
+		pivnetClient:        pivnetClient,
+		filter:              filter,
+		downloader:          downloader,
+		sha256FileSummer:    sha256FileSummer,
+		md5FileSummer:       md5FileSummer,
+		fileWriter:          fileWriter,
+		archive:             archive,
+		dependencyResolver:  dependencyResolver,
+		upgradeGraphBuilder: upgradeGraphBuilder,
+		tileInspector:       tileInspector,
 	}
 }
 
@@ -112,6 +145,8 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 		return concourse.InResponse{}, err
 	}
 
+	jsonlog.SetReleaseID(c.logger, release.ID)
+
 	if fingerprint != "" {
 		actualFingerprint := release.SoftwareFilesUpdatedAt
 		if actualFingerprint != fingerprint {
@@ -124,9 +159,17 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 		}
 	}
 
-	c.logger.Info(fmt.Sprintf("Accepting EULA for release with ID: %d", release.ID))
+	var bundle []ResolvedDependency
+	if len(input.Params.Bundle) > 0 {
+		c.logger.Info("Resolving bundle products")
+
+		bundle, err = c.resolveBundle(input.Params.Bundle)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
 
-	err = c.pivnetClient.AcceptEULA(productSlug, release.ID)
+	err = c.acceptEULAs(productSlug, release, bundle)
 	if err != nil {
 		return concourse.InResponse{}, err
 	}
@@ -187,59 +230,45 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 
 	c.logger.Info("Downloading files")
 
-	err = c.downloadFiles(input.Params.Globs, allProductFiles, productSlug, release.ID, input.Params.Unpack)
+	downloaded, downloadedPaths, err := c.downloadFiles(input.Params.Globs, allProductFiles, productSlug, release.ID, input.Params.Unpack)
 	if err != nil {
 		return concourse.InResponse{}, err
 	}
 
-	c.logger.Info("Creating metadata")
+	var tiles []metadata.Tile
+	if input.Params.InspectTiles {
+		tiles, err = c.inspectTiles(downloadedPaths)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
 
-	versionWithFingerprint, err := versions.CombineVersionAndFingerprint(version, fingerprint)
+	var dependencyLock metadata.DependencyLock
+	if input.Params.FetchDependencies {
+		c.logger.Info("Fetching dependencies")
 
-	mdata := metadata.Metadata{
-		Release: &metadata.Release{
-			ID:                    release.ID,
-			Version:               release.Version,
-			ReleaseType:           string(release.ReleaseType),
-			ReleaseDate:           release.ReleaseDate,
-			Description:           release.Description,
-			ReleaseNotesURL:       release.ReleaseNotesURL,
-			Availability:          release.Availability,
-			Controlled:            release.Controlled,
-			ECCN:                  release.ECCN,
-			LicenseException:      release.LicenseException,
-			EndOfSupportDate:      release.EndOfSupportDate,
-			EndOfGuidanceDate:     release.EndOfGuidanceDate,
-			EndOfAvailabilityDate: release.EndOfAvailabilityDate,
-		},
+		dependencyLock, err = c.fetchDependencies(dependencySpecifiers, input.Params.DependencyGlobs)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
 	}
 
-	if release.EULA != nil {
-		mdata.Release.EULASlug = release.EULA.Slug
-	}
+	var bundledProducts []metadata.BundledProduct
+	if len(bundle) > 0 {
+		c.logger.Info("Fetching bundle products")
 
-	for _, pf := range releaseProductFiles {
-		mdata.Release.ProductFiles = append(mdata.Release.ProductFiles, metadata.ReleaseProductFile{
-			ID: pf.ID,
-		})
+		bundledProducts, err = c.fetchBundle(bundle, input.Params.Bundle)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
 	}
 
-	for _, pf := range allProductFiles {
-		mdata.ProductFiles = append(mdata.ProductFiles, metadata.ProductFile{
-			ID:                 pf.ID,
-			File:               pf.Name,
-			Description:        pf.Description,
-			AWSObjectKey:       pf.AWSObjectKey,
-			FileType:           pf.FileType,
-			FileVersion:        pf.FileVersion,
-			SHA256:             pf.SHA256,
-			MD5:                pf.MD5,
-			DocsURL:            pf.DocsURL,
-			SystemRequirements: pf.SystemRequirements,
-			Platforms:          pf.Platforms,
-			IncludedFiles:      pf.IncludedFiles,
-		})
-	}
+	c.logger.Info("Creating metadata")
+
+	versionWithFingerprint, err := versions.CombineVersionAndFingerprint(version, fingerprint)
+
+	mdata := releaseMetadata(release, releaseProductFiles, allProductFiles, fileGroups)
+	mdata.Tiles = tiles
 
 	for _, d := range releaseDependencies {
 		mdata.Dependencies = append(mdata.Dependencies, metadata.Dependency{
@@ -277,21 +306,6 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 		})
 	}
 
-	for _, fg := range fileGroups {
-		mfg := metadata.FileGroup{
-			ID:   fg.ID,
-			Name: fg.Name,
-		}
-
-		for _, pf := range fg.ProductFiles {
-			mfg.ProductFiles = append(mfg.ProductFiles, metadata.FileGroupProductFile{
-				ID: pf.ID,
-			})
-		}
-
-		mdata.FileGroups = append(mdata.FileGroups, mfg)
-	}
-
 	for _, ir := range artifactReferences {
 		mdata.ArtifactReferences = append(mdata.ArtifactReferences, metadata.ArtifactReference{
 			ID:                 ir.ID,
@@ -321,6 +335,51 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 		return concourse.InResponse{}, err
 	}
 
+	if input.Params.FetchDependencies {
+		err = c.fileWriter.WriteDependencyLockFile(dependencyLock)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
+
+	if len(bundle) > 0 {
+		manifest := metadata.BundleManifest{
+			Products: append([]metadata.BundledProduct{{
+				ProductSlug: productSlug,
+				Version:     release.Version,
+				Directory:   ".",
+				Metadata:    mdata,
+				Files:       lockedFiles(".", downloaded),
+			}}, bundledProducts...),
+		}
+
+		err = c.fileWriter.WriteBundleManifestFile(manifest)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
+
+	if input.Params.OutputFormat == concourse.OutputFormatOm {
+		err = c.writeOmFiles(
+			productSlug,
+			release.Version,
+			lockedFiles(".", downloaded),
+			stemcellCandidates(dependencyLock, bundledProducts),
+			input.Params.StemcellIaaS,
+			tiles,
+		)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
+
+	if input.Params.UpgradeGraph {
+		err = c.writeUpgradeGraph(productSlug, release.Version, input.Params)
+		if err != nil {
+			return concourse.InResponse{}, err
+		}
+	}
+
 	concourseMetadata := c.addReleaseMetadata([]concourse.Metadata{}, release)
 
 	out := concourse.InResponse{
@@ -330,6 +389,8 @@ func (c *InCommand) Run(input concourse.InRequest) (concourse.InResponse, error)
 		Metadata: concourseMetadata,
 	}
 
+	c.logger.Info("Get complete")
+
 	return out, nil
 }
 
@@ -339,7 +400,80 @@ func (c InCommand) downloadFiles(
 	productSlug string,
 	releaseID int,
 	unpack bool,
-) error {
+) ([]pivnet.ProductFile, []string, error) {
+	downloaded, files, err := c.downloadAndVerify("", globs, productFiles, productSlug, releaseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if unpack {
+		for _, destinationPath := range files {
+			mime := c.archive.Mimetype(destinationPath)
+
+			if mime == "" {
+				c.logger.Info(fmt.Sprintf("not an archive: %s", destinationPath))
+				continue
+			}
+
+			err = c.archive.Extract(mime, destinationPath)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return downloaded, files, nil
+}
+
+func (c InCommand) inspectTiles(files []string) ([]metadata.Tile, error) {
+	var tiles []metadata.Tile
+	for _, f := range files {
+		if !strings.HasSuffix(f, ".pivotal") {
+			continue
+		}
+
+		c.logger.Info(fmt.Sprintf("Inspecting tile: %s", f))
+
+		t, err := c.tileInspector.Inspect(f)
+		if err != nil {
+			return nil, err
+		}
+
+		mt := metadata.Tile{
+			File:               filepath.Base(f),
+			Name:               t.Name,
+			TileProductVersion: t.ProductVersion,
+			StemcellCriteria: metadata.TileStemcellCriteria{
+				OS:          t.StemcellCriteria.OS,
+				Version:     t.StemcellCriteria.Version,
+				RequiresCPI: t.StemcellCriteria.RequiresCPI,
+			},
+		}
+
+		for _, r := range t.Releases {
+			mt.Releases = append(mt.Releases, metadata.TileRelease{
+				Name:    r.Name,
+				File:    r.File,
+				Version: r.Version,
+			})
+		}
+
+		tiles = append(tiles, mt)
+	}
+
+	return tiles, nil
+}
+
+// downloadAndVerify downloads the product files matching the globs into the
+// subdirectory of the download directory and verifies their checksums. It
+// returns the product files that were downloaded along with their paths.
+func (c InCommand) downloadAndVerify(
+	subdirectory string,
+	globs []string,
+	productFiles []pivnet.ProductFile,
+	productSlug string,
+	releaseID int,
+) ([]pivnet.ProductFile, []string, error) {
 	c.logger.Info("Filtering download links by glob")
 
 	filtered := productFiles
@@ -349,31 +483,27 @@ func (c InCommand) downloadFiles(
 		var err error
 		filtered, err = c.filter.ProductFileKeysByGlobs(productFiles, globs)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
 	c.logger.Info("Downloading filtered files")
 
-	files, err := c.downloader.Download(filtered, productSlug, releaseID)
+	var files []string
+	var err error
+	if subdirectory == "" {
+		files, err = c.downloader.Download(filtered, productSlug, releaseID)
+	} else {
+		files, err = c.downloader.DownloadToSubdirectory(subdirectory, filtered, productSlug, releaseID)
+	}
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	fileSHA256s := map[string]string{}
 	fileMD5s := map[string]string{}
 	for _, p := range productFiles {
-		parts := strings.Split(p.AWSObjectKey, "/")
-
-		if len(parts) < 1 {
-			panic("not enough components to form filename")
-		}
-
-		fileName := parts[len(parts)-1]
-
-		if fileName == "" {
-			panic("empty file name")
-		}
+		fileName := productFileName(p)
 
 		if p.FileType == pivnet.FileTypeSoftware {
 			fileSHA256s[fileName] = p.SHA256
@@ -383,28 +513,482 @@ func (c InCommand) downloadFiles(
 
 	err = c.compareSHA256sOrMD5s(files, fileSHA256s, fileMD5s)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if unpack {
-		for _, destinationPath := range files {
-			mime := c.archive.Mimetype(destinationPath)
+	return filtered, files, nil
+}
 
-			if mime == "" {
-				c.logger.Info(fmt.Sprintf("not an archive: %s", destinationPath))
-				continue
-			}
+// fetchDependencies resolves each dependency specifier to a release of the
+// dependent product and downloads its files into
+// dependencies/<product-slug>/<version>. Releases resolved by more than one
+// specifier are only downloaded once.
+func (c InCommand) fetchDependencies(
+	dependencySpecifiers []pivnet.DependencySpecifier,
+	dependencyGlobs map[string][]string,
+) (metadata.DependencyLock, error) {
+	lock := metadata.DependencyLock{}
+
+	resolved, err := c.dependencyResolver.Resolve(dependencySpecifiers)
+	if err != nil {
+		return metadata.DependencyLock{}, err
+	}
 
-			err = c.archive.Extract(mime, destinationPath)
+	downloaded := map[int][]metadata.LockedFile{}
+	for _, d := range resolved {
+		directory, err := releaseDirectory("dependencies", d.ProductSlug, d.Release.Version)
+		if err != nil {
+			return metadata.DependencyLock{}, err
+		}
+
+		lockedFiles, ok := downloaded[d.Release.ID]
+		if !ok {
+			lockedFiles, err = c.fetchDependency(d, directory, dependencyGlobs[d.ProductSlug])
 			if err != nil {
-				return err
+				return metadata.DependencyLock{}, err
 			}
+			downloaded[d.Release.ID] = lockedFiles
 		}
+
+		lock.Dependencies = append(lock.Dependencies, metadata.LockedDependency{
+			ProductSlug: d.ProductSlug,
+			Specifier:   d.Specifier,
+			ReleaseID:   d.Release.ID,
+			Version:     d.Release.Version,
+			Directory:   directory,
+			Files:       lockedFiles,
+		})
+	}
+
+	return lock, nil
+}
+
+func (c InCommand) fetchDependency(
+	d ResolvedDependency,
+	directory string,
+	globs []string,
+) ([]metadata.LockedFile, error) {
+	c.logger.Info(fmt.Sprintf(
+		"Accepting EULA for dependent product: '%s' release with ID: %d",
+		d.ProductSlug,
+		d.Release.ID,
+	))
+
+	err := c.pivnetClient.AcceptEULA(d.ProductSlug, d.Release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseProductFiles, err := c.pivnetClient.ProductFilesForRelease(d.ProductSlug, d.Release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileGroups, err := c.pivnetClient.FileGroupsForRelease(d.ProductSlug, d.Release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	allProductFiles := releaseProductFiles
+	for _, fg := range fileGroups {
+		allProductFiles = append(allProductFiles, fg.ProductFiles...)
+	}
+
+	c.logger.Info(fmt.Sprintf(
+		"Downloading files for dependent product: '%s' version: '%s'",
+		d.ProductSlug,
+		d.Release.Version,
+	))
+
+	downloaded, _, err := c.downloadAndVerify(directory, globs, allProductFiles, d.ProductSlug, d.Release.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return lockedFiles(directory, downloaded), nil
+}
+
+// resolveBundle returns the release of each bundle product, whose version may
+// be an exact version or a specifier, in the order they were provided.
+func (c InCommand) resolveBundle(products []concourse.BundleProduct) ([]ResolvedDependency, error) {
+	specifiers := make([]pivnet.DependencySpecifier, len(products))
+	for i, p := range products {
+		specifiers[i] = pivnet.DependencySpecifier{
+			Product:   pivnet.Product{Slug: p.ProductSlug},
+			Specifier: p.Version,
+		}
+	}
+
+	return c.dependencyResolver.Resolve(specifiers)
+}
+
+// acceptEULAs accepts the EULA of the release and of each bundled release.
+// When there are bundled releases every EULA is attempted, and all failures
+// are returned as one error so that they can be accepted together.
+func (c InCommand) acceptEULAs(productSlug string, release pivnet.Release, bundle []ResolvedDependency) error {
+	c.logger.Info(fmt.Sprintf("Accepting EULA for release with ID: %d", release.ID))
+
+	err := c.pivnetClient.AcceptEULA(productSlug, release.ID)
+	if len(bundle) == 0 {
+		return err
+	}
+
+	var failures []string
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("'%s' version '%s': %s", productSlug, release.Version, err))
+	}
+
+	for _, b := range bundle {
+		c.logger.Info(fmt.Sprintf(
+			"Accepting EULA for bundle product: '%s' release with ID: %d",
+			b.ProductSlug,
+			b.Release.ID,
+		))
+
+		err := c.pivnetClient.AcceptEULA(b.ProductSlug, b.Release.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("'%s' version '%s': %s", b.ProductSlug, b.Release.Version, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf(
+			"failed to accept EULA for %d product(s):\n%s",
+			len(failures),
+			strings.Join(failures, "\n"),
+		)
 	}
 
 	return nil
 }
 
+// fetchBundle downloads the files of each bundled release matching the globs
+// of its bundle product into bundle/<product-slug>/<version>.
+func (c InCommand) fetchBundle(
+	bundle []ResolvedDependency,
+	products []concourse.BundleProduct,
+) ([]metadata.BundledProduct, error) {
+	var bundled []metadata.BundledProduct
+	for i, b := range bundle {
+		directory, err := releaseDirectory("bundle", b.ProductSlug, b.Release.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		releaseProductFiles, err := c.pivnetClient.ProductFilesForRelease(b.ProductSlug, b.Release.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		fileGroups, err := c.pivnetClient.FileGroupsForRelease(b.ProductSlug, b.Release.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		allProductFiles := releaseProductFiles
+		for _, fg := range fileGroups {
+			allProductFiles = append(allProductFiles, fg.ProductFiles...)
+		}
+
+		c.logger.Info(fmt.Sprintf(
+			"Downloading files for bundle product: '%s' version: '%s'",
+			b.ProductSlug,
+			b.Release.Version,
+		))
+
+		downloaded, _, err := c.downloadAndVerify(directory, products[i].Globs, allProductFiles, b.ProductSlug, b.Release.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		bundled = append(bundled, metadata.BundledProduct{
+			ProductSlug: b.ProductSlug,
+			Version:     b.Release.Version,
+			Directory:   directory,
+			Metadata:    releaseMetadata(b.Release, releaseProductFiles, allProductFiles, fileGroups),
+			Files:       lockedFiles(directory, downloaded),
+		})
+	}
+
+	return bundled, nil
+}
+
+func (c InCommand) writeUpgradeGraph(productSlug string, version string, params concourse.InParams) error {
+	c.logger.Info("Building upgrade graph")
+
+	graph, err := c.upgradeGraphBuilder.Build(productSlug)
+	if err != nil {
+		return err
+	}
+
+	var upgradePath []string
+	if params.UpgradePathFrom != "" {
+		to := params.UpgradePathTo
+		if to == "" {
+			to = version
+		}
+
+		upgradePath, err = c.upgradeGraphBuilder.ShortestPath(graph, params.UpgradePathFrom, to)
+		if err != nil {
+			return err
+		}
+
+		c.logger.Info(fmt.Sprintf("Shortest upgrade path: %s", strings.Join(upgradePath, " -> ")))
+	}
+
+	c.logger.Info("Writing upgrade graph files")
+
+	return c.fileWriter.WriteUpgradeGraphFiles(graph, upgradePath)
+}
+
+// writeOmFiles writes the files Ops Manager automation expects for the
+// downloaded product file. If an IaaS is provided, the stemcell for it is
+// picked from the candidates and assigned to the product, which is named as
+// in its tile metadata if the tile was inspected.
+func (c InCommand) writeOmFiles(
+	productSlug string,
+	version string,
+	downloaded []metadata.LockedFile,
+	stemcells []metadata.LockedDependency,
+	stemcellIaaS string,
+	tiles []metadata.Tile,
+) error {
+	c.logger.Info("Writing om files")
+
+	product, err := omProductFile(downloaded)
+	if err != nil {
+		return err
+	}
+
+	downloadFile := metadata.OmDownloadFile{
+		ProductPath:    product.Path,
+		ProductSlug:    productSlug,
+		ProductVersion: version,
+		ProductSHA256:  product.SHA256,
+	}
+
+	if stemcellIaaS == "" {
+		return c.fileWriter.WriteOmFiles(downloadFile, nil)
+	}
+
+	stemcell, stemcellFile, err := c.stemcellForIaaS(stemcells, stemcellIaaS)
+	if err != nil {
+		return err
+	}
+
+	downloadFile.StemcellPath = stemcellFile.Path
+	downloadFile.StemcellVersion = stemcell.Version
+	downloadFile.StemcellSHA256 = stemcellFile.SHA256
+
+	productName := productSlug
+	for _, t := range tiles {
+		if t.File == filepath.Base(product.Path) && t.Name != "" {
+			productName = t.Name
+		}
+	}
+
+	return c.fileWriter.WriteOmFiles(downloadFile, &metadata.OmStemcellAssignment{
+		Product:  productName,
+		Stemcell: stemcell.Version,
+	})
+}
+
+// omProductFile returns the only downloaded file or, if several were
+// downloaded, the only tile among them.
+func omProductFile(downloaded []metadata.LockedFile) (metadata.LockedFile, error) {
+	if len(downloaded) == 1 {
+		return downloaded[0], nil
+	}
+
+	var tiles []metadata.LockedFile
+	for _, f := range downloaded {
+		if strings.HasSuffix(f.Path, ".pivotal") {
+			tiles = append(tiles, f)
+		}
+	}
+
+	if len(tiles) != 1 {
+		return metadata.LockedFile{}, fmt.Errorf(
+			"output_format: '%s' requires exactly one file or one .pivotal file to be downloaded, found: %d files and %d .pivotal files",
+			concourse.OutputFormatOm,
+			len(downloaded),
+			len(tiles),
+		)
+	}
+
+	return tiles[0], nil
+}
+
+// stemcellForIaaS returns the first stemcell file whose name contains the
+// IaaS name, e.g. 'vsphere', along with the stemcell release it belongs to.
+func (c InCommand) stemcellForIaaS(
+	stemcells []metadata.LockedDependency,
+	stemcellIaaS string,
+) (metadata.LockedDependency, metadata.LockedFile, error) {
+	iaas := strings.ToLower(stemcellIaaS)
+
+	for _, s := range stemcells {
+		for _, f := range s.Files {
+			name := strings.ToLower(filepath.Base(f.Path))
+			if strings.HasSuffix(name, ".tgz") && strings.Contains(name, iaas) {
+				c.logger.Info(fmt.Sprintf("Using stemcell: '%s' for IaaS: '%s'", f.Path, stemcellIaaS))
+				return s, f, nil
+			}
+		}
+	}
+
+	return metadata.LockedDependency{}, metadata.LockedFile{}, fmt.Errorf(
+		"no stemcell for IaaS: '%s' was downloaded - fetch one with fetch_dependencies or bundle",
+		stemcellIaaS,
+	)
+}
+
+// stemcellCandidates returns the fetched dependencies and bundle products
+// that are stemcells.
+func stemcellCandidates(
+	lock metadata.DependencyLock,
+	bundled []metadata.BundledProduct,
+) []metadata.LockedDependency {
+	var candidates []metadata.LockedDependency
+	for _, d := range lock.Dependencies {
+		if strings.HasPrefix(d.ProductSlug, "stemcells") {
+			candidates = append(candidates, d)
+		}
+	}
+
+	for _, b := range bundled {
+		if strings.HasPrefix(b.ProductSlug, "stemcells") {
+			candidates = append(candidates, metadata.LockedDependency{
+				ProductSlug: b.ProductSlug,
+				Version:     b.Version,
+				Directory:   b.Directory,
+				Files:       b.Files,
+			})
+		}
+	}
+
+	return candidates
+}
+
+func releaseMetadata(
+	release pivnet.Release,
+	releaseProductFiles []pivnet.ProductFile,
+	allProductFiles []pivnet.ProductFile,
+	fileGroups []pivnet.FileGroup,
+) metadata.Metadata {
+	mdata := metadata.Metadata{
+		Release: &metadata.Release{
+			ID:                    release.ID,
+			Version:               release.Version,
+			ReleaseType:           string(release.ReleaseType),
+			ReleaseDate:           release.ReleaseDate,
+			Description:           release.Description,
+			ReleaseNotesURL:       release.ReleaseNotesURL,
+			Availability:          release.Availability,
+			Controlled:            release.Controlled,
+			ECCN:                  release.ECCN,
+			LicenseException:      release.LicenseException,
+			EndOfSupportDate:      release.EndOfSupportDate,
+			EndOfGuidanceDate:     release.EndOfGuidanceDate,
+			EndOfAvailabilityDate: release.EndOfAvailabilityDate,
+		},
+	}
+
+	if release.EULA != nil {
+		mdata.Release.EULASlug = release.EULA.Slug
+	}
+
+	for _, pf := range releaseProductFiles {
+		mdata.Release.ProductFiles = append(mdata.Release.ProductFiles, metadata.ReleaseProductFile{
+			ID: pf.ID,
+		})
+	}
+
+	for _, pf := range allProductFiles {
+		mdata.ProductFiles = append(mdata.ProductFiles, metadata.ProductFile{
+			ID:                 pf.ID,
+			File:               pf.Name,
+			Description:        pf.Description,
+			AWSObjectKey:       pf.AWSObjectKey,
+			FileType:           pf.FileType,
+			FileVersion:        pf.FileVersion,
+			SHA256:             pf.SHA256,
+			MD5:                pf.MD5,
+			DocsURL:            pf.DocsURL,
+			SystemRequirements: pf.SystemRequirements,
+			Platforms:          pf.Platforms,
+			IncludedFiles:      pf.IncludedFiles,
+		})
+	}
+
+	for _, fg := range fileGroups {
+		mfg := metadata.FileGroup{
+			ID:   fg.ID,
+			Name: fg.Name,
+		}
+
+		for _, pf := range fg.ProductFiles {
+			mfg.ProductFiles = append(mfg.ProductFiles, metadata.FileGroupProductFile{
+				ID: pf.ID,
+			})
+		}
+
+		mdata.FileGroups = append(mdata.FileGroups, mfg)
+	}
+
+	return mdata
+}
+
+func lockedFiles(directory string, productFiles []pivnet.ProductFile) []metadata.LockedFile {
+	var files []metadata.LockedFile
+	for _, pf := range productFiles {
+		files = append(files, metadata.LockedFile{
+			ID:     pf.ID,
+			Name:   pf.Name,
+			Path:   filepath.Join(directory, productFileName(pf)),
+			SHA256: pf.SHA256,
+			MD5:    pf.MD5,
+		})
+	}
+	return files
+}
+
+// releaseDirectory returns the directory within parent that the files of a
+// release are downloaded into, i.e. <parent>/<product-slug>/<version>. The
+// product slug and version come from Pivnet or the source, so they are
+// rejected unless each is a single directory name, so that nothing is written
+// outside of the download directory.
+func releaseDirectory(parent string, productSlug string, version string) (string, error) {
+	for _, name := range []string{productSlug, version} {
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return "", fmt.Errorf(
+				"cannot download product: '%s' version: '%s' - '%s' is not a valid directory name",
+				productSlug,
+				version,
+				name,
+			)
+		}
+	}
+
+	return filepath.Join(parent, productSlug, version), nil
+}
+
+func productFileName(p pivnet.ProductFile) string {
+	parts := strings.Split(p.AWSObjectKey, "/")
+
+	if len(parts) < 1 {
+		panic("not enough components to form filename")
+	}
+
+	fileName := parts[len(parts)-1]
+
+	if fileName == "" {
+		panic("empty file name")
+	}
+
+	return fileName
+}
+
 func (c InCommand) addReleaseMetadata(
 	concourseMetadata []concourse.Metadata,
 	release pivnet.Release,
@@ -477,7 +1061,5 @@ func (c InCommand) compareSHA256sOrMD5s(filepaths []string, expectedSHA256s map[
 
 	c.logger.Info("SHA256 or MD5 matched for all downloaded files")
 
-	c.logger.Info("Get complete")
-
 	return nil
 }
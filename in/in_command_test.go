@@ -14,6 +14,8 @@ import (
 	"github.com/pivotal-cf/pivnet-resource/v3/in"
 	"github.com/pivotal-cf/pivnet-resource/v3/in/infakes"
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
 	"github.com/pivotal-cf/pivnet-resource/v3/versions"
 )
 
@@ -33,6 +35,10 @@ var _ = Describe("In", func() {
 		fakeFileWriter       *infakes.FakeFileWriter
 		fakeArchive          *infakes.FakeArchive
 
+		fakeDependencyResolver  *infakes.FakeDependencyResolver
+		fakeUpgradeGraphBuilder *infakes.FakeUpgradeGraphBuilder
+		fakeTileInspector       *infakes.FakeTileInspector
+
 		fileGroups []pivnet.FileGroup
 
 		releaseProductFiles    []pivnet.ProductFile
@@ -84,6 +90,9 @@ var _ = Describe("In", func() {
 		fakeMD5FileSummer = &infakes.FakeFileSummer{}
 		fakeFileWriter = &infakes.FakeFileWriter{}
 		fakeArchive = &infakes.FakeArchive{}
+		fakeDependencyResolver = &infakes.FakeDependencyResolver{}
+		fakeUpgradeGraphBuilder = &infakes.FakeUpgradeGraphBuilder{}
+		fakeTileInspector = &infakes.FakeTileInspector{}
 
 		getReleaseErr = nil
 		acceptEULAErr = nil
@@ -364,6 +373,9 @@ var _ = Describe("In", func() {
 			fakeMD5FileSummer,
 			fakeFileWriter,
 			fakeArchive,
+			fakeDependencyResolver,
+			fakeUpgradeGraphBuilder,
+			fakeTileInspector,
 		)
 	})
 
@@ -681,6 +693,671 @@ var _ = Describe("In", func() {
 		})
 	})
 
+	It("does not resolve dependencies or write the dependency lock file", func() {
+		_, err := inCommand.Run(inRequest)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeDependencyResolver.ResolveCallCount()).To(Equal(0))
+		Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(0))
+		Expect(fakeFileWriter.WriteDependencyLockFileCallCount()).To(Equal(0))
+	})
+
+	Describe("when fetch_dependencies is set", func() {
+		var (
+			resolvedDependencies []in.ResolvedDependency
+			resolveErr           error
+		)
+
+		BeforeEach(func() {
+			inRequest.Params.FetchDependencies = true
+
+			resolvedDependencies = []in.ResolvedDependency{
+				{
+					ProductSlug: "some-product",
+					Specifier:   "1.2.*",
+					Release: pivnet.Release{
+						ID:      2001,
+						Version: "1.2.3",
+					},
+				},
+			}
+			resolveErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeDependencyResolver.ResolveReturns(resolvedDependencies, resolveErr)
+			fakeDownloader.DownloadToSubdirectoryReturns(downloadFilepaths, downloadErr)
+		})
+
+		It("resolves the dependency specifiers of the release", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDependencyResolver.ResolveCallCount()).To(Equal(1))
+			Expect(fakeDependencyResolver.ResolveArgsForCall(0)).To(Equal(dependencySpecifiers))
+		})
+
+		It("accepts the EULA of and downloads all files of each dependency into its own directory", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePivnetClient.AcceptEULACallCount()).To(Equal(2))
+			invokedProductSlug, invokedReleaseID := fakePivnetClient.AcceptEULAArgsForCall(1)
+			Expect(invokedProductSlug).To(Equal("some-product"))
+			Expect(invokedReleaseID).To(Equal(2001))
+
+			Expect(fakeFilter.ProductFileKeysByGlobsCallCount()).To(Equal(0))
+
+			Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(1))
+			invokedSubdirectory, invokedProductFiles, invokedProductSlug, invokedReleaseID :=
+				fakeDownloader.DownloadToSubdirectoryArgsForCall(0)
+			Expect(invokedSubdirectory).To(Equal("dependencies/some-product/1.2.3"))
+			Expect(invokedProductFiles).To(Equal(filteredProductFiles))
+			Expect(invokedProductSlug).To(Equal("some-product"))
+			Expect(invokedReleaseID).To(Equal(2001))
+		})
+
+		It("writes the dependency lock file", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeFileWriter.WriteDependencyLockFileCallCount()).To(Equal(1))
+			lock := fakeFileWriter.WriteDependencyLockFileArgsForCall(0)
+
+			Expect(lock.Dependencies).To(HaveLen(1))
+			Expect(lock.Dependencies[0].ProductSlug).To(Equal("some-product"))
+			Expect(lock.Dependencies[0].Specifier).To(Equal("1.2.*"))
+			Expect(lock.Dependencies[0].ReleaseID).To(Equal(2001))
+			Expect(lock.Dependencies[0].Version).To(Equal("1.2.3"))
+			Expect(lock.Dependencies[0].Directory).To(Equal("dependencies/some-product/1.2.3"))
+
+			Expect(lock.Dependencies[0].Files).To(HaveLen(len(filteredProductFiles)))
+			Expect(lock.Dependencies[0].Files[0]).To(Equal(metadata.LockedFile{
+				ID:     1234,
+				Name:   "product file 1234",
+				Path:   "dependencies/some-product/1.2.3/file-1234",
+				SHA256: fileContentsSHA256s[0],
+				MD5:    fileContentsMD5s[0],
+			}))
+		})
+
+		Context("when dependency globs are provided for the dependent product", func() {
+			BeforeEach(func() {
+				inRequest.Params.DependencyGlobs = map[string][]string{
+					"some-product": {"file-1*"},
+				}
+			})
+
+			It("filters the files of the dependency by the globs", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeFilter.ProductFileKeysByGlobsCallCount()).To(Equal(1))
+				_, invokedGlobs := fakeFilter.ProductFileKeysByGlobsArgsForCall(0)
+				Expect(invokedGlobs).To(Equal([]string{"file-1*"}))
+			})
+		})
+
+		Context("when multiple specifiers resolve to the same release", func() {
+			BeforeEach(func() {
+				resolvedDependencies = append(resolvedDependencies, in.ResolvedDependency{
+					ProductSlug: "some-product",
+					Specifier:   "~> 1.2",
+					Release:     resolvedDependencies[0].Release,
+				})
+			})
+
+			It("downloads the release once and locks both specifiers", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(1))
+
+				lock := fakeFileWriter.WriteDependencyLockFileArgsForCall(0)
+				Expect(lock.Dependencies).To(HaveLen(2))
+				Expect(lock.Dependencies[1].Specifier).To(Equal("~> 1.2"))
+				Expect(lock.Dependencies[1].Files).To(Equal(lock.Dependencies[0].Files))
+			})
+		})
+
+		Context("when the version of a dependency is not a valid directory name", func() {
+			BeforeEach(func() {
+				resolvedDependencies[0].Release.Version = "../../1.2.3"
+			})
+
+			It("returns an error without downloading anything", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(MatchError("cannot download product: 'some-product' version: '../../1.2.3' - '../../1.2.3' is not a valid directory name"))
+
+				Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when resolving dependencies returns an error", func() {
+			BeforeEach(func() {
+				resolveErr = fmt.Errorf("some resolve error")
+			})
+
+			It("returns the error", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(Equal(resolveErr))
+			})
+		})
+
+		Context("when the checksum of a dependency file does not match", func() {
+			BeforeEach(func() {
+				fileContentsSHA256s[0] = "some-other-sha256"
+			})
+
+			JustBeforeEach(func() {
+				fakeDownloader.DownloadReturns(nil, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("SHA256 comparison failed"))
+			})
+		})
+	})
+
+	It("does not write a bundle manifest", func() {
+		_, err := inCommand.Run(inRequest)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeFileWriter.WriteBundleManifestFileCallCount()).To(Equal(0))
+	})
+
+	Describe("when a bundle is provided", func() {
+		var (
+			resolvedBundle []in.ResolvedDependency
+			resolveErr     error
+		)
+
+		BeforeEach(func() {
+			inRequest.Params.Bundle = []concourse.BundleProduct{
+				{ProductSlug: "stemcells", Version: "~> 621.0"},
+				{ProductSlug: "companion", Version: "2.0.1", Globs: []string{"file-1*"}},
+			}
+
+			resolvedBundle = []in.ResolvedDependency{
+				{
+					ProductSlug: "stemcells",
+					Specifier:   "~> 621.0",
+					Release:     pivnet.Release{ID: 3001, Version: "621.5"},
+				},
+				{
+					ProductSlug: "companion",
+					Specifier:   "2.0.1",
+					Release:     pivnet.Release{ID: 3002, Version: "2.0.1"},
+				},
+			}
+			resolveErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeDependencyResolver.ResolveReturns(resolvedBundle, resolveErr)
+			fakeDownloader.DownloadToSubdirectoryReturns(downloadFilepaths, downloadErr)
+		})
+
+		It("resolves the version of each bundle product", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDependencyResolver.ResolveCallCount()).To(Equal(1))
+			Expect(fakeDependencyResolver.ResolveArgsForCall(0)).To(Equal([]pivnet.DependencySpecifier{
+				{Product: pivnet.Product{Slug: "stemcells"}, Specifier: "~> 621.0"},
+				{Product: pivnet.Product{Slug: "companion"}, Specifier: "2.0.1"},
+			}))
+		})
+
+		It("accepts the EULA of every product before downloading", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakePivnetClient.AcceptEULACallCount()).To(Equal(3))
+			invokedProductSlug, invokedReleaseID := fakePivnetClient.AcceptEULAArgsForCall(1)
+			Expect(invokedProductSlug).To(Equal("stemcells"))
+			Expect(invokedReleaseID).To(Equal(3001))
+			invokedProductSlug, invokedReleaseID = fakePivnetClient.AcceptEULAArgsForCall(2)
+			Expect(invokedProductSlug).To(Equal("companion"))
+			Expect(invokedReleaseID).To(Equal(3002))
+		})
+
+		It("downloads each bundle product into its own directory", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(2))
+			invokedSubdirectory, _, invokedProductSlug, invokedReleaseID :=
+				fakeDownloader.DownloadToSubdirectoryArgsForCall(0)
+			Expect(invokedSubdirectory).To(Equal("bundle/stemcells/621.5"))
+			Expect(invokedProductSlug).To(Equal("stemcells"))
+			Expect(invokedReleaseID).To(Equal(3001))
+
+			invokedSubdirectory, invokedProductFiles, _, _ := fakeDownloader.DownloadToSubdirectoryArgsForCall(1)
+			Expect(invokedSubdirectory).To(Equal("bundle/companion/2.0.1"))
+			Expect(invokedProductFiles).To(Equal(filteredProductFiles))
+
+			Expect(fakeFilter.ProductFileKeysByGlobsCallCount()).To(Equal(1))
+			_, invokedGlobs := fakeFilter.ProductFileKeysByGlobsArgsForCall(0)
+			Expect(invokedGlobs).To(Equal([]string{"file-1*"}))
+		})
+
+		It("writes a manifest of every product", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeFileWriter.WriteBundleManifestFileCallCount()).To(Equal(1))
+			manifest := fakeFileWriter.WriteBundleManifestFileArgsForCall(0)
+
+			Expect(manifest.Products).To(HaveLen(3))
+
+			Expect(manifest.Products[0].ProductSlug).To(Equal(productSlug))
+			Expect(manifest.Products[0].Directory).To(Equal("."))
+			Expect(manifest.Products[0].Metadata).To(Equal(fakeFileWriter.WriteMetadataYAMLFileArgsForCall(0)))
+
+			Expect(manifest.Products[1].ProductSlug).To(Equal("stemcells"))
+			Expect(manifest.Products[1].Version).To(Equal("621.5"))
+			Expect(manifest.Products[1].Directory).To(Equal("bundle/stemcells/621.5"))
+			Expect(manifest.Products[1].Metadata.Release.ID).To(Equal(3001))
+			Expect(manifest.Products[1].Metadata.ProductFiles).To(HaveLen(len(filteredProductFiles)))
+			Expect(manifest.Products[1].Files[0]).To(Equal(metadata.LockedFile{
+				ID:     1234,
+				Name:   "product file 1234",
+				Path:   "bundle/stemcells/621.5/file-1234",
+				SHA256: fileContentsSHA256s[0],
+				MD5:    fileContentsMD5s[0],
+			}))
+
+			Expect(manifest.Products[2].ProductSlug).To(Equal("companion"))
+		})
+
+		Context("when the product slug of a bundle product is not a valid directory name", func() {
+			BeforeEach(func() {
+				resolvedBundle[1].ProductSlug = ".."
+			})
+
+			It("returns an error without downloading it", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(MatchError("cannot download product: '..' version: '2.0.1' - '..' is not a valid directory name"))
+
+				Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when resolving the bundle returns an error", func() {
+			BeforeEach(func() {
+				resolveErr = fmt.Errorf("some resolve error")
+			})
+
+			It("returns the error without accepting any EULA", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(Equal(resolveErr))
+
+				Expect(fakePivnetClient.AcceptEULACallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when accepting EULAs fails for several products", func() {
+			JustBeforeEach(func() {
+				fakePivnetClient.AcceptEULAStub = func(productSlug string, releaseID int) error {
+					if releaseID == 3001 || releaseID == 3002 {
+						return fmt.Errorf("eula error for %d", releaseID)
+					}
+					return nil
+				}
+			})
+
+			It("attempts every EULA and returns one error for all of them", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakePivnetClient.AcceptEULACallCount()).To(Equal(3))
+				Expect(err.Error()).To(ContainSubstring("failed to accept EULA for 2 product(s)"))
+				Expect(err.Error()).To(ContainSubstring("'stemcells' version '621.5': eula error for 3001"))
+				Expect(err.Error()).To(ContainSubstring("'companion' version '2.0.1': eula error for 3002"))
+
+				Expect(fakeDownloader.DownloadCallCount()).To(Equal(0))
+				Expect(fakeDownloader.DownloadToSubdirectoryCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	It("does not inspect tiles", func() {
+		_, err := inCommand.Run(inRequest)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeTileInspector.InspectCallCount()).To(Equal(0))
+	})
+
+	Describe("when inspect_tiles is set", func() {
+		var (
+			inspectedTile tile.Tile
+			inspectErr    error
+		)
+
+		BeforeEach(func() {
+			inRequest.Params.InspectTiles = true
+
+			downloadFilepaths[1] = "some-tile-2.10.3.pivotal"
+			releaseProductFiles[1].AWSObjectKey = "product/some-tile-2.10.3.pivotal"
+
+			inspectedTile = tile.Tile{
+				Name:           "cf",
+				ProductVersion: "2.10.3-build.4",
+				StemcellCriteria: tile.StemcellCriteria{
+					OS:      "ubuntu-jammy",
+					Version: "1.100",
+				},
+				Releases: []tile.Release{
+					{Name: "routing", File: "routing-0.200.0.tgz", Version: "0.200.0"},
+				},
+			}
+			inspectErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeTileInspector.InspectReturns(inspectedTile, inspectErr)
+		})
+
+		It("inspects each downloaded tile", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTileInspector.InspectCallCount()).To(Equal(1))
+			Expect(fakeTileInspector.InspectArgsForCall(0)).To(Equal("some-tile-2.10.3.pivotal"))
+		})
+
+		It("adds the tile to the written metadata", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			invokedMetadata := fakeFileWriter.WriteMetadataYAMLFileArgsForCall(0)
+			Expect(invokedMetadata.Tiles).To(Equal([]metadata.Tile{
+				{
+					File:               "some-tile-2.10.3.pivotal",
+					Name:               "cf",
+					TileProductVersion: "2.10.3-build.4",
+					StemcellCriteria: metadata.TileStemcellCriteria{
+						OS:      "ubuntu-jammy",
+						Version: "1.100",
+					},
+					Releases: []metadata.TileRelease{
+						{Name: "routing", File: "routing-0.200.0.tgz", Version: "0.200.0"},
+					},
+				},
+			}))
+		})
+
+		Context("when inspecting a tile returns an error", func() {
+			BeforeEach(func() {
+				inspectErr = fmt.Errorf("some inspect error")
+			})
+
+			It("returns the error", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(Equal(inspectErr))
+			})
+		})
+
+		Context("when output_format is om and stemcell_iaas is provided", func() {
+			BeforeEach(func() {
+				inRequest.Params.OutputFormat = concourse.OutputFormatOm
+				inRequest.Params.StemcellIaaS = "vsphere"
+				inRequest.Params.Bundle = []concourse.BundleProduct{
+					{ProductSlug: "stemcells-ubuntu-jammy", Version: "1.100"},
+				}
+
+				fileGroups[1].ProductFiles[0].AWSObjectKey = "product/bosh-stemcell-1.100-vsphere-esxi-ubuntu-jammy-go_agent.tgz"
+			})
+
+			JustBeforeEach(func() {
+				fakeDependencyResolver.ResolveReturns([]in.ResolvedDependency{
+					{
+						ProductSlug: "stemcells-ubuntu-jammy",
+						Specifier:   "1.100",
+						Release:     pivnet.Release{ID: 2001, Version: "1.100"},
+					},
+				}, nil)
+				fakeDownloader.DownloadToSubdirectoryReturns(nil, nil)
+			})
+
+			It("assigns the stemcell to the product named in the tile", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, assignment := fakeFileWriter.WriteOmFilesArgsForCall(0)
+				Expect(assignment.Product).To(Equal("cf"))
+			})
+		})
+	})
+
+	It("does not write om files", func() {
+		_, err := inCommand.Run(inRequest)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeFileWriter.WriteOmFilesCallCount()).To(Equal(0))
+	})
+
+	Describe("when output_format is om", func() {
+		BeforeEach(func() {
+			inRequest.Params.OutputFormat = concourse.OutputFormatOm
+			inRequest.Params.Globs = []string{"file-1234"}
+		})
+
+		JustBeforeEach(func() {
+			fakeFilter.ProductFileKeysByGlobsReturnsOnCall(0, []pivnet.ProductFile{releaseProductFiles[0]}, nil)
+		})
+
+		It("writes a download file describing the downloaded product file", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeFileWriter.WriteOmFilesCallCount()).To(Equal(1))
+			downloadFile, assignment := fakeFileWriter.WriteOmFilesArgsForCall(0)
+			Expect(downloadFile).To(Equal(metadata.OmDownloadFile{
+				ProductPath:    "file-1234",
+				ProductSlug:    productSlug,
+				ProductVersion: version,
+				ProductSHA256:  fileContentsSHA256s[0],
+			}))
+			Expect(assignment).To(BeNil())
+		})
+
+		Context("when several files are downloaded", func() {
+			BeforeEach(func() {
+				releaseProductFiles[1].AWSObjectKey = "product/some-tile-1.2.3.pivotal"
+			})
+
+			JustBeforeEach(func() {
+				fakeFilter.ProductFileKeysByGlobsReturnsOnCall(0, releaseProductFiles, nil)
+			})
+
+			It("describes the downloaded tile", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				downloadFile, _ := fakeFileWriter.WriteOmFilesArgsForCall(0)
+				Expect(downloadFile.ProductPath).To(Equal("some-tile-1.2.3.pivotal"))
+			})
+		})
+
+		Context("when several files but no tile are downloaded", func() {
+			JustBeforeEach(func() {
+				fakeFilter.ProductFileKeysByGlobsReturnsOnCall(0, releaseProductFiles, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("requires exactly one file or one .pivotal file"))
+			})
+		})
+
+		Context("when stemcell_iaas is provided", func() {
+			var (
+				stemcellFilename string
+			)
+
+			BeforeEach(func() {
+				inRequest.Params.StemcellIaaS = "vSphere"
+				inRequest.Params.FetchDependencies = true
+
+				stemcellFilename = "bosh-stemcell-621.5-vsphere-esxi-ubuntu-jammy-go_agent.tgz"
+				filteredProductFiles[3].AWSObjectKey = "product/" + stemcellFilename
+				fileGroups[1].ProductFiles[0].AWSObjectKey = "product/" + stemcellFilename
+			})
+
+			JustBeforeEach(func() {
+				fakeDependencyResolver.ResolveReturns([]in.ResolvedDependency{
+					{
+						ProductSlug: "stemcells-ubuntu-jammy",
+						Specifier:   "621.*",
+						Release:     pivnet.Release{ID: 2001, Version: "621.5"},
+					},
+				}, nil)
+			})
+
+			It("describes and assigns the stemcell for the IaaS", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				downloadFile, assignment := fakeFileWriter.WriteOmFilesArgsForCall(0)
+				Expect(downloadFile.StemcellPath).To(Equal("dependencies/stemcells-ubuntu-jammy/621.5/" + stemcellFilename))
+				Expect(downloadFile.StemcellVersion).To(Equal("621.5"))
+				Expect(downloadFile.StemcellSHA256).To(Equal(fileContentsSHA256s[3]))
+
+				Expect(assignment).To(Equal(&metadata.OmStemcellAssignment{
+					Product:  productSlug,
+					Stemcell: "621.5",
+				}))
+			})
+
+			Context("when no stemcell for the IaaS was downloaded", func() {
+				BeforeEach(func() {
+					inRequest.Params.StemcellIaaS = "azure"
+				})
+
+				It("returns an error", func() {
+					_, err := inCommand.Run(inRequest)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("no stemcell for IaaS: 'azure' was downloaded"))
+				})
+			})
+		})
+	})
+
+	It("does not build the upgrade graph", func() {
+		_, err := inCommand.Run(inRequest)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeUpgradeGraphBuilder.BuildCallCount()).To(Equal(0))
+		Expect(fakeFileWriter.WriteUpgradeGraphFilesCallCount()).To(Equal(0))
+	})
+
+	Describe("when upgrade_graph is set", func() {
+		var (
+			graph           upgradegraph.Graph
+			buildErr        error
+			upgradePath     []string
+			shortestPathErr error
+		)
+
+		BeforeEach(func() {
+			inRequest.Params.UpgradeGraph = true
+
+			graph = upgradegraph.Graph{
+				ProductSlug: productSlug,
+				Releases: []upgradegraph.Release{
+					{ID: 1234, Version: version},
+					{ID: 1233, Version: "B"},
+				},
+				UpgradePaths: []upgradegraph.Edge{
+					{From: "B", To: version},
+				},
+			}
+			buildErr = nil
+			upgradePath = []string{"B", version}
+			shortestPathErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeUpgradeGraphBuilder.BuildReturns(graph, buildErr)
+			fakeUpgradeGraphBuilder.ShortestPathReturns(upgradePath, shortestPathErr)
+		})
+
+		It("builds the upgrade graph for the product and writes it", func() {
+			_, err := inCommand.Run(inRequest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeUpgradeGraphBuilder.BuildCallCount()).To(Equal(1))
+			Expect(fakeUpgradeGraphBuilder.BuildArgsForCall(0)).To(Equal(productSlug))
+			Expect(fakeUpgradeGraphBuilder.ShortestPathCallCount()).To(Equal(0))
+
+			Expect(fakeFileWriter.WriteUpgradeGraphFilesCallCount()).To(Equal(1))
+			invokedGraph, invokedPath := fakeFileWriter.WriteUpgradeGraphFilesArgsForCall(0)
+			Expect(invokedGraph).To(Equal(graph))
+			Expect(invokedPath).To(BeNil())
+		})
+
+		Context("when upgrade_path_from is provided", func() {
+			BeforeEach(func() {
+				inRequest.Params.UpgradePathFrom = "B"
+			})
+
+			It("writes the shortest upgrade path to the fetched version", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeUpgradeGraphBuilder.ShortestPathCallCount()).To(Equal(1))
+				invokedGraph, invokedFrom, invokedTo := fakeUpgradeGraphBuilder.ShortestPathArgsForCall(0)
+				Expect(invokedGraph).To(Equal(graph))
+				Expect(invokedFrom).To(Equal("B"))
+				Expect(invokedTo).To(Equal(version))
+
+				_, invokedPath := fakeFileWriter.WriteUpgradeGraphFilesArgsForCall(0)
+				Expect(invokedPath).To(Equal(upgradePath))
+			})
+
+			Context("when upgrade_path_to is provided", func() {
+				BeforeEach(func() {
+					inRequest.Params.UpgradePathTo = "D"
+				})
+
+				It("computes the shortest upgrade path to that version", func() {
+					_, err := inCommand.Run(inRequest)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, _, invokedTo := fakeUpgradeGraphBuilder.ShortestPathArgsForCall(0)
+					Expect(invokedTo).To(Equal("D"))
+				})
+			})
+
+			Context("when computing the shortest path returns an error", func() {
+				BeforeEach(func() {
+					shortestPathErr = fmt.Errorf("some shortest path error")
+				})
+
+				It("returns the error", func() {
+					_, err := inCommand.Run(inRequest)
+					Expect(err).To(Equal(shortestPathErr))
+				})
+			})
+		})
+
+		Context("when building the upgrade graph returns an error", func() {
+			BeforeEach(func() {
+				buildErr = fmt.Errorf("some build error")
+			})
+
+			It("returns the error", func() {
+				_, err := inCommand.Run(inRequest)
+				Expect(err).To(Equal(buildErr))
+			})
+		})
+	})
+
 	Context("when getting release dependencies returns an error", func() {
 		BeforeEach(func() {
 			releaseDependenciesErr = fmt.Errorf("some release dependencies error")
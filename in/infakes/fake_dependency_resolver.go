@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package infakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/pivnet-resource/v3/in"
+)
+
+type FakeDependencyResolver struct {
+	ResolveStub        func([]pivnet.DependencySpecifier) ([]in.ResolvedDependency, error)
+	resolveMutex       sync.RWMutex
+	resolveArgsForCall []struct {
+		arg1 []pivnet.DependencySpecifier
+	}
+	resolveReturns struct {
+		result1 []in.ResolvedDependency
+		result2 error
+	}
+	resolveReturnsOnCall map[int]struct {
+		result1 []in.ResolvedDependency
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDependencyResolver) Resolve(arg1 []pivnet.DependencySpecifier) ([]in.ResolvedDependency, error) {
+	var arg1Copy []pivnet.DependencySpecifier
+	if arg1 != nil {
+		arg1Copy = make([]pivnet.DependencySpecifier, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.resolveMutex.Lock()
+	ret, specificReturn := fake.resolveReturnsOnCall[len(fake.resolveArgsForCall)]
+	fake.resolveArgsForCall = append(fake.resolveArgsForCall, struct {
+		arg1 []pivnet.DependencySpecifier
+	}{arg1Copy})
+	stub := fake.ResolveStub
+	fakeReturns := fake.resolveReturns
+	fake.recordInvocation("Resolve", []interface{}{arg1Copy})
+	fake.resolveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDependencyResolver) ResolveCallCount() int {
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	return len(fake.resolveArgsForCall)
+}
+
+func (fake *FakeDependencyResolver) ResolveCalls(stub func([]pivnet.DependencySpecifier) ([]in.ResolvedDependency, error)) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = stub
+}
+
+func (fake *FakeDependencyResolver) ResolveArgsForCall(i int) []pivnet.DependencySpecifier {
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	argsForCall := fake.resolveArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDependencyResolver) ResolveReturns(result1 []in.ResolvedDependency, result2 error) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = nil
+	fake.resolveReturns = struct {
+		result1 []in.ResolvedDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependencyResolver) ResolveReturnsOnCall(i int, result1 []in.ResolvedDependency, result2 error) {
+	fake.resolveMutex.Lock()
+	defer fake.resolveMutex.Unlock()
+	fake.ResolveStub = nil
+	if fake.resolveReturnsOnCall == nil {
+		fake.resolveReturnsOnCall = make(map[int]struct {
+			result1 []in.ResolvedDependency
+			result2 error
+		})
+	}
+	fake.resolveReturnsOnCall[i] = struct {
+		result1 []in.ResolvedDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependencyResolver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.resolveMutex.RLock()
+	defer fake.resolveMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDependencyResolver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
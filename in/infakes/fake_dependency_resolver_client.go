@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package infakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type FakeDependencyResolverClient struct {
+	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
+	releasesForProductSlugMutex       sync.RWMutex
+	releasesForProductSlugArgsForCall []struct {
+		arg1 string
+	}
+	releasesForProductSlugReturns struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	releasesForProductSlugReturnsOnCall map[int]struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
+	fake.releasesForProductSlugMutex.Lock()
+	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
+	fake.releasesForProductSlugArgsForCall = append(fake.releasesForProductSlugArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleasesForProductSlugStub
+	fakeReturns := fake.releasesForProductSlugReturns
+	fake.recordInvocation("ReleasesForProductSlug", []interface{}{arg1})
+	fake.releasesForProductSlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlugCallCount() int {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	return len(fake.releasesForProductSlugArgsForCall)
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlugCalls(stub func(string) ([]pivnet.Release, error)) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = stub
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlugArgsForCall(i int) string {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	argsForCall := fake.releasesForProductSlugArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlugReturns(result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	fake.releasesForProductSlugReturns = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependencyResolverClient) ReleasesForProductSlugReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	if fake.releasesForProductSlugReturnsOnCall == nil {
+		fake.releasesForProductSlugReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.Release
+			result2 error
+		})
+	}
+	fake.releasesForProductSlugReturnsOnCall[i] = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependencyResolverClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDependencyResolverClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -23,6 +23,22 @@ type FakeDownloader struct {
 		result1 []string
 		result2 error
 	}
+	DownloadToSubdirectoryStub        func(string, []pivnet.ProductFile, string, int) ([]string, error)
+	downloadToSubdirectoryMutex       sync.RWMutex
+	downloadToSubdirectoryArgsForCall []struct {
+		arg1 string
+		arg2 []pivnet.ProductFile
+		arg3 string
+		arg4 int
+	}
+	downloadToSubdirectoryReturns struct {
+		result1 []string
+		result2 error
+	}
+	downloadToSubdirectoryReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -98,11 +114,85 @@ func (fake *FakeDownloader) DownloadReturnsOnCall(i int, result1 []string, resul
 	}{result1, result2}
 }
 
+func (fake *FakeDownloader) DownloadToSubdirectory(arg1 string, arg2 []pivnet.ProductFile, arg3 string, arg4 int) ([]string, error) {
+	var arg2Copy []pivnet.ProductFile
+	if arg2 != nil {
+		arg2Copy = make([]pivnet.ProductFile, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.downloadToSubdirectoryMutex.Lock()
+	ret, specificReturn := fake.downloadToSubdirectoryReturnsOnCall[len(fake.downloadToSubdirectoryArgsForCall)]
+	fake.downloadToSubdirectoryArgsForCall = append(fake.downloadToSubdirectoryArgsForCall, struct {
+		arg1 string
+		arg2 []pivnet.ProductFile
+		arg3 string
+		arg4 int
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.DownloadToSubdirectoryStub
+	fakeReturns := fake.downloadToSubdirectoryReturns
+	fake.recordInvocation("DownloadToSubdirectory", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.downloadToSubdirectoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDownloader) DownloadToSubdirectoryCallCount() int {
+	fake.downloadToSubdirectoryMutex.RLock()
+	defer fake.downloadToSubdirectoryMutex.RUnlock()
+	return len(fake.downloadToSubdirectoryArgsForCall)
+}
+
+func (fake *FakeDownloader) DownloadToSubdirectoryCalls(stub func(string, []pivnet.ProductFile, string, int) ([]string, error)) {
+	fake.downloadToSubdirectoryMutex.Lock()
+	defer fake.downloadToSubdirectoryMutex.Unlock()
+	fake.DownloadToSubdirectoryStub = stub
+}
+
+func (fake *FakeDownloader) DownloadToSubdirectoryArgsForCall(i int) (string, []pivnet.ProductFile, string, int) {
+	fake.downloadToSubdirectoryMutex.RLock()
+	defer fake.downloadToSubdirectoryMutex.RUnlock()
+	argsForCall := fake.downloadToSubdirectoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDownloader) DownloadToSubdirectoryReturns(result1 []string, result2 error) {
+	fake.downloadToSubdirectoryMutex.Lock()
+	defer fake.downloadToSubdirectoryMutex.Unlock()
+	fake.DownloadToSubdirectoryStub = nil
+	fake.downloadToSubdirectoryReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDownloader) DownloadToSubdirectoryReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.downloadToSubdirectoryMutex.Lock()
+	defer fake.downloadToSubdirectoryMutex.Unlock()
+	fake.DownloadToSubdirectoryStub = nil
+	if fake.downloadToSubdirectoryReturnsOnCall == nil {
+		fake.downloadToSubdirectoryReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.downloadToSubdirectoryReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeDownloader) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.downloadMutex.RLock()
 	defer fake.downloadMutex.RUnlock()
+	fake.downloadToSubdirectoryMutex.RLock()
+	defer fake.downloadToSubdirectoryMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
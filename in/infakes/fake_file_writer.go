@@ -5,9 +5,32 @@ import (
 	"sync"
 
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
 )
 
 type FakeFileWriter struct {
+	WriteBundleManifestFileStub        func(metadata.BundleManifest) error
+	writeBundleManifestFileMutex       sync.RWMutex
+	writeBundleManifestFileArgsForCall []struct {
+		arg1 metadata.BundleManifest
+	}
+	writeBundleManifestFileReturns struct {
+		result1 error
+	}
+	writeBundleManifestFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteDependencyLockFileStub        func(metadata.DependencyLock) error
+	writeDependencyLockFileMutex       sync.RWMutex
+	writeDependencyLockFileArgsForCall []struct {
+		arg1 metadata.DependencyLock
+	}
+	writeDependencyLockFileReturns struct {
+		result1 error
+	}
+	writeDependencyLockFileReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteMetadataJSONFileStub        func(metadata.Metadata) error
 	writeMetadataJSONFileMutex       sync.RWMutex
 	writeMetadataJSONFileArgsForCall []struct {
@@ -30,6 +53,30 @@ type FakeFileWriter struct {
 	writeMetadataYAMLFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WriteOmFilesStub        func(metadata.OmDownloadFile, *metadata.OmStemcellAssignment) error
+	writeOmFilesMutex       sync.RWMutex
+	writeOmFilesArgsForCall []struct {
+		arg1 metadata.OmDownloadFile
+		arg2 *metadata.OmStemcellAssignment
+	}
+	writeOmFilesReturns struct {
+		result1 error
+	}
+	writeOmFilesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	WriteUpgradeGraphFilesStub        func(upgradegraph.Graph, []string) error
+	writeUpgradeGraphFilesMutex       sync.RWMutex
+	writeUpgradeGraphFilesArgsForCall []struct {
+		arg1 upgradegraph.Graph
+		arg2 []string
+	}
+	writeUpgradeGraphFilesReturns struct {
+		result1 error
+	}
+	writeUpgradeGraphFilesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteVersionFileStub        func(string) error
 	writeVersionFileMutex       sync.RWMutex
 	writeVersionFileArgsForCall []struct {
@@ -45,6 +92,128 @@ type FakeFileWriter struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeFileWriter) WriteBundleManifestFile(arg1 metadata.BundleManifest) error {
+	fake.writeBundleManifestFileMutex.Lock()
+	ret, specificReturn := fake.writeBundleManifestFileReturnsOnCall[len(fake.writeBundleManifestFileArgsForCall)]
+	fake.writeBundleManifestFileArgsForCall = append(fake.writeBundleManifestFileArgsForCall, struct {
+		arg1 metadata.BundleManifest
+	}{arg1})
+	stub := fake.WriteBundleManifestFileStub
+	fakeReturns := fake.writeBundleManifestFileReturns
+	fake.recordInvocation("WriteBundleManifestFile", []interface{}{arg1})
+	fake.writeBundleManifestFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileWriter) WriteBundleManifestFileCallCount() int {
+	fake.writeBundleManifestFileMutex.RLock()
+	defer fake.writeBundleManifestFileMutex.RUnlock()
+	return len(fake.writeBundleManifestFileArgsForCall)
+}
+
+func (fake *FakeFileWriter) WriteBundleManifestFileCalls(stub func(metadata.BundleManifest) error) {
+	fake.writeBundleManifestFileMutex.Lock()
+	defer fake.writeBundleManifestFileMutex.Unlock()
+	fake.WriteBundleManifestFileStub = stub
+}
+
+func (fake *FakeFileWriter) WriteBundleManifestFileArgsForCall(i int) metadata.BundleManifest {
+	fake.writeBundleManifestFileMutex.RLock()
+	defer fake.writeBundleManifestFileMutex.RUnlock()
+	argsForCall := fake.writeBundleManifestFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileWriter) WriteBundleManifestFileReturns(result1 error) {
+	fake.writeBundleManifestFileMutex.Lock()
+	defer fake.writeBundleManifestFileMutex.Unlock()
+	fake.WriteBundleManifestFileStub = nil
+	fake.writeBundleManifestFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteBundleManifestFileReturnsOnCall(i int, result1 error) {
+	fake.writeBundleManifestFileMutex.Lock()
+	defer fake.writeBundleManifestFileMutex.Unlock()
+	fake.WriteBundleManifestFileStub = nil
+	if fake.writeBundleManifestFileReturnsOnCall == nil {
+		fake.writeBundleManifestFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeBundleManifestFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFile(arg1 metadata.DependencyLock) error {
+	fake.writeDependencyLockFileMutex.Lock()
+	ret, specificReturn := fake.writeDependencyLockFileReturnsOnCall[len(fake.writeDependencyLockFileArgsForCall)]
+	fake.writeDependencyLockFileArgsForCall = append(fake.writeDependencyLockFileArgsForCall, struct {
+		arg1 metadata.DependencyLock
+	}{arg1})
+	stub := fake.WriteDependencyLockFileStub
+	fakeReturns := fake.writeDependencyLockFileReturns
+	fake.recordInvocation("WriteDependencyLockFile", []interface{}{arg1})
+	fake.writeDependencyLockFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFileCallCount() int {
+	fake.writeDependencyLockFileMutex.RLock()
+	defer fake.writeDependencyLockFileMutex.RUnlock()
+	return len(fake.writeDependencyLockFileArgsForCall)
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFileCalls(stub func(metadata.DependencyLock) error) {
+	fake.writeDependencyLockFileMutex.Lock()
+	defer fake.writeDependencyLockFileMutex.Unlock()
+	fake.WriteDependencyLockFileStub = stub
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFileArgsForCall(i int) metadata.DependencyLock {
+	fake.writeDependencyLockFileMutex.RLock()
+	defer fake.writeDependencyLockFileMutex.RUnlock()
+	argsForCall := fake.writeDependencyLockFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFileReturns(result1 error) {
+	fake.writeDependencyLockFileMutex.Lock()
+	defer fake.writeDependencyLockFileMutex.Unlock()
+	fake.WriteDependencyLockFileStub = nil
+	fake.writeDependencyLockFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteDependencyLockFileReturnsOnCall(i int, result1 error) {
+	fake.writeDependencyLockFileMutex.Lock()
+	defer fake.writeDependencyLockFileMutex.Unlock()
+	fake.WriteDependencyLockFileStub = nil
+	if fake.writeDependencyLockFileReturnsOnCall == nil {
+		fake.writeDependencyLockFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeDependencyLockFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileWriter) WriteMetadataJSONFile(arg1 metadata.Metadata) error {
 	fake.writeMetadataJSONFileMutex.Lock()
 	ret, specificReturn := fake.writeMetadataJSONFileReturnsOnCall[len(fake.writeMetadataJSONFileArgsForCall)]
@@ -167,6 +336,135 @@ func (fake *FakeFileWriter) WriteMetadataYAMLFileReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *FakeFileWriter) WriteOmFiles(arg1 metadata.OmDownloadFile, arg2 *metadata.OmStemcellAssignment) error {
+	fake.writeOmFilesMutex.Lock()
+	ret, specificReturn := fake.writeOmFilesReturnsOnCall[len(fake.writeOmFilesArgsForCall)]
+	fake.writeOmFilesArgsForCall = append(fake.writeOmFilesArgsForCall, struct {
+		arg1 metadata.OmDownloadFile
+		arg2 *metadata.OmStemcellAssignment
+	}{arg1, arg2})
+	stub := fake.WriteOmFilesStub
+	fakeReturns := fake.writeOmFilesReturns
+	fake.recordInvocation("WriteOmFiles", []interface{}{arg1, arg2})
+	fake.writeOmFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileWriter) WriteOmFilesCallCount() int {
+	fake.writeOmFilesMutex.RLock()
+	defer fake.writeOmFilesMutex.RUnlock()
+	return len(fake.writeOmFilesArgsForCall)
+}
+
+func (fake *FakeFileWriter) WriteOmFilesCalls(stub func(metadata.OmDownloadFile, *metadata.OmStemcellAssignment) error) {
+	fake.writeOmFilesMutex.Lock()
+	defer fake.writeOmFilesMutex.Unlock()
+	fake.WriteOmFilesStub = stub
+}
+
+func (fake *FakeFileWriter) WriteOmFilesArgsForCall(i int) (metadata.OmDownloadFile, *metadata.OmStemcellAssignment) {
+	fake.writeOmFilesMutex.RLock()
+	defer fake.writeOmFilesMutex.RUnlock()
+	argsForCall := fake.writeOmFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileWriter) WriteOmFilesReturns(result1 error) {
+	fake.writeOmFilesMutex.Lock()
+	defer fake.writeOmFilesMutex.Unlock()
+	fake.WriteOmFilesStub = nil
+	fake.writeOmFilesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteOmFilesReturnsOnCall(i int, result1 error) {
+	fake.writeOmFilesMutex.Lock()
+	defer fake.writeOmFilesMutex.Unlock()
+	fake.WriteOmFilesStub = nil
+	if fake.writeOmFilesReturnsOnCall == nil {
+		fake.writeOmFilesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeOmFilesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFiles(arg1 upgradegraph.Graph, arg2 []string) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.writeUpgradeGraphFilesMutex.Lock()
+	ret, specificReturn := fake.writeUpgradeGraphFilesReturnsOnCall[len(fake.writeUpgradeGraphFilesArgsForCall)]
+	fake.writeUpgradeGraphFilesArgsForCall = append(fake.writeUpgradeGraphFilesArgsForCall, struct {
+		arg1 upgradegraph.Graph
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.WriteUpgradeGraphFilesStub
+	fakeReturns := fake.writeUpgradeGraphFilesReturns
+	fake.recordInvocation("WriteUpgradeGraphFiles", []interface{}{arg1, arg2Copy})
+	fake.writeUpgradeGraphFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFilesCallCount() int {
+	fake.writeUpgradeGraphFilesMutex.RLock()
+	defer fake.writeUpgradeGraphFilesMutex.RUnlock()
+	return len(fake.writeUpgradeGraphFilesArgsForCall)
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFilesCalls(stub func(upgradegraph.Graph, []string) error) {
+	fake.writeUpgradeGraphFilesMutex.Lock()
+	defer fake.writeUpgradeGraphFilesMutex.Unlock()
+	fake.WriteUpgradeGraphFilesStub = stub
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFilesArgsForCall(i int) (upgradegraph.Graph, []string) {
+	fake.writeUpgradeGraphFilesMutex.RLock()
+	defer fake.writeUpgradeGraphFilesMutex.RUnlock()
+	argsForCall := fake.writeUpgradeGraphFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFilesReturns(result1 error) {
+	fake.writeUpgradeGraphFilesMutex.Lock()
+	defer fake.writeUpgradeGraphFilesMutex.Unlock()
+	fake.WriteUpgradeGraphFilesStub = nil
+	fake.writeUpgradeGraphFilesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileWriter) WriteUpgradeGraphFilesReturnsOnCall(i int, result1 error) {
+	fake.writeUpgradeGraphFilesMutex.Lock()
+	defer fake.writeUpgradeGraphFilesMutex.Unlock()
+	fake.WriteUpgradeGraphFilesStub = nil
+	if fake.writeUpgradeGraphFilesReturnsOnCall == nil {
+		fake.writeUpgradeGraphFilesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeUpgradeGraphFilesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeFileWriter) WriteVersionFile(arg1 string) error {
 	fake.writeVersionFileMutex.Lock()
 	ret, specificReturn := fake.writeVersionFileReturnsOnCall[len(fake.writeVersionFileArgsForCall)]
@@ -231,10 +529,18 @@ func (fake *FakeFileWriter) WriteVersionFileReturnsOnCall(i int, result1 error)
 func (fake *FakeFileWriter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.writeBundleManifestFileMutex.RLock()
+	defer fake.writeBundleManifestFileMutex.RUnlock()
+	fake.writeDependencyLockFileMutex.RLock()
+	defer fake.writeDependencyLockFileMutex.RUnlock()
 	fake.writeMetadataJSONFileMutex.RLock()
 	defer fake.writeMetadataJSONFileMutex.RUnlock()
 	fake.writeMetadataYAMLFileMutex.RLock()
 	defer fake.writeMetadataYAMLFileMutex.RUnlock()
+	fake.writeOmFilesMutex.RLock()
+	defer fake.writeOmFilesMutex.RUnlock()
+	fake.writeUpgradeGraphFilesMutex.RLock()
+	defer fake.writeUpgradeGraphFilesMutex.RUnlock()
 	fake.writeVersionFileMutex.RLock()
 	defer fake.writeVersionFileMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
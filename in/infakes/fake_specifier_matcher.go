@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package infakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type FakeSpecifierMatcher struct {
+	ReleasesMatchingStub        func([]pivnet.Release, string) ([]pivnet.Release, error)
+	releasesMatchingMutex       sync.RWMutex
+	releasesMatchingArgsForCall []struct {
+		arg1 []pivnet.Release
+		arg2 string
+	}
+	releasesMatchingReturns struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	releasesMatchingReturnsOnCall map[int]struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatching(arg1 []pivnet.Release, arg2 string) ([]pivnet.Release, error) {
+	var arg1Copy []pivnet.Release
+	if arg1 != nil {
+		arg1Copy = make([]pivnet.Release, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.releasesMatchingMutex.Lock()
+	ret, specificReturn := fake.releasesMatchingReturnsOnCall[len(fake.releasesMatchingArgsForCall)]
+	fake.releasesMatchingArgsForCall = append(fake.releasesMatchingArgsForCall, struct {
+		arg1 []pivnet.Release
+		arg2 string
+	}{arg1Copy, arg2})
+	stub := fake.ReleasesMatchingStub
+	fakeReturns := fake.releasesMatchingReturns
+	fake.recordInvocation("ReleasesMatching", []interface{}{arg1Copy, arg2})
+	fake.releasesMatchingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatchingCallCount() int {
+	fake.releasesMatchingMutex.RLock()
+	defer fake.releasesMatchingMutex.RUnlock()
+	return len(fake.releasesMatchingArgsForCall)
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatchingCalls(stub func([]pivnet.Release, string) ([]pivnet.Release, error)) {
+	fake.releasesMatchingMutex.Lock()
+	defer fake.releasesMatchingMutex.Unlock()
+	fake.ReleasesMatchingStub = stub
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatchingArgsForCall(i int) ([]pivnet.Release, string) {
+	fake.releasesMatchingMutex.RLock()
+	defer fake.releasesMatchingMutex.RUnlock()
+	argsForCall := fake.releasesMatchingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatchingReturns(result1 []pivnet.Release, result2 error) {
+	fake.releasesMatchingMutex.Lock()
+	defer fake.releasesMatchingMutex.Unlock()
+	fake.ReleasesMatchingStub = nil
+	fake.releasesMatchingReturns = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpecifierMatcher) ReleasesMatchingReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.releasesMatchingMutex.Lock()
+	defer fake.releasesMatchingMutex.Unlock()
+	fake.ReleasesMatchingStub = nil
+	if fake.releasesMatchingReturnsOnCall == nil {
+		fake.releasesMatchingReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.Release
+			result2 error
+		})
+	}
+	fake.releasesMatchingReturnsOnCall[i] = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSpecifierMatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.releasesMatchingMutex.RLock()
+	defer fake.releasesMatchingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSpecifierMatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package infakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+)
+
+type FakeTileInspector struct {
+	InspectStub        func(string) (tile.Tile, error)
+	inspectMutex       sync.RWMutex
+	inspectArgsForCall []struct {
+		arg1 string
+	}
+	inspectReturns struct {
+		result1 tile.Tile
+		result2 error
+	}
+	inspectReturnsOnCall map[int]struct {
+		result1 tile.Tile
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTileInspector) Inspect(arg1 string) (tile.Tile, error) {
+	fake.inspectMutex.Lock()
+	ret, specificReturn := fake.inspectReturnsOnCall[len(fake.inspectArgsForCall)]
+	fake.inspectArgsForCall = append(fake.inspectArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.InspectStub
+	fakeReturns := fake.inspectReturns
+	fake.recordInvocation("Inspect", []interface{}{arg1})
+	fake.inspectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTileInspector) InspectCallCount() int {
+	fake.inspectMutex.RLock()
+	defer fake.inspectMutex.RUnlock()
+	return len(fake.inspectArgsForCall)
+}
+
+func (fake *FakeTileInspector) InspectCalls(stub func(string) (tile.Tile, error)) {
+	fake.inspectMutex.Lock()
+	defer fake.inspectMutex.Unlock()
+	fake.InspectStub = stub
+}
+
+func (fake *FakeTileInspector) InspectArgsForCall(i int) string {
+	fake.inspectMutex.RLock()
+	defer fake.inspectMutex.RUnlock()
+	argsForCall := fake.inspectArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTileInspector) InspectReturns(result1 tile.Tile, result2 error) {
+	fake.inspectMutex.Lock()
+	defer fake.inspectMutex.Unlock()
+	fake.InspectStub = nil
+	fake.inspectReturns = struct {
+		result1 tile.Tile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTileInspector) InspectReturnsOnCall(i int, result1 tile.Tile, result2 error) {
+	fake.inspectMutex.Lock()
+	defer fake.inspectMutex.Unlock()
+	fake.InspectStub = nil
+	if fake.inspectReturnsOnCall == nil {
+		fake.inspectReturnsOnCall = make(map[int]struct {
+			result1 tile.Tile
+			result2 error
+		})
+	}
+	fake.inspectReturnsOnCall[i] = struct {
+		result1 tile.Tile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTileInspector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.inspectMutex.RLock()
+	defer fake.inspectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTileInspector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package infakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
+)
+
+type FakeUpgradeGraphBuilder struct {
+	BuildStub        func(string) (upgradegraph.Graph, error)
+	buildMutex       sync.RWMutex
+	buildArgsForCall []struct {
+		arg1 string
+	}
+	buildReturns struct {
+		result1 upgradegraph.Graph
+		result2 error
+	}
+	buildReturnsOnCall map[int]struct {
+		result1 upgradegraph.Graph
+		result2 error
+	}
+	ShortestPathStub        func(upgradegraph.Graph, string, string) ([]string, error)
+	shortestPathMutex       sync.RWMutex
+	shortestPathArgsForCall []struct {
+		arg1 upgradegraph.Graph
+		arg2 string
+		arg3 string
+	}
+	shortestPathReturns struct {
+		result1 []string
+		result2 error
+	}
+	shortestPathReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUpgradeGraphBuilder) Build(arg1 string) (upgradegraph.Graph, error) {
+	fake.buildMutex.Lock()
+	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
+	fake.buildArgsForCall = append(fake.buildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BuildStub
+	fakeReturns := fake.buildReturns
+	fake.recordInvocation("Build", []interface{}{arg1})
+	fake.buildMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUpgradeGraphBuilder) BuildCallCount() int {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	return len(fake.buildArgsForCall)
+}
+
+func (fake *FakeUpgradeGraphBuilder) BuildCalls(stub func(string) (upgradegraph.Graph, error)) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = stub
+}
+
+func (fake *FakeUpgradeGraphBuilder) BuildArgsForCall(i int) string {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	argsForCall := fake.buildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeUpgradeGraphBuilder) BuildReturns(result1 upgradegraph.Graph, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	fake.buildReturns = struct {
+		result1 upgradegraph.Graph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpgradeGraphBuilder) BuildReturnsOnCall(i int, result1 upgradegraph.Graph, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	if fake.buildReturnsOnCall == nil {
+		fake.buildReturnsOnCall = make(map[int]struct {
+			result1 upgradegraph.Graph
+			result2 error
+		})
+	}
+	fake.buildReturnsOnCall[i] = struct {
+		result1 upgradegraph.Graph
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPath(arg1 upgradegraph.Graph, arg2 string, arg3 string) ([]string, error) {
+	fake.shortestPathMutex.Lock()
+	ret, specificReturn := fake.shortestPathReturnsOnCall[len(fake.shortestPathArgsForCall)]
+	fake.shortestPathArgsForCall = append(fake.shortestPathArgsForCall, struct {
+		arg1 upgradegraph.Graph
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ShortestPathStub
+	fakeReturns := fake.shortestPathReturns
+	fake.recordInvocation("ShortestPath", []interface{}{arg1, arg2, arg3})
+	fake.shortestPathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPathCallCount() int {
+	fake.shortestPathMutex.RLock()
+	defer fake.shortestPathMutex.RUnlock()
+	return len(fake.shortestPathArgsForCall)
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPathCalls(stub func(upgradegraph.Graph, string, string) ([]string, error)) {
+	fake.shortestPathMutex.Lock()
+	defer fake.shortestPathMutex.Unlock()
+	fake.ShortestPathStub = stub
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPathArgsForCall(i int) (upgradegraph.Graph, string, string) {
+	fake.shortestPathMutex.RLock()
+	defer fake.shortestPathMutex.RUnlock()
+	argsForCall := fake.shortestPathArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPathReturns(result1 []string, result2 error) {
+	fake.shortestPathMutex.Lock()
+	defer fake.shortestPathMutex.Unlock()
+	fake.ShortestPathStub = nil
+	fake.shortestPathReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpgradeGraphBuilder) ShortestPathReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.shortestPathMutex.Lock()
+	defer fake.shortestPathMutex.Unlock()
+	fake.ShortestPathStub = nil
+	if fake.shortestPathReturnsOnCall == nil {
+		fake.shortestPathReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.shortestPathReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpgradeGraphBuilder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	fake.shortestPathMutex.RLock()
+	defer fake.shortestPathMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeUpgradeGraphBuilder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
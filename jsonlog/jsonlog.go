@@ -0,0 +1,146 @@
+package jsonlog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
+)
+
+// CorrelationIDHeader is the header that carries the correlation ID of an
+// invocation in every request to Pivnet.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// Fields are added to every line logged.
+type Fields struct {
+	Command       string
+	ProductSlug   string
+	CorrelationID string
+	Build         useragent.Build
+}
+
+// Logger writes one JSON object per line. It implements logger.Logger, and
+// io.Writer so that a log.Logger can write through it.
+type Logger struct {
+	writer  io.Writer
+	verbose bool
+	fields  Fields
+	now     func() time.Time
+
+	mu        sync.Mutex
+	releaseID int
+}
+
+type line struct {
+	Time          string      `json:"time"`
+	Level         string      `json:"level"`
+	Message       string      `json:"message"`
+	Data          logger.Data `json:"data,omitempty"`
+	Command       string      `json:"command"`
+	ProductSlug   string      `json:"product_slug"`
+	ReleaseID     int         `json:"release_id,omitempty"`
+	Pipeline      string      `json:"pipeline,omitempty"`
+	Job           string      `json:"job,omitempty"`
+	Build         string      `json:"build,omitempty"`
+	Resource      string      `json:"resource,omitempty"`
+	CorrelationID string      `json:"correlation_id"`
+}
+
+func NewLogger(writer io.Writer, verbose bool, fields Fields, now func() time.Time) *Logger {
+	return &Logger{
+		writer:  writer,
+		verbose: verbose,
+		fields:  fields,
+		now:     now,
+	}
+}
+
+func (l *Logger) Debug(action string, data ...logger.Data) {
+	if l.verbose {
+		l.write("debug", action, data...)
+	}
+}
+
+func (l *Logger) Info(action string, data ...logger.Data) {
+	l.write("info", action, data...)
+}
+
+// Write logs p as an info line, without a trailing newline.
+func (l *Logger) Write(p []byte) (int, error) {
+	l.write("info", string(bytes.TrimRight(p, "\n")))
+	return len(p), nil
+}
+
+// SetReleaseID adds the ID of the release being worked on to every line
+// logged from now on.
+func (l *Logger) SetReleaseID(releaseID int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.releaseID = releaseID
+}
+
+func (l *Logger) write(level string, message string, data ...logger.Data) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var merged logger.Data
+	for _, d := range data {
+		for k, v := range d {
+			if merged == nil {
+				merged = logger.Data{}
+			}
+			merged[k] = v
+		}
+	}
+
+	// URLs are logged as they are, rather than with '&' escaped
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(line{
+		Time:          l.now().UTC().Format(time.RFC3339Nano),
+		Level:         level,
+		Message:       message,
+		Data:          merged,
+		Command:       l.fields.Command,
+		ProductSlug:   l.fields.ProductSlug,
+		ReleaseID:     l.releaseID,
+		Pipeline:      l.fields.Build.Pipeline,
+		Job:           l.fields.Build.Job,
+		Build:         l.fields.Build.Name,
+		Resource:      l.fields.Build.Resource,
+		CorrelationID: l.fields.CorrelationID,
+	})
+	if err != nil {
+		b.Reset()
+		fmt.Fprintf(&b, "{\"level\":\"error\",\"message\":%q}\n", err.Error())
+	}
+
+	l.writer.Write(b.Bytes())
+}
+
+// SetReleaseID adds the release ID to the lines of l if it is a JSON logger.
+func SetReleaseID(l logger.Logger, releaseID int) {
+	if jsonLogger, ok := l.(*Logger); ok {
+		jsonLogger.SetReleaseID(releaseID)
+	}
+}
+
+// NewCorrelationID returns a random ID for an invocation.
+func NewCorrelationID() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
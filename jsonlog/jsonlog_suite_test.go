@@ -0,0 +1,13 @@
+package jsonlog_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestJSONLog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "JSONLog Suite")
+}
@@ -0,0 +1,128 @@
+package jsonlog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/jsonlog"
+	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logger", func() {
+	var (
+		buffer  *bytes.Buffer
+		verbose bool
+		fields  jsonlog.Fields
+
+		l *jsonlog.Logger
+	)
+
+	lines := func() []map[string]interface{} {
+		var decoded []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			var m map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &m)).To(Succeed(), line)
+			decoded = append(decoded, m)
+		}
+		return decoded
+	}
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		verbose = false
+		fields = jsonlog.Fields{
+			Command:       "get",
+			ProductSlug:   "some-product",
+			CorrelationID: "some-correlation-id",
+			Build: useragent.Build{
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Name:     "42",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		now := func() time.Time {
+			return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		}
+		l = jsonlog.NewLogger(buffer, verbose, fields, now)
+	})
+
+	It("writes one JSON object per line with the fields", func() {
+		l.Info("some message", logger.Data{"some": "data"}, logger.Data{"more": 1})
+
+		Expect(lines()).To(Equal([]map[string]interface{}{
+			{
+				"time":           "2020-01-02T03:04:05Z",
+				"level":          "info",
+				"message":        "some message",
+				"data":           map[string]interface{}{"some": "data", "more": float64(1)},
+				"command":        "get",
+				"product_slug":   "some-product",
+				"pipeline":       "some-pipeline",
+				"job":            "some-job",
+				"build":          "42",
+				"correlation_id": "some-correlation-id",
+			},
+		}))
+	})
+
+	It("adds the release ID once it is known", func() {
+		l.Info("before")
+		jsonlog.SetReleaseID(l, 1234)
+		l.Info("after")
+
+		decoded := lines()
+		Expect(decoded[0]).NotTo(HaveKey("release_id"))
+		Expect(decoded[1]).To(HaveKeyWithValue("release_id", float64(1234)))
+	})
+
+	It("ignores the release ID for other loggers", func() {
+		shim := logshim.NewLogShim(log.New(buffer, "", 0), log.New(buffer, "", 0), false)
+		jsonlog.SetReleaseID(shim, 1234)
+	})
+
+	It("writes lines from a log.Logger", func() {
+		log.New(l, "", 0).Printf("Creating download directory: %s", "some-dir")
+
+		decoded := lines()
+		Expect(decoded).To(HaveLen(1))
+		Expect(decoded[0]).To(HaveKeyWithValue("message", "Creating download directory: some-dir"))
+		Expect(decoded[0]).To(HaveKeyWithValue("level", "info"))
+	})
+
+	Describe("Debug", func() {
+		It("does not write when not verbose", func() {
+			l.Debug("some message")
+			Expect(buffer.String()).To(BeEmpty())
+		})
+
+		Context("when verbose", func() {
+			BeforeEach(func() {
+				verbose = true
+			})
+
+			It("writes a debug line", func() {
+				l.Debug("some message")
+				Expect(lines()[0]).To(HaveKeyWithValue("level", "debug"))
+			})
+		})
+	})
+
+	Describe("NewCorrelationID", func() {
+		It("returns a different ID every time", func() {
+			id := jsonlog.NewCorrelationID()
+			Expect(id).To(MatchRegexp("^[0-9a-f]{32}$"))
+			Expect(jsonlog.NewCorrelationID()).NotTo(Equal(id))
+		})
+	})
+})
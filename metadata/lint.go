@@ -0,0 +1,344 @@
+package metadata
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// Schema is the JSON Schema of the metadata provided to put.
+//
+//go:embed schema.json
+var Schema []byte
+
+var metadataSchema = mustParseSchema(Schema)
+
+// Problem is a single problem with a metadata file. Line and Column are zero
+// when the position of the problem is not known.
+type Problem struct {
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d, column %d: %s", p.Line, p.Column, p.Message)
+}
+
+// Problems is every problem found with a metadata file.
+type Problems []Problem
+
+func (p Problems) Error() string {
+	lines := make([]string, len(p))
+	for i, problem := range p {
+		lines[i] = problem.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Decode parses a metadata file, rejecting unknown fields and values of the
+// wrong type. Every such problem is returned, with its line and column.
+func Decode(contents []byte) (Metadata, error) {
+	_, problems, err := checkSchema(contents)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	if len(problems) > 0 {
+		return Metadata{}, problems
+	}
+
+	var m Metadata
+	err = yaml.Unmarshal(contents, &m)
+	if err != nil {
+		return Metadata{}, err
+	}
+
+	return m, nil
+}
+
+// Lint parses and validates a metadata file, returning every problem found
+// by Decode and Validate together, ordered by line and column.
+func Lint(contents []byte) (Metadata, []string, error) {
+	root, problems, err := checkSchema(contents)
+	if err != nil {
+		return Metadata{}, nil, err
+	}
+
+	// Values of the wrong type were reported above, and are left empty so
+	// that the rest of the file can still be validated.
+	var m Metadata
+	err = yaml.Unmarshal(contents, &m)
+	if _, ok := err.(*yaml.TypeError); err != nil && !ok {
+		return Metadata{}, nil, err
+	}
+
+	// Problems found by Validate are about a field of the mapping at the
+	// parent path, as are the missing and unknown fields found above, so they
+	// are reported the same way.
+	for _, p := range m.problems() {
+		if problems.hasPath(p.Path) {
+			continue
+		}
+
+		parent := parentPath(p.Path)
+		if n := locateKey(root, parent); n != nil {
+			p.Line, p.Column = n.Line, n.Column
+		}
+		if parent != "" {
+			p.Message = parent + ": " + p.Message
+		}
+		problems = append(problems, p)
+	}
+
+	if len(problems) > 0 {
+		sort.SliceStable(problems, func(i, j int) bool {
+			if problems[i].Line != problems[j].Line {
+				return problems[i].Line < problems[j].Line
+			}
+			return problems[i].Column < problems[j].Column
+		})
+		return Metadata{}, nil, problems
+	}
+
+	var deprecations []string
+	return m, deprecations, nil
+}
+
+func (p Problems) hasPath(path string) bool {
+	for _, problem := range p {
+		if problem.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// schema is the subset of JSON Schema used by schema.json.
+type schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	MinLength            int                `json:"minLength"`
+}
+
+func mustParseSchema(b []byte) *schema {
+	var s schema
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		panic(fmt.Sprintf("metadata schema is invalid: %s", err))
+	}
+	return &s
+}
+
+func checkSchema(contents []byte) (*yaml3.Node, Problems, error) {
+	var doc yaml3.Node
+	err := yaml3.Unmarshal(contents, &doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	root := doc.Content[0]
+	return root, metadataSchema.check(root, root, ""), nil
+}
+
+// check checks the value n at the path. Problems with the fields of a mapping
+// as a whole, e.g. a missing field, are reported at key, the key of the
+// mapping or the list item.
+func (s *schema) check(n *yaml3.Node, key *yaml3.Node, path string) Problems {
+	if n.Kind == yaml3.AliasNode {
+		n = n.Alias
+	}
+
+	if n.Kind == yaml3.ScalarNode && n.Tag == "!!null" {
+		if s.MinLength > 0 {
+			return Problems{problemAt(n, path, "must not be empty")}
+		}
+		return nil
+	}
+
+	switch s.Type {
+	case "object":
+		return s.checkObject(n, key, path)
+	case "array":
+		if n.Kind != yaml3.SequenceNode {
+			return Problems{problemAt(n, path, "must be a list")}
+		}
+
+		var problems Problems
+		if s.Items != nil {
+			for i, item := range n.Content {
+				problems = append(problems, s.Items.check(item, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+		return problems
+	case "string":
+		if n.Kind != yaml3.ScalarNode {
+			return Problems{problemAt(n, path, "must be a string")}
+		}
+		if len(n.Value) < s.MinLength {
+			return Problems{problemAt(n, path, "must not be empty")}
+		}
+	case "integer":
+		if n.Kind != yaml3.ScalarNode || n.Tag != "!!int" {
+			return Problems{problemAt(n, path, "must be an integer")}
+		}
+	case "boolean":
+		if n.Kind != yaml3.ScalarNode || !isBool(n) {
+			return Problems{problemAt(n, path, "must be a boolean")}
+		}
+	}
+
+	return nil
+}
+
+func (s *schema) checkObject(n *yaml3.Node, key *yaml3.Node, path string) Problems {
+	if n.Kind != yaml3.MappingNode {
+		return Problems{problemAt(n, path, "must be a mapping")}
+	}
+
+	var problems Problems
+	present := map[string]bool{}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		fieldKey, value := n.Content[i], n.Content[i+1]
+		present[fieldKey.Value] = true
+
+		property, ok := s.Properties[fieldKey.Value]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				p := problemAt(fieldKey, path, fmt.Sprintf("unknown field %q", fieldKey.Value))
+				p.Path = childPath(path, fieldKey.Value)
+				problems = append(problems, p)
+			}
+			continue
+		}
+
+		problems = append(problems, property.check(value, fieldKey, childPath(path, fieldKey.Value))...)
+	}
+
+	for _, name := range s.Required {
+		if !present[name] {
+			p := problemAt(key, path, fmt.Sprintf("missing required field %q", name))
+			p.Path = childPath(path, name)
+			problems = append(problems, p)
+		}
+	}
+
+	return problems
+}
+
+// isBool accepts the YAML 1.1 booleans, e.g. 'yes', which are still decoded
+// as booleans.
+func isBool(n *yaml3.Node) bool {
+	if n.Tag == "!!bool" {
+		return true
+	}
+
+	switch strings.ToLower(n.Value) {
+	case "y", "yes", "n", "no", "on", "off":
+		return n.Style == 0
+	}
+	return false
+}
+
+// locateKey returns the key of the field at the path, or the item for a path
+// ending in an index. If the path is not present, the key of the closest
+// enclosing field is returned.
+func locateKey(root *yaml3.Node, path string) *yaml3.Node {
+	key := root
+	if root == nil || path == "" {
+		return key
+	}
+
+	n := root
+	for _, segment := range strings.Split(path, ".") {
+		name := segment
+		var indexes []int
+		if i := strings.Index(segment, "["); i >= 0 {
+			name = segment[:i]
+			for _, index := range strings.Split(strings.Trim(segment[i:], "[]"), "][") {
+				j, _ := strconv.Atoi(index)
+				indexes = append(indexes, j)
+			}
+		}
+
+		fieldKey, value := mappingField(n, name)
+		if fieldKey == nil {
+			return key
+		}
+		key, n = fieldKey, value
+
+		for _, j := range indexes {
+			if n.Kind != yaml3.SequenceNode || j >= len(n.Content) {
+				return key
+			}
+			key, n = n.Content[j], n.Content[j]
+		}
+	}
+
+	return key
+}
+
+func mappingField(n *yaml3.Node, key string) (*yaml3.Node, *yaml3.Node) {
+	if n.Kind == yaml3.AliasNode {
+		n = n.Alias
+	}
+
+	if n.Kind != yaml3.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func problemAt(n *yaml3.Node, path string, message string) Problem {
+	if path != "" {
+		message = path + ": " + message
+	}
+
+	return Problem{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: message,
+	}
+}
+
+// parentPath returns the path of the field or list containing the path, e.g.
+// 'dependency_specifiers[0]' for 'dependency_specifiers[0].product_slug'.
+func parentPath(path string) string {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+func childPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,174 @@
+package metadata_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lint", func() {
+	var contents string
+
+	BeforeEach(func() {
+		contents = `---
+release:
+  version: "1.0.0"
+  release_type: All-In-One
+  eula_slug: some-eula
+  release_date: 1997-12-31
+  controlled: false
+  user_group_ids:
+  - 8
+product_files:
+- file: some/file
+  id: 1234
+`
+	})
+
+	Describe("Decode", func() {
+		It("decodes valid metadata", func() {
+			m, err := metadata.Decode([]byte(contents))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.Release.Version).To(Equal("1.0.0"))
+			Expect(m.Release.ReleaseDate).To(Equal("1997-12-31"))
+			Expect(m.Release.UserGroupIDs).To(Equal([]string{"8"}))
+			Expect(m.ProductFiles).To(Equal([]metadata.ProductFile{{File: "some/file", ID: 1234}}))
+		})
+
+		It("decodes metadata in JSON format", func() {
+			m, err := metadata.Decode([]byte(`{"release": {"version": "1.0.0", "controlled": true}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.Release.Version).To(Equal("1.0.0"))
+			Expect(m.Release.Controlled).To(BeTrue())
+		})
+
+		Context("when there are unknown fields and values of the wrong type", func() {
+			BeforeEach(func() {
+				contents = `---
+release:
+  version: "1.0.0"
+  relase_notes_url: http://example.com
+  controlled: maybe
+product_files:
+- file: ""
+  id: abc
+bogus: true
+`
+			})
+
+			It("returns every problem with its line and column", func() {
+				_, err := metadata.Decode([]byte(contents))
+				Expect(err).To(HaveOccurred())
+
+				Expect(err.Error()).To(Equal(strings.Join([]string{
+					`line 4, column 3: release: unknown field "relase_notes_url"`,
+					`line 5, column 15: release.controlled: must be a boolean`,
+					`line 7, column 9: product_files[0].file: must not be empty`,
+					`line 8, column 7: product_files[0].id: must be an integer`,
+					`line 9, column 1: unknown field "bogus"`,
+				}, "\n")))
+
+				problems, ok := err.(metadata.Problems)
+				Expect(ok).To(BeTrue())
+				Expect(problems).To(HaveLen(5))
+			})
+		})
+
+		Context("when the file is not valid YAML", func() {
+			It("returns an error", func() {
+				_, err := metadata.Decode([]byte("release: [\n"))
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("line"))
+			})
+		})
+	})
+
+	It("returns no problems for valid metadata", func() {
+		m, deprecations, err := metadata.Lint([]byte(contents))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deprecations).To(BeEmpty())
+		Expect(m.Release.Version).To(Equal("1.0.0"))
+	})
+
+	Context("when there are problems with the structure and values", func() {
+		BeforeEach(func() {
+			contents = `---
+release:
+  version: "1.0.0"
+  relase_notes_url: http://example.com
+product_files:
+- description: no file
+dependency_specifiers:
+- product_slug: some-product
+  specifier: ""
+- specifier: 1.2.*
+`
+		})
+
+		It("returns every problem ordered by line and column", func() {
+			_, _, err := metadata.Lint([]byte(contents))
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(Equal(strings.Join([]string{
+				`line 2, column 1: release: missing required value "release_type"`,
+				`line 2, column 1: release: missing required value "eula_slug"`,
+				`line 4, column 3: release: unknown field "relase_notes_url"`,
+				`line 6, column 3: product_files[0]: missing required field "file"`,
+				`line 9, column 14: dependency_specifiers[0].specifier: must not be empty`,
+				`line 10, column 3: dependency_specifiers[1]: missing required field "product_slug"`,
+			}, "\n")))
+		})
+	})
+
+	Context("when the release is missing", func() {
+		It("returns the problem", func() {
+			_, _, err := metadata.Lint([]byte("product_files: []\n"))
+			Expect(err).To(MatchError(`line 1, column 1: missing required value "release"`))
+		})
+	})
+
+	Describe("Schema", func() {
+		type jsonSchema struct {
+			Properties map[string]*jsonSchema `json:"properties"`
+			Items      *jsonSchema            `json:"items"`
+		}
+
+		var checkFields func(t reflect.Type, s *jsonSchema, path string)
+		checkFields = func(t reflect.Type, s *jsonSchema, path string) {
+			switch t.Kind() {
+			case reflect.Ptr:
+				checkFields(t.Elem(), s, path)
+			case reflect.Slice:
+				if s.Items != nil {
+					checkFields(t.Elem(), s.Items, path+"[]")
+				}
+			case reflect.Struct:
+				Expect(s.Properties).To(HaveLen(t.NumField()), path)
+
+				for i := 0; i < t.NumField(); i++ {
+					name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+
+					property, ok := s.Properties[name]
+					Expect(ok).To(BeTrue(), "%s.%s is not in the schema", path, name)
+
+					checkFields(t.Field(i).Type, property, path+"."+name)
+				}
+			}
+		}
+
+		It("describes every field of the metadata", func() {
+			var s jsonSchema
+			err := json.Unmarshal(metadata.Schema, &s)
+			Expect(err).NotTo(HaveOccurred())
+
+			checkFields(reflect.TypeOf(metadata.Metadata{}), &s, "")
+		})
+	})
+})
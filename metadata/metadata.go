@@ -12,6 +12,7 @@ type Metadata struct {
 	UpgradePathSpecifiers []UpgradePathSpecifier `yaml:"upgrade_path_specifiers,omitempty"`
 	FileGroups            []FileGroup            `yaml:"file_groups,omitempty"`
 	ArtifactReferences    []ArtifactReference    `yaml:"artifact_references,omitempty"`
+	Tiles                 []Tile                 `yaml:"tiles,omitempty"`
 
 	// Deprecated
 	Dependencies []Dependency  `yaml:"dependencies,omitempty"`
@@ -28,6 +29,7 @@ type Release struct {
 	ReleaseNotesURL       string               `yaml:"release_notes_url"`
 	Availability          string               `yaml:"availability"`
 	UserGroupIDs          []string             `yaml:"user_group_ids,omitempty"`
+	UserGroups            []string             `yaml:"user_groups,omitempty"`
 	Controlled            bool                 `yaml:"controlled"`
 	ECCN                  string               `yaml:"eccn"`
 	LicenseException      string               `yaml:"license_exception"`
@@ -38,7 +40,8 @@ type Release struct {
 }
 
 type ExistingRelease struct {
-	ID int `yaml:"id,omitempty"`
+	ID                      int  `yaml:"id,omitempty"`
+	PruneArtifactReferences bool `yaml:"prune_artifact_references,omitempty"`
 }
 
 type ReleaseProductFile struct {
@@ -79,7 +82,8 @@ type ArtifactReference struct {
 }
 
 type FileGroupProductFile struct {
-	ID int `yaml:"id,omitempty"`
+	ID   int    `yaml:"id,omitempty"`
+	Name string `yaml:"name,omitempty"`
 }
 
 type Dependency struct {
@@ -114,74 +118,167 @@ type UpgradePathSpecifier struct {
 	Specifier string `yaml:"specifier,omitempty"`
 }
 
+// Tile describes a downloaded .pivotal file, as read from the metadata inside
+// it. It is only written by get.
+type Tile struct {
+	File               string               `yaml:"file"`
+	Name               string               `yaml:"name"`
+	TileProductVersion string               `yaml:"tile_product_version"`
+	StemcellCriteria   TileStemcellCriteria `yaml:"stemcell_criteria"`
+	Releases           []TileRelease        `yaml:"releases,omitempty"`
+}
+
+type TileStemcellCriteria struct {
+	OS          string `yaml:"os"`
+	Version     string `yaml:"version"`
+	RequiresCPI bool   `yaml:"requires_cpi"`
+}
+
+type TileRelease struct {
+	Name    string `yaml:"name"`
+	File    string `yaml:"file"`
+	Version string `yaml:"version"`
+}
+
+// DependencyLock records the releases and files that dependency specifiers
+// were resolved to during a get.
+type DependencyLock struct {
+	Dependencies []LockedDependency `yaml:"dependencies"`
+}
+
+type LockedDependency struct {
+	ProductSlug string       `yaml:"product_slug"`
+	Specifier   string       `yaml:"specifier"`
+	ReleaseID   int          `yaml:"release_id"`
+	Version     string       `yaml:"version"`
+	Directory   string       `yaml:"directory"`
+	Files       []LockedFile `yaml:"files,omitempty"`
+}
+
+// BundleManifest records the products downloaded by a bundle get, including
+// the product of the resource itself.
+type BundleManifest struct {
+	Products []BundledProduct `yaml:"products"`
+}
+
+type BundledProduct struct {
+	ProductSlug string       `yaml:"product_slug"`
+	Version     string       `yaml:"version"`
+	Directory   string       `yaml:"directory"`
+	Metadata    Metadata     `yaml:"metadata"`
+	Files       []LockedFile `yaml:"files,omitempty"`
+}
+
+// OmDownloadFile describes the downloaded product and stemcell in the form
+// Ops Manager automation expects from 'om download-product'. Paths are
+// relative to the directory the resource was fetched into.
+type OmDownloadFile struct {
+	ProductPath     string `json:"product_path"`
+	ProductSlug     string `json:"product_slug"`
+	ProductVersion  string `json:"product_version"`
+	ProductSHA256   string `json:"product_sha256,omitempty"`
+	StemcellPath    string `json:"stemcell_path,omitempty"`
+	StemcellVersion string `json:"stemcell_version,omitempty"`
+	StemcellSHA256  string `json:"stemcell_sha256,omitempty"`
+}
+
+// OmStemcellAssignment is the config for 'om assign-stemcell'.
+type OmStemcellAssignment struct {
+	Product  string `yaml:"product"`
+	Stemcell string `yaml:"stemcell"`
+}
+
+type LockedFile struct {
+	ID     int    `yaml:"id"`
+	Name   string `yaml:"name"`
+	Path   string `yaml:"path"`
+	SHA256 string `yaml:"sha256,omitempty"`
+	MD5    string `yaml:"md5,omitempty"`
+}
+
+// Validate returns every problem with the metadata, as Problems.
 func (m Metadata) Validate() ([]string, error) {
-	for _, productFile := range m.ProductFiles {
+	problems := m.problems()
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	var deprecations []string
+	return deprecations, nil
+}
+
+// problems returns every problem with the metadata. Their paths are used by
+// Lint to find their line and column.
+func (m Metadata) problems() Problems {
+	var problems Problems
+	add := func(path string, message string) {
+		problems = append(problems, Problem{Path: path, Message: message})
+	}
+
+	for i, productFile := range m.ProductFiles {
 		if productFile.File == "" {
-			return nil, fmt.Errorf("empty value for file")
+			add(fmt.Sprintf("product_files[%d].file", i), "empty value for file")
 		}
 	}
 
 	if m.Release == nil && m.ExistingRelease == nil {
-		return nil, fmt.Errorf("missing required value %q", "release")
-	}
-
-	if m.ExistingRelease == nil {
+		add("release", fmt.Sprintf("missing required value %q", "release"))
+	} else if m.ExistingRelease == nil {
 		if m.Release.Version == "" {
-			return nil, fmt.Errorf("missing required value %q", "version")
+			add("release.version", fmt.Sprintf("missing required value %q", "version"))
 		}
 
 		if m.Release.ReleaseType == "" {
-			return nil, fmt.Errorf("missing required value %q", "release_type")
+			add("release.release_type", fmt.Sprintf("missing required value %q", "release_type"))
 		}
 
 		if m.Release.EULASlug == "" {
-			return nil, fmt.Errorf("missing required value %q", "eula_slug")
+			add("release.eula_slug", fmt.Sprintf("missing required value %q", "eula_slug"))
 		}
 	} else {
-		if len(m.ProductFiles) == 0 {
-			return nil, fmt.Errorf(
-				"adding files to an %q must include at least one product file",
+		if len(m.ProductFiles) == 0 && len(m.ArtifactReferences) == 0 && !m.ExistingRelease.PruneArtifactReferences {
+			add("existing_release", fmt.Sprintf(
+				"adding files to an %q must include at least one product file or artifact reference",
 				"existing release",
-			)
+			))
 		}
 	}
 
 	for i, d := range m.DependencySpecifiers {
 		if d.ProductSlug == "" {
-			return nil, fmt.Errorf(
+			add(fmt.Sprintf("dependency_specifiers[%d].product_slug", i), fmt.Sprintf(
 				"Dependent product slug must be provided for dependency_specifiers[%d]",
 				i,
-			)
+			))
 		}
 		if d.Specifier == "" {
-			return nil, fmt.Errorf(
+			add(fmt.Sprintf("dependency_specifiers[%d].specifier", i), fmt.Sprintf(
 				"Specifier must be provided for dependency_specifiers[%d]",
 				i,
-			)
+			))
 		}
 	}
 
 	for i, d := range m.UpgradePathSpecifiers {
 		if d.Specifier == "" {
-			return nil, fmt.Errorf(
+			add(fmt.Sprintf("upgrade_path_specifiers[%d].specifier", i), fmt.Sprintf(
 				"Specifier must be provided for upgrade_path_specifiers[%d]",
 				i,
-			)
+			))
 		}
 	}
 
 	if len(m.Dependencies) > 0 {
-		return nil, fmt.Errorf(
+		add("dependencies",
 			"'dependencies' is deprecated. Please use 'dependency_specifiers' to add all dependency metadata.",
 		)
 	}
 
 	if len(m.UpgradePaths) > 0 {
-		return nil, fmt.Errorf(
+		add("upgrade_paths",
 			"'upgrade_paths' is deprecated. Please use 'upgrade_path_specifiers' to add all upgrade path metadata.",
 		)
 	}
 
-	var deprecations []string
-	return deprecations, nil
+	return problems
 }
@@ -220,6 +220,19 @@ var _ = Describe("Metadata", func() {
 
 					Expect(err.Error()).To(MatchRegexp("must include at least one product file"))
 				})
+
+				Context("when artifact references are provided", func() {
+					BeforeEach(func() {
+						data.ArtifactReferences = []metadata.ArtifactReference{
+							{ID: 456},
+						}
+					})
+
+					It("returns without error", func() {
+						_, err := data.Validate()
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
 			})
 		})
 	})
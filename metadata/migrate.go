@@ -0,0 +1,222 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Migrate returns the metadata in the current format, with the deprecated
+// 'dependencies' turned into dependency_specifiers pinned to the version of
+// each dependency, and the deprecated 'upgrade_paths' turned into
+// upgrade_path_specifiers. Specifiers that are already present are not
+// duplicated.
+//
+// If dropReadOnly is true, the fields written by get that put does not use,
+// or that refer to the release the metadata was read from, are dropped: IDs,
+// hashes, AWS object keys and tiles. The product files of file groups are
+// then referred to by the file of the matching product_files entry.
+//
+// Every change made is described by the returned notes. An error is returned
+// if a deprecated entry does not have the slug or version needed to migrate
+// it.
+func (m Metadata) Migrate(dropReadOnly bool) (Metadata, []string, error) {
+	var notes []string
+	var problems []string
+
+	migrated := m
+	migrated.Dependencies = nil
+	migrated.UpgradePaths = nil
+	migrated.DependencySpecifiers = append([]DependencySpecifier(nil), m.DependencySpecifiers...)
+	migrated.UpgradePathSpecifiers = append([]UpgradePathSpecifier(nil), m.UpgradePathSpecifiers...)
+
+	for i, d := range m.Dependencies {
+		if d.Release.Product.Slug == "" || d.Release.Version == "" {
+			problems = append(problems, fmt.Sprintf(
+				"dependencies[%d]: product slug and version must be provided to migrate it",
+				i,
+			))
+			continue
+		}
+
+		specifier := DependencySpecifier{
+			ProductSlug: d.Release.Product.Slug,
+			Specifier:   d.Release.Version,
+		}
+
+		if hasDependencySpecifier(migrated.DependencySpecifiers, specifier) {
+			notes = append(notes, fmt.Sprintf(
+				"dropped dependencies[%d]: dependency_specifiers already has '%s' on '%s'",
+				i,
+				specifier.Specifier,
+				specifier.ProductSlug,
+			))
+			continue
+		}
+
+		migrated.DependencySpecifiers = append(migrated.DependencySpecifiers, specifier)
+		notes = append(notes, fmt.Sprintf(
+			"migrated dependencies[%d] to dependency_specifiers: '%s' on '%s'",
+			i,
+			specifier.Specifier,
+			specifier.ProductSlug,
+		))
+	}
+
+	for i, u := range m.UpgradePaths {
+		if u.Version == "" {
+			problems = append(problems, fmt.Sprintf(
+				"upgrade_paths[%d]: version must be provided to migrate it",
+				i,
+			))
+			continue
+		}
+
+		specifier := UpgradePathSpecifier{Specifier: u.Version}
+
+		if hasUpgradePathSpecifier(migrated.UpgradePathSpecifiers, specifier) {
+			notes = append(notes, fmt.Sprintf(
+				"dropped upgrade_paths[%d]: upgrade_path_specifiers already has '%s'",
+				i,
+				specifier.Specifier,
+			))
+			continue
+		}
+
+		migrated.UpgradePathSpecifiers = append(migrated.UpgradePathSpecifiers, specifier)
+		notes = append(notes, fmt.Sprintf(
+			"migrated upgrade_paths[%d] to upgrade_path_specifiers: '%s'",
+			i,
+			specifier.Specifier,
+		))
+	}
+
+	if dropReadOnly {
+		var readOnlyProblems []string
+		migrated, readOnlyProblems = migrated.withoutReadOnly()
+		problems = append(problems, readOnlyProblems...)
+		notes = append(notes, "dropped IDs, hashes, AWS object keys and tiles, and referred to the product files of file groups by their file")
+	}
+
+	if len(problems) > 0 {
+		return Metadata{}, nil, fmt.Errorf(
+			"metadata could not be migrated:\n  %s",
+			strings.Join(problems, "\n  "),
+		)
+	}
+
+	return migrated, notes, nil
+}
+
+// withoutReadOnly returns the metadata without the fields that put does not
+// use. Product files of file groups that are referred to by ID are referred to
+// by the file of the product_files entry with that ID instead; a problem is
+// returned for each that has no such entry.
+func (m Metadata) withoutReadOnly() (Metadata, []string) {
+	var problems []string
+
+	productFileNames := map[int]string{}
+	for _, pf := range m.ProductFiles {
+		name := pf.File
+		if name == "" {
+			name = pf.UploadAs
+		}
+		if pf.ID != 0 && name != "" {
+			productFileNames[pf.ID] = name
+		}
+	}
+
+	if m.Release != nil {
+		release := *m.Release
+		release.ID = 0
+		release.ProductFiles = nil
+		m.Release = &release
+	}
+
+	m.Tiles = nil
+
+	var productFiles []ProductFile
+	for _, pf := range m.ProductFiles {
+		pf.ID = 0
+		pf.AWSObjectKey = ""
+		pf.SHA256 = ""
+		pf.MD5 = ""
+		productFiles = append(productFiles, pf)
+	}
+	m.ProductFiles = productFiles
+
+	var fileGroups []FileGroup
+	for i, fg := range m.FileGroups {
+		var fileGroupProductFiles []FileGroupProductFile
+		seen := map[string]bool{}
+		for j, pf := range fg.ProductFiles {
+			name := pf.Name
+			if name == "" {
+				name = productFileNames[pf.ID]
+			}
+
+			if name == "" {
+				problems = append(problems, fmt.Sprintf(
+					"file_groups[%d].product_files[%d]: product file %d is not in product_files, so it cannot be referred to by name",
+					i,
+					j,
+					pf.ID,
+				))
+				continue
+			}
+
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			fileGroupProductFiles = append(fileGroupProductFiles, FileGroupProductFile{Name: name})
+		}
+
+		fileGroups = append(fileGroups, FileGroup{
+			Name:         fg.Name,
+			ProductFiles: fileGroupProductFiles,
+		})
+	}
+	m.FileGroups = fileGroups
+
+	var artifactReferences []ArtifactReference
+	for _, ar := range m.ArtifactReferences {
+		ar.ID = 0
+		artifactReferences = append(artifactReferences, ar)
+	}
+	m.ArtifactReferences = artifactReferences
+
+	var dependencySpecifiers []DependencySpecifier
+	for _, d := range m.DependencySpecifiers {
+		d.ID = 0
+		dependencySpecifiers = append(dependencySpecifiers, d)
+	}
+	m.DependencySpecifiers = dependencySpecifiers
+
+	var upgradePathSpecifiers []UpgradePathSpecifier
+	for _, u := range m.UpgradePathSpecifiers {
+		u.ID = 0
+		upgradePathSpecifiers = append(upgradePathSpecifiers, u)
+	}
+	m.UpgradePathSpecifiers = upgradePathSpecifiers
+
+	return m, problems
+}
+
+func hasDependencySpecifier(specifiers []DependencySpecifier, specifier DependencySpecifier) bool {
+	for _, s := range specifiers {
+		if s.ProductSlug == specifier.ProductSlug && s.Specifier == specifier.Specifier {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUpgradePathSpecifier(specifiers []UpgradePathSpecifier, specifier UpgradePathSpecifier) bool {
+	for _, s := range specifiers {
+		if s.Specifier == specifier.Specifier {
+			return true
+		}
+	}
+	return false
+}
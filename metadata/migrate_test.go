@@ -0,0 +1,211 @@
+package metadata_test
+
+import (
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrate", func() {
+	var (
+		data         metadata.Metadata
+		dropReadOnly bool
+	)
+
+	BeforeEach(func() {
+		dropReadOnly = false
+
+		data = metadata.Metadata{
+			Release: &metadata.Release{
+				ID:           1234,
+				Version:      "2.0.0",
+				ReleaseType:  "Major Release",
+				EULASlug:     "some-eula",
+				ProductFiles: []metadata.ReleaseProductFile{{ID: 5678}},
+			},
+			ProductFiles: []metadata.ProductFile{
+				{
+					ID:           5678,
+					File:         "some-file",
+					AWSObjectKey: "some/key",
+					SHA256:       "some-sha256",
+					MD5:          "some-md5",
+					FileType:     "Software",
+				},
+			},
+			FileGroups: []metadata.FileGroup{
+				{
+					ID:   2345,
+					Name: "some file group",
+					ProductFiles: []metadata.FileGroupProductFile{
+						{ID: 5678},
+						{Name: "some-file"},
+					},
+				},
+			},
+			ArtifactReferences: []metadata.ArtifactReference{
+				{ID: 4567, Name: "some artifact", Digest: "sha256:digest"},
+			},
+			Dependencies: []metadata.Dependency{
+				{Release: metadata.DependentRelease{
+					ID:      111,
+					Version: "621.1",
+					Product: metadata.Product{ID: 1, Slug: "stemcells"},
+				}},
+				{Release: metadata.DependentRelease{
+					ID:      222,
+					Version: "2.10.3",
+					Product: metadata.Product{ID: 2, Slug: "ops-manager"},
+				}},
+			},
+			DependencySpecifiers: []metadata.DependencySpecifier{
+				{ID: 9, ProductSlug: "stemcells", Specifier: "621.1"},
+			},
+			UpgradePaths: []metadata.UpgradePath{
+				{ID: 333, Version: "1.9.0"},
+			},
+			UpgradePathSpecifiers: []metadata.UpgradePathSpecifier{
+				{ID: 8, Specifier: "1.8.*"},
+			},
+			Tiles: []metadata.Tile{{File: "some.pivotal"}},
+		}
+	})
+
+	It("migrates deprecated sections to specifiers", func() {
+		migrated, notes, err := data.Migrate(dropReadOnly)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(migrated.Dependencies).To(BeNil())
+		Expect(migrated.UpgradePaths).To(BeNil())
+		Expect(migrated.DependencySpecifiers).To(Equal([]metadata.DependencySpecifier{
+			{ID: 9, ProductSlug: "stemcells", Specifier: "621.1"},
+			{ProductSlug: "ops-manager", Specifier: "2.10.3"},
+		}))
+		Expect(migrated.UpgradePathSpecifiers).To(Equal([]metadata.UpgradePathSpecifier{
+			{ID: 8, Specifier: "1.8.*"},
+			{Specifier: "1.9.0"},
+		}))
+
+		Expect(migrated.Release.ID).To(Equal(1234))
+		Expect(migrated.ProductFiles).To(Equal(data.ProductFiles))
+
+		Expect(notes).To(Equal([]string{
+			"dropped dependencies[0]: dependency_specifiers already has '621.1' on 'stemcells'",
+			"migrated dependencies[1] to dependency_specifiers: '2.10.3' on 'ops-manager'",
+			"migrated upgrade_paths[0] to upgrade_path_specifiers: '1.9.0'",
+		}))
+
+		_, err = migrated.Validate()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("does not modify the provided metadata", func() {
+		_, _, err := data.Migrate(true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(data.Dependencies).To(HaveLen(2))
+		Expect(data.DependencySpecifiers).To(HaveLen(1))
+		Expect(data.Release.ID).To(Equal(1234))
+		Expect(data.ProductFiles[0].SHA256).To(Equal("some-sha256"))
+	})
+
+	Context("when dropping read-only fields", func() {
+		BeforeEach(func() {
+			dropReadOnly = true
+		})
+
+		It("drops IDs, hashes, AWS object keys and tiles", func() {
+			migrated, notes, err := data.Migrate(dropReadOnly)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrated.Release.ID).To(BeZero())
+			Expect(migrated.Release.ProductFiles).To(BeNil())
+			Expect(migrated.ProductFiles).To(Equal([]metadata.ProductFile{
+				{File: "some-file", FileType: "Software"},
+			}))
+			Expect(migrated.FileGroups).To(Equal([]metadata.FileGroup{
+				{
+					Name:         "some file group",
+					ProductFiles: []metadata.FileGroupProductFile{{Name: "some-file"}},
+				},
+			}))
+			Expect(migrated.ArtifactReferences).To(Equal([]metadata.ArtifactReference{
+				{Name: "some artifact", Digest: "sha256:digest"},
+			}))
+			Expect(migrated.DependencySpecifiers[0].ID).To(BeZero())
+			Expect(migrated.UpgradePathSpecifiers[0].ID).To(BeZero())
+			Expect(migrated.Tiles).To(BeNil())
+
+			Expect(notes).To(ContainElement("dropped IDs, hashes, AWS object keys and tiles, and referred to the product files of file groups by their file"))
+		})
+
+		It("keeps the product files of file groups in metadata written by get", func() {
+			data, err := metadata.Decode([]byte(`---
+release:
+  id: 1234
+  version: 2.0.0
+  release_type: Major Release
+  eula_slug: some-eula
+  product_files:
+  - id: 5678
+  - id: 5679
+product_files:
+- id: 5678
+  file: Some File
+  aws_object_key: some/key
+  file_type: Software
+- id: 5679
+  file: Some Other File
+  aws_object_key: some/other/key
+  file_type: Software
+file_groups:
+- id: 2345
+  name: some file group
+  product_files:
+  - id: 5678
+  - id: 5679
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			migrated, _, err := data.Migrate(dropReadOnly)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(migrated.FileGroups).To(Equal([]metadata.FileGroup{
+				{
+					Name: "some file group",
+					ProductFiles: []metadata.FileGroupProductFile{
+						{Name: "Some File"},
+						{Name: "Some Other File"},
+					},
+				},
+			}))
+		})
+
+		Context("when a product file of a file group is not in product_files", func() {
+			BeforeEach(func() {
+				data.FileGroups[0].ProductFiles = append(data.FileGroups[0].ProductFiles, metadata.FileGroupProductFile{ID: 9999})
+			})
+
+			It("returns a problem rather than dropping it", func() {
+				_, _, err := data.Migrate(dropReadOnly)
+				Expect(err).To(MatchError("metadata could not be migrated:\n" +
+					"  file_groups[0].product_files[2]: product file 9999 is not in product_files, so it cannot be referred to by name"))
+			})
+		})
+	})
+
+	Context("when a deprecated entry cannot be migrated", func() {
+		BeforeEach(func() {
+			data.Dependencies[0].Release.Product.Slug = ""
+			data.UpgradePaths[0].Version = ""
+		})
+
+		It("returns every problem", func() {
+			_, _, err := data.Migrate(dropReadOnly)
+			Expect(err).To(MatchError("metadata could not be migrated:\n" +
+				"  dependencies[0]: product slug and version must be provided to migrate it\n" +
+				"  upgrade_paths[0]: version must be provided to migrate it"))
+		})
+	})
+})
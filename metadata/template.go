@@ -0,0 +1,100 @@
+package metadata
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Renderer renders metadata files as Go templates, with helpers to read
+// environment variables, files in the sources directory, and dates.
+type Renderer struct {
+	sourcesDir string
+	now        time.Time
+}
+
+func NewRenderer(sourcesDir string, now time.Time) *Renderer {
+	return &Renderer{
+		sourcesDir: sourcesDir,
+		now:        now,
+	}
+}
+
+// Render executes the contents of the named metadata file as a template.
+// Errors include the template line they occurred on.
+func (r Renderer) Render(name string, contents []byte) ([]byte, error) {
+	t, err := template.New(name).Funcs(r.funcs()).Parse(string(contents))
+	if err != nil {
+		return nil, withTemplateLine(err, name, contents)
+	}
+
+	var b bytes.Buffer
+	err = t.Execute(&b, nil)
+	if err != nil {
+		return nil, withTemplateLine(err, name, contents)
+	}
+
+	return b.Bytes(), nil
+}
+
+func (r Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"file": func(path string) (string, error) {
+			b, err := ioutil.ReadFile(filepath.Join(r.sourcesDir, path))
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(string(b)), nil
+		},
+		"today": func() string {
+			return r.now.Format(dateLayout)
+		},
+		"addDays": func(date string, days int) (string, error) {
+			return addDate(date, 0, 0, days)
+		},
+		"addMonths": func(date string, months int) (string, error) {
+			return addDate(date, 0, months, 0)
+		},
+		"addYears": func(date string, years int) (string, error) {
+			return addDate(date, years, 0, 0)
+		},
+	}
+}
+
+func addDate(date string, years int, months int, days int) (string, error) {
+	d, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return "", fmt.Errorf("date: '%s' is not in the format: '%s'", date, dateLayout)
+	}
+
+	return d.AddDate(years, months, days).Format(dateLayout), nil
+}
+
+// withTemplateLine appends the line of the template that text/template
+// reports the error on, e.g. 'template: metadata.yml:3:12: executing ...'.
+func withTemplateLine(err error, name string, contents []byte) error {
+	lineRegexp := regexp.MustCompile(`^template: ` + regexp.QuoteMeta(name) + `:(\d+)`)
+
+	match := lineRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+
+	lineNumber, _ := strconv.Atoi(match[1])
+	lines := strings.Split(string(contents), "\n")
+	if lineNumber < 1 || lineNumber > len(lines) {
+		return err
+	}
+
+	return fmt.Errorf("%s\n%d | %s", err, lineNumber, lines[lineNumber-1])
+}
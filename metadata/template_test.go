@@ -0,0 +1,111 @@
+package metadata_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Renderer", func() {
+	var (
+		sourcesDir string
+
+		renderer *metadata.Renderer
+	)
+
+	BeforeEach(func() {
+		var err error
+		sourcesDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = os.MkdirAll(filepath.Join(sourcesDir, "version"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ioutil.WriteFile(filepath.Join(sourcesDir, "version", "version"), []byte("1.2.3\n"), os.ModePerm)
+		Expect(err).NotTo(HaveOccurred())
+
+		os.Setenv("PIVNET_RESOURCE_TEST_VAR", "some-value")
+
+		renderer = metadata.NewRenderer(sourcesDir, time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC))
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("PIVNET_RESOURCE_TEST_VAR")
+
+		err := os.RemoveAll(sourcesDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("renders environment variables, files and dates", func() {
+		contents := `---
+release:
+  version: "{{ file "version/version" }}"
+  description: {{ env "PIVNET_RESOURCE_TEST_VAR" }}
+  {{- $releaseDate := today }}
+  release_date: {{ $releaseDate }}
+  end_of_support_date: {{ addMonths $releaseDate 18 }}
+  end_of_guidance_date: {{ addYears "2024-02-29" 1 }}
+  end_of_availability_date: {{ addDays $releaseDate 1 }}
+`
+
+		rendered, err := renderer.Render("metadata.yml", []byte(contents))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(rendered)).To(Equal(`---
+release:
+  version: "1.2.3"
+  description: some-value
+  release_date: 2024-01-31
+  end_of_support_date: 2025-07-31
+  end_of_guidance_date: 2025-03-01
+  end_of_availability_date: 2024-02-01
+`))
+	})
+
+	It("leaves files without template actions unchanged", func() {
+		contents := "---\nrelease:\n  version: 1.2.3\n"
+
+		rendered, err := renderer.Render("metadata.yml", []byte(contents))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(rendered)).To(Equal(contents))
+	})
+
+	Context("when the template cannot be parsed", func() {
+		It("returns an error with the template line", func() {
+			contents := "---\nrelease:\n  version: {{ unknown }}\n"
+
+			_, err := renderer.Render("metadata.yml", []byte(contents))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`metadata.yml:3: function "unknown" not defined`))
+			Expect(err.Error()).To(HaveSuffix("\n3 |   version: {{ unknown }}"))
+		})
+	})
+
+	Context("when the template cannot be executed", func() {
+		It("returns an error with the template line", func() {
+			contents := "---\nrelease:\n  release_date: 2024-01-01\n  end_of_support_date: {{ addMonths \"soon\" 18 }}\n"
+
+			_, err := renderer.Render("metadata.yml", []byte(contents))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("date: 'soon' is not in the format: '2006-01-02'"))
+			Expect(err.Error()).To(HaveSuffix("\n4 |   end_of_support_date: {{ addMonths \"soon\" 18 }}"))
+		})
+	})
+
+	Context("when a file cannot be read", func() {
+		It("returns an error", func() {
+			contents := `version: {{ file "missing" }}`
+
+			_, err := renderer.Render("metadata.yml", []byte(contents))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error calling file"))
+			Expect(err.Error()).To(HaveSuffix(`1 | version: {{ file "missing" }}`))
+		})
+	})
+})
@@ -2,10 +2,12 @@ package out
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/jsonlog"
 	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
 )
 
@@ -21,6 +23,9 @@ type OutCommand struct {
 	releaseFileGroupsAdder         releaseFileGroupsAdder
 	releaseArtifactReferencesAdder releaseArtifactReferencesAdder
 	releaseDependenciesAdder       releaseDependenciesAdder
+	specifiersValidator            specifiersValidator
+	preflightValidator             preflightValidator
+	tileVersionValidator           tileVersionValidator
 	dependencySpecifiersCreator    dependencySpecifiersCreator
 	releaseUpgradePathsAdder       releaseUpgradePathsAdder
 	upgradePathSpecifiersCreator   upgradePathSpecifiersCreator
@@ -29,6 +34,7 @@ type OutCommand struct {
 	m                              metadata.Metadata
 	skipUpload                     bool
 	filesOnly                      bool
+	verifyTileVersion              bool
 }
 
 type OutCommandConfig struct {
@@ -43,6 +49,9 @@ type OutCommandConfig struct {
 	ReleaseFileGroupsAdder         releaseFileGroupsAdder
 	ReleaseArtifactReferencesAdder releaseArtifactReferencesAdder
 	ReleaseDependenciesAdder       releaseDependenciesAdder
+	SpecifiersValidator            specifiersValidator
+	PreflightValidator             preflightValidator
+	TileVersionValidator           tileVersionValidator
 	DependencySpecifiersCreator    dependencySpecifiersCreator
 	ReleaseUpgradePathsAdder       releaseUpgradePathsAdder
 	UpgradePathSpecifiersCreator   upgradePathSpecifiersCreator
@@ -51,6 +60,7 @@ type OutCommandConfig struct {
 	M                              metadata.Metadata
 	SkipUpload                     bool
 	FilesOnly                      bool
+	VerifyTileVersion              bool
 }
 
 func NewOutCommand(config OutCommandConfig) OutCommand {
@@ -66,6 +76,9 @@ func NewOutCommand(config OutCommandConfig) OutCommand {
 		releaseFileGroupsAdder:         config.ReleaseFileGroupsAdder,
 		releaseArtifactReferencesAdder: config.ReleaseArtifactReferencesAdder,
 		releaseDependenciesAdder:       config.ReleaseDependenciesAdder,
+		specifiersValidator:            config.SpecifiersValidator,
+		preflightValidator:             config.PreflightValidator,
+		tileVersionValidator:           config.TileVersionValidator,
 		dependencySpecifiersCreator:    config.DependencySpecifiersCreator,
 		releaseUpgradePathsAdder:       config.ReleaseUpgradePathsAdder,
 		upgradePathSpecifiersCreator:   config.UpgradePathSpecifiersCreator,
@@ -74,6 +87,7 @@ func NewOutCommand(config OutCommandConfig) OutCommand {
 		m:                              config.M,
 		skipUpload:                     config.SkipUpload,
 		filesOnly:                      config.FilesOnly,
+		verifyTileVersion:              config.VerifyTileVersion,
 	}
 }
 
@@ -112,6 +126,21 @@ type releaseDependenciesAdder interface {
 	AddReleaseDependencies(release pivnet.Release) error
 }
 
+//counterfeiter:generate --fake-name SpecifiersValidator . specifiersValidator
+type specifiersValidator interface {
+	ValidateSpecifiers() error
+}
+
+//counterfeiter:generate --fake-name PreflightValidator . preflightValidator
+type preflightValidator interface {
+	ValidatePreflight(exactGlobs []string) error
+}
+
+//counterfeiter:generate --fake-name TileVersionValidator . tileVersionValidator
+type tileVersionValidator interface {
+	ValidateTileVersions(exactGlobs []string) error
+}
+
 //counterfeiter:generate --fake-name DependencySpecifiersCreator . dependencySpecifiersCreator
 type dependencySpecifiersCreator interface {
 	CreateDependencySpecifiers(release pivnet.Release) error
@@ -174,12 +203,29 @@ func (c OutCommand) Run(input concourse.OutRequest) (concourse.OutResponse, erro
 		}
 	}
 
+	var problems []error
 	if len(missingFiles) > 0 {
-		return concourse.OutResponse{},
-			fmt.Errorf(
-				"product files were provided in metadata that match no globs: %v",
-				missingFiles,
-			)
+		problems = append(problems, fmt.Errorf(
+			"product files were provided in metadata that match no globs: %v",
+			missingFiles,
+		))
+	}
+
+	problems = append(problems, c.preflight(exactGlobs)...)
+
+	switch len(problems) {
+	case 0:
+	case 1:
+		return concourse.OutResponse{}, problems[0]
+	default:
+		messages := make([]string, len(problems))
+		for i, problem := range problems {
+			messages[i] = problem.Error()
+		}
+		return concourse.OutResponse{}, fmt.Errorf(
+			"pre-flight validation failed - nothing was changed on Pivnet:\n%s",
+			strings.Join(messages, "\n"),
+		)
 	}
 
 	var pivnetRelease pivnet.Release
@@ -195,6 +241,8 @@ func (c OutCommand) Run(input concourse.OutRequest) (concourse.OutResponse, erro
 		}
 	}
 
+	jsonlog.SetReleaseID(c.logger, pivnetRelease.ID)
+
 	if c.skipUpload {
 		c.logger.Info(
 			"file glob not provided - skipping upload to s3")
@@ -205,7 +253,14 @@ func (c OutCommand) Run(input concourse.OutRequest) (concourse.OutResponse, erro
 		}
 	}
 
-	if !c.filesOnly {
+	if c.filesOnly {
+		if len(c.m.ArtifactReferences) > 0 || c.m.ExistingRelease.PruneArtifactReferences {
+			err = c.releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+			if err != nil {
+				return concourse.OutResponse{}, err
+			}
+		}
+	} else {
 		err = c.releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
 		if err != nil {
 			return concourse.OutResponse{}, err
@@ -250,3 +305,37 @@ func (c OutCommand) Run(input concourse.OutRequest) (concourse.OutResponse, erro
 
 	return out, nil
 }
+
+// preflight checks the metadata and the files to be uploaded against Pivnet
+// before anything is changed on Pivnet, returning the errors of every check.
+func (c OutCommand) preflight(exactGlobs []string) []error {
+	var errs []error
+
+	// Without a file glob nothing is uploaded, so the release will have no
+	// product files of its own.
+	uploadGlobs := exactGlobs
+	if c.skipUpload {
+		uploadGlobs = nil
+	}
+
+	err := c.preflightValidator.ValidatePreflight(uploadGlobs)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if !c.filesOnly {
+		err = c.specifiersValidator.ValidateSpecifiers()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if c.verifyTileVersion {
+			err = c.tileVersionValidator.ValidateTileVersions(exactGlobs)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errs
+}
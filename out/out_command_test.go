@@ -25,6 +25,9 @@ var _ = Describe("Out", func() {
 			releaseFileGroupsAdder         *outfakes.ReleaseFileGroupsAdder
 			releaseArtifactReferencesAdder *outfakes.ReleaseArtifactReferencesAdder
 			releaseDependenciesAdder       *outfakes.ReleaseDependenciesAdder
+			specifiersValidator            *outfakes.SpecifiersValidator
+			preflightValidator             *outfakes.PreflightValidator
+			tileVersionValidator           *outfakes.TileVersionValidator
 			dependencySpecifiersCreator    *outfakes.DependencySpecifiersCreator
 			releaseUpgradePathsAdder       *outfakes.ReleaseUpgradePathsAdder
 			upgradePathSpecifiersCreator   *outfakes.UpgradePathSpecifiersCreator
@@ -35,8 +38,9 @@ var _ = Describe("Out", func() {
 			globber                        *outfakes.Globber
 			cmd                            out.OutCommand
 
-			skipUpload bool
-			request    concourse.OutRequest
+			skipUpload        bool
+			verifyTileVersion bool
+			request           concourse.OutRequest
 
 			productSlug string
 
@@ -53,6 +57,8 @@ var _ = Describe("Out", func() {
 			addReleaseFileGroupsErr         error
 			addReleaseArtifactReferencesErr error
 			addReleaseDependenciesErr       error
+			validateSpecifiersErr           error
+			validatePreflightErr            error
 			createDependencySpecifiersErr   error
 			addReleaseUpgradePathsErr       error
 			createUpgradePathSpecifiersErr  error
@@ -68,6 +74,9 @@ var _ = Describe("Out", func() {
 			releaseFileGroupsAdder = &outfakes.ReleaseFileGroupsAdder{}
 			releaseArtifactReferencesAdder = &outfakes.ReleaseArtifactReferencesAdder{}
 			releaseDependenciesAdder = &outfakes.ReleaseDependenciesAdder{}
+			specifiersValidator = &outfakes.SpecifiersValidator{}
+			preflightValidator = &outfakes.PreflightValidator{}
+			tileVersionValidator = &outfakes.TileVersionValidator{}
 			dependencySpecifiersCreator = &outfakes.DependencySpecifiersCreator{}
 			releaseUpgradePathsAdder = &outfakes.ReleaseUpgradePathsAdder{}
 			upgradePathSpecifiersCreator = &outfakes.UpgradePathSpecifiersCreator{}
@@ -78,6 +87,7 @@ var _ = Describe("Out", func() {
 			globber = &outfakes.Globber{}
 
 			skipUpload = false
+			verifyTileVersion = false
 
 			productSlug = "some-product-slug"
 
@@ -92,6 +102,8 @@ var _ = Describe("Out", func() {
 			addReleaseFileGroupsErr = nil
 			addReleaseArtifactReferencesErr = nil
 			addReleaseDependenciesErr = nil
+			validateSpecifiersErr = nil
+			validatePreflightErr = nil
 			createDependencySpecifiersErr = nil
 			addReleaseUpgradePathsErr = nil
 			createUpgradePathSpecifiersErr = nil
@@ -127,6 +139,9 @@ var _ = Describe("Out", func() {
 					ReleaseFileGroupsAdder:         releaseFileGroupsAdder,
 					ReleaseArtifactReferencesAdder: releaseArtifactReferencesAdder,
 					ReleaseDependenciesAdder:       releaseDependenciesAdder,
+					SpecifiersValidator:            specifiersValidator,
+					PreflightValidator:             preflightValidator,
+					TileVersionValidator:           tileVersionValidator,
 					DependencySpecifiersCreator:    dependencySpecifiersCreator,
 					ReleaseUpgradePathsAdder:       releaseUpgradePathsAdder,
 					UpgradePathSpecifiersCreator:   upgradePathSpecifiersCreator,
@@ -134,6 +149,7 @@ var _ = Describe("Out", func() {
 					M:                              meta,
 					SkipUpload:                     skipUpload,
 					FilesOnly:                      false,
+					VerifyTileVersion:              verifyTileVersion,
 				}
 
 				cmd = out.NewOutCommand(config)
@@ -149,6 +165,8 @@ var _ = Describe("Out", func() {
 				releaseFileGroupsAdder.AddReleaseFileGroupsReturns(addReleaseFileGroupsErr)
 				releaseArtifactReferencesAdder.AddReleaseArtifactReferencesReturns(addReleaseArtifactReferencesErr)
 				releaseDependenciesAdder.AddReleaseDependenciesReturns(addReleaseDependenciesErr)
+				specifiersValidator.ValidateSpecifiersReturns(validateSpecifiersErr)
+				preflightValidator.ValidatePreflightReturns(validatePreflightErr)
 				dependencySpecifiersCreator.CreateDependencySpecifiersReturns(createDependencySpecifiersErr)
 				releaseUpgradePathsAdder.AddReleaseUpgradePathsReturns(addReleaseUpgradePathsErr)
 				upgradePathSpecifiersCreator.CreateUpgradePathSpecifiersReturns(createUpgradePathSpecifiersErr)
@@ -176,6 +194,9 @@ var _ = Describe("Out", func() {
 					},
 				}))
 
+				Expect(preflightValidator.ValidatePreflightCallCount()).To(Equal(1))
+				Expect(preflightValidator.ValidatePreflightArgsForCall(0)).To(Equal([]string{"some-glob-1", "some-glob-2"}))
+				Expect(specifiersValidator.ValidateSpecifiersCallCount()).To(Equal(1))
 				Expect(creator.CreateCallCount()).To(Equal(1))
 
 				Expect(globber.ExactGlobsCallCount()).To(Equal(1))
@@ -202,6 +223,49 @@ var _ = Describe("Out", func() {
 				Expect(invokedReleaseVersion).To(Equal("some-version"))
 			})
 
+			It("does not validate tile versions", func() {
+				_, err := cmd.Run(request)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tileVersionValidator.ValidateTileVersionsCallCount()).To(Equal(0))
+			})
+
+			Context("when verifyTileVersion is true", func() {
+				var (
+					validateTileVersionsErr error
+				)
+
+				BeforeEach(func() {
+					verifyTileVersion = true
+					validateTileVersionsErr = nil
+				})
+
+				JustBeforeEach(func() {
+					tileVersionValidator.ValidateTileVersionsReturns(validateTileVersionsErr)
+				})
+
+				It("validates the versions of the files to be uploaded", func() {
+					_, err := cmd.Run(request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(tileVersionValidator.ValidateTileVersionsCallCount()).To(Equal(1))
+					Expect(tileVersionValidator.ValidateTileVersionsArgsForCall(0)).To(Equal(returnedExactGlobs))
+				})
+
+				Context("when a tile version does not match", func() {
+					BeforeEach(func() {
+						validateTileVersionsErr = errors.New("some tile version error")
+					})
+
+					It("returns the error without creating the release", func() {
+						_, err := cmd.Run(request)
+						Expect(err).To(Equal(validateTileVersionsErr))
+
+						Expect(creator.CreateCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when skipUpload is true", func() {
 				BeforeEach(func() {
 					skipUpload = true
@@ -213,6 +277,13 @@ var _ = Describe("Out", func() {
 
 					Expect(uploader.UploadCallCount()).To(Equal(0))
 				})
+
+				It("pre-flights the release without product files of its own", func() {
+					_, err := cmd.Run(request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(preflightValidator.ValidatePreflightArgsForCall(0)).To(BeNil())
+				})
 			})
 
 			Context("when outdir is not provided", func() {
@@ -258,6 +329,53 @@ var _ = Describe("Out", func() {
 				})
 			})
 
+			Context("when the specifiers are invalid", func() {
+				BeforeEach(func() {
+					validateSpecifiersErr = errors.New("some specifiers error")
+				})
+
+				It("returns an error without creating the release", func() {
+					_, err := cmd.Run(request)
+					Expect(err).To(MatchError(validateSpecifiersErr))
+
+					Expect(creator.CreateCallCount()).To(Equal(0))
+					Expect(uploader.UploadCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the pre-flight validation fails", func() {
+				BeforeEach(func() {
+					validatePreflightErr = errors.New("some pre-flight error")
+				})
+
+				It("returns an error without creating the release", func() {
+					_, err := cmd.Run(request)
+					Expect(err).To(Equal(validatePreflightErr))
+
+					Expect(creator.CreateCallCount()).To(Equal(0))
+					Expect(uploader.UploadCallCount()).To(Equal(0))
+				})
+
+				Context("when there are other problems", func() {
+					BeforeEach(func() {
+						returnedExactGlobs = []string{"some-glob-1"}
+						validateSpecifiersErr = errors.New("some specifiers error")
+					})
+
+					It("returns every problem together", func() {
+						_, err := cmd.Run(request)
+						Expect(err).To(MatchError(
+							"pre-flight validation failed - nothing was changed on Pivnet:\n" +
+								"product files were provided in metadata that match no globs: [some-glob-2]\n" +
+								"some pre-flight error\n" +
+								"some specifiers error",
+						))
+
+						Expect(creator.CreateCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 			Context("when a release cannot be created", func() {
 				BeforeEach(func() {
 					createErr = errors.New("some create error")
@@ -376,6 +494,8 @@ var _ = Describe("Out", func() {
 					ReleaseFileGroupsAdder:         releaseFileGroupsAdder,
 					ReleaseArtifactReferencesAdder: releaseArtifactReferencesAdder,
 					ReleaseDependenciesAdder:       releaseDependenciesAdder,
+					SpecifiersValidator:            specifiersValidator,
+					PreflightValidator:             preflightValidator,
 					DependencySpecifiersCreator:    dependencySpecifiersCreator,
 					ReleaseUpgradePathsAdder:       releaseUpgradePathsAdder,
 					UpgradePathSpecifiersCreator:   upgradePathSpecifiersCreator,
@@ -398,6 +518,8 @@ var _ = Describe("Out", func() {
 				releaseFileGroupsAdder.AddReleaseFileGroupsReturns(addReleaseFileGroupsErr)
 				releaseArtifactReferencesAdder.AddReleaseArtifactReferencesReturns(addReleaseArtifactReferencesErr)
 				releaseDependenciesAdder.AddReleaseDependenciesReturns(addReleaseDependenciesErr)
+				specifiersValidator.ValidateSpecifiersReturns(validateSpecifiersErr)
+				preflightValidator.ValidatePreflightReturns(validatePreflightErr)
 				dependencySpecifiersCreator.CreateDependencySpecifiersReturns(createDependencySpecifiersErr)
 				releaseUpgradePathsAdder.AddReleaseUpgradePathsReturns(addReleaseUpgradePathsErr)
 				upgradePathSpecifiersCreator.CreateUpgradePathSpecifiersReturns(createUpgradePathSpecifiersErr)
@@ -447,6 +569,84 @@ var _ = Describe("Out", func() {
 				})
 			})
 
+			Context("when artifact references are provided", func() {
+				BeforeEach(func() {
+					pivnetRelease = pivnet.Release{ID: 123, Version: "existing-product-version"}
+				})
+
+				JustBeforeEach(func() {
+					meta := metadata.Metadata{
+						ExistingRelease: &metadata.ExistingRelease{
+							ID: 123,
+						},
+						ArtifactReferences: []metadata.ArtifactReference{
+							{ID: 456},
+						},
+					}
+
+					cmd = out.NewOutCommand(out.OutCommandConfig{
+						Logger:                         fakeLogger,
+						OutDir:                         "some/out/dir",
+						SourcesDir:                     "some/sources/dir",
+						GlobClient:                     globber,
+						Validation:                     validator,
+						Creator:                        creator,
+						Finder:                         finder,
+						Finalizer:                      finalizer,
+						UserGroupsUpdater:              userGroupsUpdater,
+						ReleaseFileGroupsAdder:         releaseFileGroupsAdder,
+						ReleaseArtifactReferencesAdder: releaseArtifactReferencesAdder,
+						ReleaseDependenciesAdder:       releaseDependenciesAdder,
+						SpecifiersValidator:            specifiersValidator,
+						PreflightValidator:             preflightValidator,
+						DependencySpecifiersCreator:    dependencySpecifiersCreator,
+						ReleaseUpgradePathsAdder:       releaseUpgradePathsAdder,
+						UpgradePathSpecifiersCreator:   upgradePathSpecifiersCreator,
+						Uploader:                       uploader,
+						M:                              meta,
+						SkipUpload:                     skipUpload,
+						FilesOnly:                      true,
+					})
+				})
+
+				It("adds the artifact references to the existing release", func() {
+					_, err := cmd.Run(request)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(releaseArtifactReferencesAdder.AddReleaseArtifactReferencesCallCount()).To(Equal(1))
+					Expect(releaseArtifactReferencesAdder.AddReleaseArtifactReferencesArgsForCall(0)).To(Equal(pivnetRelease))
+
+					Expect(creator.CreateCallCount()).To(Equal(0))
+					Expect(releaseFileGroupsAdder.AddReleaseFileGroupsCallCount()).To(Equal(0))
+				})
+
+				Context("when adding the artifact references fails", func() {
+					BeforeEach(func() {
+						addReleaseArtifactReferencesErr = errors.New("some artifact references error")
+					})
+
+					It("returns an error", func() {
+						_, err := cmd.Run(request)
+						Expect(err).To(MatchError("some artifact references error"))
+					})
+				})
+			})
+
+			Context("when the pre-flight validation fails", func() {
+				BeforeEach(func() {
+					validatePreflightErr = errors.New("some pre-flight error")
+				})
+
+				It("returns an error without uploading files", func() {
+					_, err := cmd.Run(request)
+					Expect(err).To(Equal(validatePreflightErr))
+
+					Expect(finder.FindCallCount()).To(Equal(0))
+					Expect(uploader.UploadCallCount()).To(Equal(0))
+					Expect(specifiersValidator.ValidateSpecifiersCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("finder cannot find release", func() {
 				BeforeEach(func() {
 					findErr = errors.New("some find error")
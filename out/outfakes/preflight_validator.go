@@ -0,0 +1,112 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package outfakes
+
+import (
+	"sync"
+)
+
+type PreflightValidator struct {
+	ValidatePreflightStub        func([]string) error
+	validatePreflightMutex       sync.RWMutex
+	validatePreflightArgsForCall []struct {
+		arg1 []string
+	}
+	validatePreflightReturns struct {
+		result1 error
+	}
+	validatePreflightReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PreflightValidator) ValidatePreflight(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.validatePreflightMutex.Lock()
+	ret, specificReturn := fake.validatePreflightReturnsOnCall[len(fake.validatePreflightArgsForCall)]
+	fake.validatePreflightArgsForCall = append(fake.validatePreflightArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.ValidatePreflightStub
+	fakeReturns := fake.validatePreflightReturns
+	fake.recordInvocation("ValidatePreflight", []interface{}{arg1Copy})
+	fake.validatePreflightMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PreflightValidator) ValidatePreflightCallCount() int {
+	fake.validatePreflightMutex.RLock()
+	defer fake.validatePreflightMutex.RUnlock()
+	return len(fake.validatePreflightArgsForCall)
+}
+
+func (fake *PreflightValidator) ValidatePreflightCalls(stub func([]string) error) {
+	fake.validatePreflightMutex.Lock()
+	defer fake.validatePreflightMutex.Unlock()
+	fake.ValidatePreflightStub = stub
+}
+
+func (fake *PreflightValidator) ValidatePreflightArgsForCall(i int) []string {
+	fake.validatePreflightMutex.RLock()
+	defer fake.validatePreflightMutex.RUnlock()
+	argsForCall := fake.validatePreflightArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PreflightValidator) ValidatePreflightReturns(result1 error) {
+	fake.validatePreflightMutex.Lock()
+	defer fake.validatePreflightMutex.Unlock()
+	fake.ValidatePreflightStub = nil
+	fake.validatePreflightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PreflightValidator) ValidatePreflightReturnsOnCall(i int, result1 error) {
+	fake.validatePreflightMutex.Lock()
+	defer fake.validatePreflightMutex.Unlock()
+	fake.ValidatePreflightStub = nil
+	if fake.validatePreflightReturnsOnCall == nil {
+		fake.validatePreflightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validatePreflightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PreflightValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validatePreflightMutex.RLock()
+	defer fake.validatePreflightMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PreflightValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,98 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package outfakes
+
+import (
+	"sync"
+)
+
+type SpecifiersValidator struct {
+	ValidateSpecifiersStub        func() error
+	validateSpecifiersMutex       sync.RWMutex
+	validateSpecifiersArgsForCall []struct {
+	}
+	validateSpecifiersReturns struct {
+		result1 error
+	}
+	validateSpecifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SpecifiersValidator) ValidateSpecifiers() error {
+	fake.validateSpecifiersMutex.Lock()
+	ret, specificReturn := fake.validateSpecifiersReturnsOnCall[len(fake.validateSpecifiersArgsForCall)]
+	fake.validateSpecifiersArgsForCall = append(fake.validateSpecifiersArgsForCall, struct {
+	}{})
+	stub := fake.ValidateSpecifiersStub
+	fakeReturns := fake.validateSpecifiersReturns
+	fake.recordInvocation("ValidateSpecifiers", []interface{}{})
+	fake.validateSpecifiersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SpecifiersValidator) ValidateSpecifiersCallCount() int {
+	fake.validateSpecifiersMutex.RLock()
+	defer fake.validateSpecifiersMutex.RUnlock()
+	return len(fake.validateSpecifiersArgsForCall)
+}
+
+func (fake *SpecifiersValidator) ValidateSpecifiersCalls(stub func() error) {
+	fake.validateSpecifiersMutex.Lock()
+	defer fake.validateSpecifiersMutex.Unlock()
+	fake.ValidateSpecifiersStub = stub
+}
+
+func (fake *SpecifiersValidator) ValidateSpecifiersReturns(result1 error) {
+	fake.validateSpecifiersMutex.Lock()
+	defer fake.validateSpecifiersMutex.Unlock()
+	fake.ValidateSpecifiersStub = nil
+	fake.validateSpecifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SpecifiersValidator) ValidateSpecifiersReturnsOnCall(i int, result1 error) {
+	fake.validateSpecifiersMutex.Lock()
+	defer fake.validateSpecifiersMutex.Unlock()
+	fake.ValidateSpecifiersStub = nil
+	if fake.validateSpecifiersReturnsOnCall == nil {
+		fake.validateSpecifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateSpecifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SpecifiersValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateSpecifiersMutex.RLock()
+	defer fake.validateSpecifiersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SpecifiersValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
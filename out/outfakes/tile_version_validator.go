@@ -0,0 +1,112 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package outfakes
+
+import (
+	"sync"
+)
+
+type TileVersionValidator struct {
+	ValidateTileVersionsStub        func([]string) error
+	validateTileVersionsMutex       sync.RWMutex
+	validateTileVersionsArgsForCall []struct {
+		arg1 []string
+	}
+	validateTileVersionsReturns struct {
+		result1 error
+	}
+	validateTileVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TileVersionValidator) ValidateTileVersions(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.validateTileVersionsMutex.Lock()
+	ret, specificReturn := fake.validateTileVersionsReturnsOnCall[len(fake.validateTileVersionsArgsForCall)]
+	fake.validateTileVersionsArgsForCall = append(fake.validateTileVersionsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	stub := fake.ValidateTileVersionsStub
+	fakeReturns := fake.validateTileVersionsReturns
+	fake.recordInvocation("ValidateTileVersions", []interface{}{arg1Copy})
+	fake.validateTileVersionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TileVersionValidator) ValidateTileVersionsCallCount() int {
+	fake.validateTileVersionsMutex.RLock()
+	defer fake.validateTileVersionsMutex.RUnlock()
+	return len(fake.validateTileVersionsArgsForCall)
+}
+
+func (fake *TileVersionValidator) ValidateTileVersionsCalls(stub func([]string) error) {
+	fake.validateTileVersionsMutex.Lock()
+	defer fake.validateTileVersionsMutex.Unlock()
+	fake.ValidateTileVersionsStub = stub
+}
+
+func (fake *TileVersionValidator) ValidateTileVersionsArgsForCall(i int) []string {
+	fake.validateTileVersionsMutex.RLock()
+	defer fake.validateTileVersionsMutex.RUnlock()
+	argsForCall := fake.validateTileVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *TileVersionValidator) ValidateTileVersionsReturns(result1 error) {
+	fake.validateTileVersionsMutex.Lock()
+	defer fake.validateTileVersionsMutex.Unlock()
+	fake.ValidateTileVersionsStub = nil
+	fake.validateTileVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TileVersionValidator) ValidateTileVersionsReturnsOnCall(i int, result1 error) {
+	fake.validateTileVersionsMutex.Lock()
+	defer fake.validateTileVersionsMutex.Unlock()
+	fake.ValidateTileVersionsStub = nil
+	if fake.validateTileVersionsReturnsOnCall == nil {
+		fake.validateTileVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateTileVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TileVersionValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateTileVersionsMutex.RLock()
+	defer fake.validateTileVersionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TileVersionValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,452 @@
+package release
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blang/semver"
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+)
+
+type PreflightValidator struct {
+	logger          logger.Logger
+	pivnet          preflightClient
+	semverConverter semverConverter
+	metadata        metadata.Metadata
+	params          concourse.OutParams
+	source          concourse.Source
+	productSlug     string
+}
+
+func NewPreflightValidator(
+	logger logger.Logger,
+	pivnetClient preflightClient,
+	semverConverter semverConverter,
+	metadata metadata.Metadata,
+	params concourse.OutParams,
+	source concourse.Source,
+	productSlug string,
+) PreflightValidator {
+	return PreflightValidator{
+		logger:          logger,
+		pivnet:          pivnetClient,
+		semverConverter: semverConverter,
+		metadata:        metadata,
+		params:          params,
+		source:          source,
+		productSlug:     productSlug,
+	}
+}
+
+//counterfeiter:generate --fake-name PreflightClient . preflightClient
+type preflightClient interface {
+	EULAs() ([]pivnet.EULA, error)
+	ReleaseTypes() ([]pivnet.ReleaseType, error)
+	ListUserGroups() ([]pivnet.UserGroup, error)
+	ReleasesForProductSlug(productSlug string) ([]pivnet.Release, error)
+	FileGroups(productSlug string) ([]pivnet.FileGroup, error)
+	ProductFiles(productSlug string) ([]pivnet.ProductFile, error)
+	ArtifactReferences(productSlug string) ([]pivnet.ArtifactReference, error)
+}
+
+//counterfeiter:generate --fake-name FakeSemverConverter . semverConverter
+type semverConverter interface {
+	ToValidSemver(string) (semver.Version, error)
+}
+
+// ValidatePreflight checks the metadata against Pivnet before anything is
+// changed on Pivnet: the release version, EULA, release type and user groups
+// of a new release, every existing file group, product file and artifact
+// reference referred to by ID, and every product file of a file group
+// referred to by name, which must be exactly one of the files uploaded from
+// exactGlobs or else exactly one existing product file. All problems are
+// reported together.
+func (v PreflightValidator) ValidatePreflight(exactGlobs []string) error {
+	var problems []string
+
+	if v.metadata.ExistingRelease == nil && v.metadata.Release != nil {
+		releaseProblems, err := v.validateRelease()
+		if err != nil {
+			return err
+		}
+		problems = append(problems, releaseProblems...)
+
+		userGroupProblems, err := v.validateUserGroups()
+		if err != nil {
+			return err
+		}
+		problems = append(problems, userGroupProblems...)
+
+		fileGroupProblems, err := v.validateFileGroups(exactGlobs)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, fileGroupProblems...)
+	}
+
+	artifactReferenceProblems, err := v.validateArtifactReferences()
+	if err != nil {
+		return err
+	}
+	problems = append(problems, artifactReferenceProblems...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf(
+			"invalid metadata:\n  %s",
+			strings.Join(problems, "\n  "),
+		)
+	}
+
+	return nil
+}
+
+func (v PreflightValidator) validateRelease() ([]string, error) {
+	var problems []string
+
+	version := v.metadata.Release.Version
+
+	v.logger.Info(fmt.Sprintf("Validating release version: '%s'", version))
+
+	if v.source.SortBy.Includes(concourse.SortBySemver) {
+		_, err := v.semverConverter.ToValidSemver(version)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"release.version: '%s' is not valid semver: %s",
+				version,
+				err,
+			))
+		}
+	}
+
+	err := validateProductVersion(version, v.source.ProductVersion)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("release.version: %s", err))
+	}
+
+	// With override, an existing release is deleted by the release creator,
+	// so it is not a problem here.
+	if !v.params.Override {
+		releases, err := v.pivnet.ReleasesForProductSlug(v.productSlug)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range releases {
+			if r.Version == version {
+				problems = append(problems, fmt.Sprintf(
+					"release.version: release '%s' with version '%s' already exists",
+					v.productSlug,
+					version,
+				))
+				break
+			}
+		}
+	}
+
+	v.logger.Info(fmt.Sprintf("Validating EULA: '%s'", v.metadata.Release.EULASlug))
+
+	eulas, err := v.pivnet.EULAs()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateEULA(v.metadata.Release.EULASlug, eulas)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("release.eula_slug: %s", err))
+	}
+
+	v.logger.Info(fmt.Sprintf("Validating release type: '%s'", v.metadata.Release.ReleaseType))
+
+	releaseTypes, err := v.pivnet.ReleaseTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateReleaseType(v.metadata.Release.ReleaseType, releaseTypes, v.source.ReleaseType)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("release.release_type: %s", err))
+	}
+
+	return problems, nil
+}
+
+func (v PreflightValidator) validateUserGroups() ([]string, error) {
+	userGroupIDs := v.metadata.Release.UserGroupIDs
+	userGroupNames := v.metadata.Release.UserGroups
+
+	if len(userGroupIDs) == 0 && len(userGroupNames) == 0 {
+		return nil, nil
+	}
+
+	v.logger.Info("Validating user groups")
+
+	userGroups, err := v.pivnet.ListUserGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(userGroups))
+	for _, g := range userGroups {
+		known[g.ID] = true
+	}
+
+	var problems []string
+	for i, idString := range userGroupIDs {
+		id, err := strconv.Atoi(idString)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf(
+				"release.user_group_ids[%d]: '%s' is not a valid ID",
+				i,
+				idString,
+			))
+			continue
+		}
+
+		if !known[id] {
+			problems = append(problems, fmt.Sprintf(
+				"release.user_group_ids[%d]: user group with ID: %d could not be found",
+				i,
+				id,
+			))
+		}
+	}
+
+	if len(userGroupNames) > 0 {
+		_, err = resolveUserGroupIDs(userGroupNames, userGroups)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("release.user_groups: %s", err))
+		}
+	}
+
+	return problems, nil
+}
+
+func (v PreflightValidator) validateFileGroups(exactGlobs []string) ([]string, error) {
+	var fileGroupIDs, productFileIDs, productFileNames bool
+	for _, fileGroup := range v.metadata.FileGroups {
+		if fileGroup.ID != 0 {
+			fileGroupIDs = true
+		}
+		for _, pf := range fileGroup.ProductFiles {
+			if pf.ID != 0 {
+				productFileIDs = true
+			} else if fileGroup.ID == 0 {
+				productFileNames = true
+			}
+		}
+	}
+
+	var problems []string
+
+	if fileGroupIDs {
+		v.logger.Info("Validating file group IDs")
+
+		fileGroups, err := v.pivnet.FileGroups(v.productSlug)
+		if err != nil {
+			return nil, err
+		}
+
+		known := make(map[int]bool, len(fileGroups))
+		for _, g := range fileGroups {
+			known[g.ID] = true
+		}
+
+		for i, fileGroup := range v.metadata.FileGroups {
+			if fileGroup.ID != 0 && !known[fileGroup.ID] {
+				problems = append(problems, fmt.Sprintf(
+					"file_groups[%d]: file group with ID: %d could not be found",
+					i,
+					fileGroup.ID,
+				))
+			}
+		}
+	}
+
+	if !productFileIDs && !productFileNames {
+		return problems, nil
+	}
+
+	v.logger.Info("Validating file group product files")
+
+	productFiles, err := v.pivnet.ProductFiles(v.productSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(productFiles))
+	for _, pf := range productFiles {
+		known[pf.ID] = true
+	}
+
+	// Names are resolved as the release file groups adder resolves them once
+	// the files have been uploaded. Product files of file groups referred to
+	// by ID are left as they are, so their names are not resolved.
+	resolver := newPlannedProductFileResolver(v.metadata, exactGlobs, productFiles)
+
+	for i, fileGroup := range v.metadata.FileGroups {
+		for j, pf := range fileGroup.ProductFiles {
+			if pf.ID != 0 {
+				if !known[pf.ID] {
+					problems = append(problems, fmt.Sprintf(
+						"file_groups[%d].product_files[%d]: product file with ID: %d could not be found",
+						i,
+						j,
+						pf.ID,
+					))
+				}
+				continue
+			}
+
+			if fileGroup.ID != 0 {
+				continue
+			}
+
+			_, err := resolver.resolveRef(pf)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf(
+					"file_groups[%d].product_files[%d]: %s",
+					i,
+					j,
+					err,
+				))
+			}
+		}
+	}
+
+	return problems, nil
+}
+
+func (v PreflightValidator) validateArtifactReferences() ([]string, error) {
+	var ids bool
+	for _, artifactReference := range v.metadata.ArtifactReferences {
+		if artifactReference.ID != 0 {
+			ids = true
+			break
+		}
+	}
+
+	if !ids {
+		return nil, nil
+	}
+
+	v.logger.Info("Validating artifact reference IDs")
+
+	artifactReferences, err := v.pivnet.ArtifactReferences(v.productSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(artifactReferences))
+	for _, ref := range artifactReferences {
+		known[ref.ID] = true
+	}
+
+	var problems []string
+	for i, artifactReference := range v.metadata.ArtifactReferences {
+		if artifactReference.ID != 0 && !known[artifactReference.ID] {
+			problems = append(problems, fmt.Sprintf(
+				"artifact_references[%d]: artifact reference with ID: %d could not be found",
+				i,
+				artifactReference.ID,
+			))
+		}
+	}
+
+	return problems, nil
+}
+
+// validateProductVersion returns an error if the version matches none of the
+// product_version regexes of the source, if there are any.
+func validateProductVersion(version string, productVersions []string) error {
+	if len(productVersions) == 0 {
+		return nil
+	}
+
+	for _, productVersion := range productVersions {
+		match, err := regexp.MatchString(productVersion, version)
+		if err != nil {
+			return err
+		}
+
+		if match {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"provided product version: '%s' does not match any regex in source: %s",
+		version,
+		printable(productVersions),
+	)
+}
+
+func validateEULA(eulaSlug string, eulas []pivnet.EULA) error {
+	eulaSlugs := make([]string, len(eulas))
+	for i, e := range eulas {
+		eulaSlugs[i] = e.Slug
+	}
+
+	for _, slug := range eulaSlugs {
+		if eulaSlug == slug {
+			return nil
+		}
+	}
+
+	return notFoundError("EULA slug", eulaSlug, eulaSlugs)
+}
+
+// validateReleaseType returns an error if the release type does not exist on
+// Pivnet, or is not one of the release types of the source, if there are any.
+func validateReleaseType(
+	releaseType string,
+	releaseTypes []pivnet.ReleaseType,
+	sourceReleaseTypes []string,
+) error {
+	releaseTypesAsStrings := make([]string, len(releaseTypes))
+	for i, r := range releaseTypes {
+		releaseTypesAsStrings[i] = string(r)
+	}
+
+	var containsReleaseType bool
+	for _, t := range releaseTypesAsStrings {
+		if releaseType == t {
+			containsReleaseType = true
+			break
+		}
+	}
+
+	if !containsReleaseType {
+		releaseTypesPrintable := fmt.Sprintf(
+			"['%s']",
+			strings.Join(releaseTypesAsStrings, "', '"),
+		)
+		return fmt.Errorf(
+			"provided release type: '%s' must be one of: %s",
+			releaseType,
+			releaseTypesPrintable,
+		)
+	}
+
+	var matchesSourceReleaseType bool
+	for _, t := range sourceReleaseTypes {
+		if releaseType == t {
+			matchesSourceReleaseType = true
+			break
+		}
+	}
+
+	if len(sourceReleaseTypes) > 0 && !matchesSourceReleaseType {
+		return fmt.Errorf(
+			"provided release type: '%s' must match one of %s from source configuration",
+			releaseType,
+			printable(sourceReleaseTypes),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package release_test
+
+import (
+	"errors"
+	"log"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release/releasefakes"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreflightValidator", func() {
+	Describe("ValidatePreflight", func() {
+		var (
+			fakeLogger logger.Logger
+
+			pivnetClient *releasefakes.PreflightClient
+
+			mdata  metadata.Metadata
+			params concourse.OutParams
+			source concourse.Source
+
+			productSlug string
+			exactGlobs  []string
+
+			preflightValidator release.PreflightValidator
+		)
+
+		BeforeEach(func() {
+			logger := log.New(GinkgoWriter, "", log.LstdFlags)
+			fakeLogger = logshim.NewLogShim(logger, logger, true)
+
+			pivnetClient = &releasefakes.PreflightClient{}
+
+			productSlug = "some-product-slug"
+			exactGlobs = nil
+
+			mdata = metadata.Metadata{
+				Release: &metadata.Release{
+					Version:      "2.0.0",
+					EULASlug:     "some-eula",
+					ReleaseType:  "Major Release",
+					UserGroupIDs: []string{"8"},
+					UserGroups:   []string{"Some Partners"},
+				},
+				FileGroups: []metadata.FileGroup{
+					{
+						ID: 2345,
+						ProductFiles: []metadata.FileGroupProductFile{
+							{ID: 5432},
+							{Name: "uploaded-in-this-put"},
+						},
+					},
+					{Name: "new file group"},
+				},
+				ArtifactReferences: []metadata.ArtifactReference{
+					{ID: 4567},
+					{Name: "new artifact reference"},
+				},
+			}
+			params = concourse.OutParams{}
+			source = concourse.Source{
+				ProductVersion: []string{`2\..*`},
+				ReleaseType:    []string{"Major Release"},
+				SortBy:         concourse.SortBySemver,
+			}
+
+			pivnetClient.ReleasesForProductSlugReturns([]pivnet.Release{{Version: "1.0.0"}}, nil)
+			pivnetClient.EULAsReturns([]pivnet.EULA{{Slug: "some-eula"}}, nil)
+			pivnetClient.ReleaseTypesReturns([]pivnet.ReleaseType{"Major Release", "Minor Release"}, nil)
+			pivnetClient.ListUserGroupsReturns([]pivnet.UserGroup{{ID: 8, Name: "Some Partners"}}, nil)
+			pivnetClient.FileGroupsReturns([]pivnet.FileGroup{{ID: 2345}}, nil)
+			pivnetClient.ProductFilesReturns([]pivnet.ProductFile{{ID: 5432}}, nil)
+			pivnetClient.ArtifactReferencesReturns([]pivnet.ArtifactReference{{ID: 4567}}, nil)
+		})
+
+		JustBeforeEach(func() {
+			preflightValidator = release.NewPreflightValidator(
+				fakeLogger,
+				pivnetClient,
+				semver.NewSemverConverter(fakeLogger),
+				mdata,
+				params,
+				source,
+				productSlug,
+			)
+		})
+
+		It("checks everything the metadata refers to", func() {
+			err := preflightValidator.ValidatePreflight(exactGlobs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal(productSlug))
+			Expect(pivnetClient.EULAsCallCount()).To(Equal(1))
+			Expect(pivnetClient.ReleaseTypesCallCount()).To(Equal(1))
+			Expect(pivnetClient.ListUserGroupsCallCount()).To(Equal(1))
+			Expect(pivnetClient.FileGroupsArgsForCall(0)).To(Equal(productSlug))
+			Expect(pivnetClient.ProductFilesArgsForCall(0)).To(Equal(productSlug))
+			Expect(pivnetClient.ArtifactReferencesArgsForCall(0)).To(Equal(productSlug))
+		})
+
+		Context("when there are several problems", func() {
+			BeforeEach(func() {
+				mdata.Release.Version = "not-semver"
+				mdata.Release.EULASlug = "some-eul"
+				mdata.Release.ReleaseType = "Minor Release"
+				mdata.Release.UserGroupIDs = []string{"9", "abc"}
+				mdata.Release.UserGroups = []string{"Unknown Partners"}
+				mdata.FileGroups[0].ID = 1111
+				mdata.FileGroups[0].ProductFiles[0].ID = 2222
+				mdata.ArtifactReferences[0].ID = 3333
+			})
+
+			It("reports every problem together", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(HaveOccurred())
+
+				Expect(err.Error()).To(HavePrefix("invalid metadata:\n"))
+				Expect(err.Error()).To(ContainSubstring("release.version: 'not-semver' is not valid semver"))
+				Expect(err.Error()).To(ContainSubstring(`release.version: provided product version: 'not-semver' does not match any regex in source: ['2\..*']`))
+				Expect(err.Error()).To(ContainSubstring("release.eula_slug: provided EULA slug: 'some-eul' not found - did you mean one of: ['some-eula']"))
+				Expect(err.Error()).To(ContainSubstring("release.release_type: provided release type: 'Minor Release' must match one of ['Major Release'] from source configuration"))
+				Expect(err.Error()).To(ContainSubstring("release.user_group_ids[0]: user group with ID: 9 could not be found"))
+				Expect(err.Error()).To(ContainSubstring("release.user_group_ids[1]: 'abc' is not a valid ID"))
+				Expect(err.Error()).To(ContainSubstring("release.user_groups: provided user group: 'Unknown Partners' must be one of: ['Some Partners']"))
+				Expect(err.Error()).To(ContainSubstring("file_groups[0]: file group with ID: 1111 could not be found"))
+				Expect(err.Error()).To(ContainSubstring("file_groups[0].product_files[0]: product file with ID: 2222 could not be found"))
+				Expect(err.Error()).To(ContainSubstring("artifact_references[0]: artifact reference with ID: 3333 could not be found"))
+			})
+		})
+
+		Context("when the release type does not exist", func() {
+			BeforeEach(func() {
+				mdata.Release.ReleaseType = "Some Release"
+				source.ReleaseType = nil
+			})
+
+			It("returns an error", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("release.release_type: provided release type: 'Some Release' must be one of: ['Major Release', 'Minor Release']"))
+			})
+		})
+
+		Context("when the release already exists", func() {
+			BeforeEach(func() {
+				pivnetClient.ReleasesForProductSlugReturns([]pivnet.Release{{Version: "2.0.0"}}, nil)
+			})
+
+			It("returns an error", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("release.version: release 'some-product-slug' with version '2.0.0' already exists"))
+			})
+
+			Context("when override is true", func() {
+				BeforeEach(func() {
+					params.Override = true
+				})
+
+				It("leaves the existing release to the release creator", func() {
+					err := preflightValidator.ValidatePreflight(exactGlobs)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pivnetClient.ReleasesForProductSlugCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the source has several release types and product versions", func() {
+			BeforeEach(func() {
+				source.ReleaseType = []string{"Minor Release", "Major Release"}
+				source.ProductVersion = []string{`1\..*`, `2\..*`}
+			})
+
+			It("accepts a release matching any of them", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when semver is one of several sort keys", func() {
+			BeforeEach(func() {
+				source.SortBy = "release_date,semver"
+				mdata.Release.Version = "not-semver"
+				source.ProductVersion = nil
+			})
+
+			It("requires the version to be semver", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("release.version: 'not-semver' is not valid semver"))
+			})
+		})
+
+		Context("when a new file group refers to product files by name", func() {
+			BeforeEach(func() {
+				mdata.ProductFiles = []metadata.ProductFile{
+					{File: "files/other-file.zip", UploadAs: "Other File"},
+				}
+				mdata.FileGroups[1].ProductFiles = []metadata.FileGroupProductFile{
+					{Name: "some-file.tgz"},
+					{Name: "files/other-file.zip"},
+					{Name: "Existing File"},
+				}
+				exactGlobs = []string{"files/some-file.tgz", "files/other-file.zip"}
+
+				pivnetClient.ProductFilesReturns([]pivnet.ProductFile{
+					{ID: 5432},
+					{ID: 6543, Name: "Existing File", AWSObjectKey: "product-files/existing-file.tgz"},
+				}, nil)
+			})
+
+			It("resolves them against the files to be uploaded and the existing product files", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pivnetClient.ProductFilesCallCount()).To(Equal(1))
+			})
+
+			Context("when names do not resolve to exactly one product file", func() {
+				BeforeEach(func() {
+					mdata.FileGroups[1].ProductFiles = []metadata.FileGroupProductFile{
+						{Name: "missing-file.tgz"},
+						{Name: "Duplicate File"},
+						{Name: "some-file.tgz"},
+					}
+					exactGlobs = []string{"files/some-file.tgz", "more-files/some-file.tgz"}
+
+					pivnetClient.ProductFilesReturns([]pivnet.ProductFile{
+						{ID: 5432},
+						{ID: 6543, Name: "Duplicate File"},
+						{ID: 6544, Name: "Duplicate File"},
+					}, nil)
+				})
+
+				It("reports every name as a problem", func() {
+					err := preflightValidator.ValidatePreflight(exactGlobs)
+					Expect(err).To(HaveOccurred())
+
+					Expect(err.Error()).To(ContainSubstring("file_groups[1].product_files[0]: no product file found with name: 'missing-file.tgz'"))
+					Expect(err.Error()).To(ContainSubstring("file_groups[1].product_files[1]: product file name: 'Duplicate File' is ambiguous - matches product files with IDs: [6543 6544]"))
+					Expect(err.Error()).To(ContainSubstring("file_groups[1].product_files[2]: product file name: 'some-file.tgz' is ambiguous - matches several files to be uploaded"))
+				})
+			})
+
+			Context("when no files are uploaded", func() {
+				BeforeEach(func() {
+					exactGlobs = nil
+				})
+
+				It("reports names of files that would have been uploaded", func() {
+					err := preflightValidator.ValidatePreflight(exactGlobs)
+					Expect(err).To(HaveOccurred())
+
+					Expect(err.Error()).To(ContainSubstring("file_groups[1].product_files[0]: no product file found with name: 'some-file.tgz'"))
+					Expect(err.Error()).To(ContainSubstring("file_groups[1].product_files[1]: no product file found with name: 'files/other-file.zip'"))
+					Expect(err.Error()).NotTo(ContainSubstring("file_groups[1].product_files[2]"))
+				})
+			})
+		})
+
+		Context("when nothing is referred to by ID", func() {
+			BeforeEach(func() {
+				mdata.Release.UserGroupIDs = nil
+				mdata.Release.UserGroups = nil
+				mdata.FileGroups = []metadata.FileGroup{{Name: "new file group"}}
+				mdata.ArtifactReferences = nil
+			})
+
+			It("does not look up user groups, file groups, product files or artifact references", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pivnetClient.ListUserGroupsCallCount()).To(Equal(0))
+				Expect(pivnetClient.FileGroupsCallCount()).To(Equal(0))
+				Expect(pivnetClient.ProductFilesCallCount()).To(Equal(0))
+				Expect(pivnetClient.ArtifactReferencesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when adding files to an existing release", func() {
+			BeforeEach(func() {
+				mdata.Release = nil
+				mdata.ExistingRelease = &metadata.ExistingRelease{ID: 1234}
+				mdata.ArtifactReferences[0].ID = 3333
+			})
+
+			It("only checks the artifact references", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(MatchError("invalid metadata:\n  artifact_references[0]: artifact reference with ID: 3333 could not be found"))
+
+				Expect(pivnetClient.EULAsCallCount()).To(Equal(0))
+				Expect(pivnetClient.FileGroupsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a lookup fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("eulas error")
+				pivnetClient.EULAsReturns(nil, expectedErr)
+			})
+
+			It("returns the error", func() {
+				err := preflightValidator.ValidatePreflight(exactGlobs)
+				Expect(err).To(Equal(expectedErr))
+			})
+		})
+	})
+})
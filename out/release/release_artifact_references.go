@@ -2,6 +2,7 @@ package release
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	pivnet "github.com/pivotal-cf/go-pivnet/v7"
@@ -40,9 +41,12 @@ func NewReleaseArtifactReferencesAdder(
 type releaseArtifactReferencesAdderClient interface {
 	ArtifactReferences(productSlug string) ([]pivnet.ArtifactReference, error)
 	ArtifactReferencesForDigest(productSlug string, digest string) ([]pivnet.ArtifactReference, error)
+	ArtifactReferencesForRelease(productSlug string, releaseID int) ([]pivnet.ArtifactReference, error)
 	AddArtifactReference(productSlug string, releaseID int, artifactReferenceID int) error
 	CreateArtifactReference(config pivnet.CreateArtifactReferenceConfig) (pivnet.ArtifactReference, error)
 	GetArtifactReference(productSlug string, artifactReferenceID int) (pivnet.ArtifactReference, error)
+	UpdateArtifactReference(productSlug string, artifactReference pivnet.ArtifactReference) (pivnet.ArtifactReference, error)
+	RemoveArtifactReference(productSlug string, releaseID int, artifactReferenceID int) error
 	DeleteArtifactReference(productSlug string, artifactReferenceID int) (pivnet.ArtifactReference, error)
 }
 
@@ -63,9 +67,10 @@ func (rf ReleaseArtifactReferencesAdder) AddReleaseArtifactReferences(release pi
 				return err
 			}
 
-			if len(foundArtifactReferences) > 0 {
-				artifactReferenceID = foundArtifactReferences[0].ID
-			} else {
+			matchingArtifactReferences := matchArtifactReferences(foundArtifactReferences, artifactReference)
+
+			switch len(matchingArtifactReferences) {
+			case 0:
 				rf.logger.Info(fmt.Sprintf(
 					"Creating artifact reference with name: %s",
 					artifactReference.Name,
@@ -86,6 +91,33 @@ func (rf ReleaseArtifactReferencesAdder) AddReleaseArtifactReferences(release pi
 				}
 
 				artifactReferenceID = ir.ID
+			case 1:
+				existing := matchingArtifactReferences[0]
+				artifactReferenceID = existing.ID
+
+				rf.logger.Info(fmt.Sprintf(
+					"Reusing existing artifact reference with name: %s and ID: %d",
+					existing.Name,
+					existing.ID,
+				))
+
+				err = rf.updateArtifactReference(existing, artifactReference)
+				if err != nil {
+					return err
+				}
+			default:
+				ids := make([]int, len(matchingArtifactReferences))
+				for j, ref := range matchingArtifactReferences {
+					ids[j] = ref.ID
+				}
+
+				return fmt.Errorf(
+					"found multiple artifact references with name: %s, artifact path: %s and digest: %s (IDs: %v) - provide the id of the one to use",
+					artifactReference.Name,
+					artifactReference.ArtifactPath,
+					artifactReference.Digest,
+					ids,
+				)
 			}
 			rf.metadata.ArtifactReferences[i].ID = artifactReferenceID
 		}
@@ -125,8 +157,32 @@ func (rf ReleaseArtifactReferencesAdder) AddReleaseArtifactReferences(release pi
 	}
 
 	// add references to release
+	var releaseArtifactReferences []pivnet.ArtifactReference
+	if rf.metadata.ExistingRelease != nil {
+		var err error
+		releaseArtifactReferences, err = rf.pivnet.ArtifactReferencesForRelease(rf.productSlug, release.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	attached := make(map[int]bool, len(releaseArtifactReferences))
+	for _, ref := range releaseArtifactReferences {
+		attached[ref.ID] = true
+	}
+
+	listed := make(map[int]bool, len(rf.metadata.ArtifactReferences))
 	for _, artifactReference := range rf.metadata.ArtifactReferences {
 		var artifactReferenceID = artifactReference.ID
+		listed[artifactReferenceID] = true
+
+		if attached[artifactReferenceID] {
+			rf.logger.Info(fmt.Sprintf(
+				"Artifact reference with ID: %d is already part of the release",
+				artifactReferenceID,
+			))
+			continue
+		}
 
 		rf.logger.Info(fmt.Sprintf(
 			"Adding artifact reference with ID: %d",
@@ -138,5 +194,89 @@ func (rf ReleaseArtifactReferencesAdder) AddReleaseArtifactReferences(release pi
 		}
 	}
 
+	// remove references no longer listed from an existing release
+	if rf.metadata.ExistingRelease != nil && rf.metadata.ExistingRelease.PruneArtifactReferences {
+		for _, ref := range releaseArtifactReferences {
+			if listed[ref.ID] {
+				continue
+			}
+
+			rf.logger.Info(fmt.Sprintf(
+				"Removing artifact reference with name: %s and ID: %d",
+				ref.Name,
+				ref.ID,
+			))
+			err := rf.pivnet.RemoveArtifactReference(rf.productSlug, release.ID, ref.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
+
+// updateArtifactReference updates the description, docs URL and system
+// requirements of an existing artifact reference where the metadata provides
+// a different value.
+func (rf ReleaseArtifactReferencesAdder) updateArtifactReference(
+	existing pivnet.ArtifactReference,
+	artifactReference metadata.ArtifactReference,
+) error {
+	updated := existing
+	changed := false
+
+	if artifactReference.Description != "" && artifactReference.Description != existing.Description {
+		updated.Description = artifactReference.Description
+		changed = true
+	}
+
+	if artifactReference.DocsURL != "" && artifactReference.DocsURL != existing.DocsURL {
+		updated.DocsURL = artifactReference.DocsURL
+		changed = true
+	}
+
+	if len(artifactReference.SystemRequirements) > 0 &&
+		!reflect.DeepEqual(artifactReference.SystemRequirements, existing.SystemRequirements) {
+		updated.SystemRequirements = artifactReference.SystemRequirements
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	rf.logger.Info(fmt.Sprintf(
+		"Updating artifact reference with ID: %d",
+		existing.ID,
+	))
+
+	_, err := rf.pivnet.UpdateArtifactReference(rf.productSlug, updated)
+	return err
+}
+
+func matchArtifactReferences(
+	artifactReferences []pivnet.ArtifactReference,
+	artifactReference metadata.ArtifactReference,
+) []pivnet.ArtifactReference {
+	wanted := artifactReferenceKey{
+		Name:         artifactReference.Name,
+		ArtifactPath: artifactReference.ArtifactPath,
+		Digest:       artifactReference.Digest,
+	}
+
+	var matches []pivnet.ArtifactReference
+	for _, ref := range artifactReferences {
+		key := artifactReferenceKey{
+			Name:         ref.Name,
+			ArtifactPath: ref.ArtifactPath,
+			Digest:       ref.Digest,
+		}
+
+		if key == wanted {
+			matches = append(matches, ref)
+		}
+	}
+
+	return matches
+}
@@ -182,6 +182,106 @@ var _ = Describe("ReleaseArtifactReferencesAdder", func() {
 						Expect(artifactReferenceID).To(Equal(1234))
 					})
 				})
+				Context("when an existing artifact reference has the same digest but a different name or path", func() {
+					BeforeEach(func() {
+						pivnetClient.ArtifactReferencesForDigestReturns([]pivnet.ArtifactReference{
+							{
+								ID:           4321,
+								Name:         "another-name",
+								ArtifactPath: "my/path:123",
+							},
+							{
+								ID:           5432,
+								Name:         "my-difficult-artifact",
+								ArtifactPath: "another/path:456",
+							},
+						}, nil)
+					})
+
+					It("creates a new artifact reference", func() {
+						err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pivnetClient.CreateArtifactReferenceCallCount()).To(Equal(1))
+						Expect(pivnetClient.UpdateArtifactReferenceCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the existing artifact reference has different details", func() {
+					BeforeEach(func() {
+						mdata.ArtifactReferences[0] = metadata.ArtifactReference{
+							Name:               "my-difficult-artifact",
+							ArtifactPath:       "my/path:123",
+							Digest:             "sha256:difficultdigest",
+							Description:        "a new description",
+							SystemRequirements: []string{"req1"},
+						}
+
+						pivnetClient.ArtifactReferencesForDigestReturns([]pivnet.ArtifactReference{
+							{
+								ID:                 9876,
+								Name:               "my-difficult-artifact",
+								ArtifactPath:       "my/path:123",
+								Digest:             "sha256:difficultdigest",
+								Description:        "an old description",
+								DocsURL:            "some-docs-url",
+								SystemRequirements: []string{"req1"},
+							},
+						}, nil)
+					})
+
+					It("updates the changed fields of the existing artifact reference", func() {
+						err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pivnetClient.CreateArtifactReferenceCallCount()).To(Equal(0))
+						Expect(pivnetClient.UpdateArtifactReferenceCallCount()).To(Equal(1))
+
+						slug, updated := pivnetClient.UpdateArtifactReferenceArgsForCall(0)
+						Expect(slug).To(Equal(productSlug))
+						Expect(updated).To(Equal(pivnet.ArtifactReference{
+							ID:                 9876,
+							Name:               "my-difficult-artifact",
+							ArtifactPath:       "my/path:123",
+							Digest:             "sha256:difficultdigest",
+							Description:        "a new description",
+							DocsURL:            "some-docs-url",
+							SystemRequirements: []string{"req1"},
+						}))
+					})
+
+					Context("when updating the artifact reference returns an error", func() {
+						BeforeEach(func() {
+							pivnetClient.UpdateArtifactReferenceReturns(pivnet.ArtifactReference{}, fmt.Errorf("some update error"))
+						})
+
+						It("forwards the error", func() {
+							err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+							Expect(err).To(MatchError("some update error"))
+						})
+					})
+				})
+
+				Context("when several existing artifact references match", func() {
+					BeforeEach(func() {
+						mdata.ArtifactReferences[0].ArtifactPath = "my/path:123"
+						mdata.ArtifactReferences[0].Digest = "sha256:difficultdigest"
+
+						pivnetClient.ArtifactReferencesForDigestReturns([]pivnet.ArtifactReference{
+							{ID: 11, Name: "my-difficult-artifact", ArtifactPath: "my/path:123", Digest: "sha256:difficultdigest"},
+							{ID: 22, Name: "my-difficult-artifact", ArtifactPath: "my/path:123", Digest: "sha256:difficultdigest"},
+						}, nil)
+					})
+
+					It("returns an error", func() {
+						err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+						Expect(err).To(MatchError("found multiple artifact references with name: my-difficult-artifact, artifact path: my/path:123 and digest: sha256:difficultdigest (IDs: [11 22]) - provide the id of the one to use"))
+
+						Expect(pivnetClient.CreateArtifactReferenceCallCount()).To(Equal(0))
+						Expect(pivnetClient.AddArtifactReferenceCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when creating the artifact reference returns an error", func() {
 					var (
 						expectedErr error
@@ -284,6 +384,74 @@ var _ = Describe("ReleaseArtifactReferencesAdder", func() {
 					})
 				})
 			})
+
+			Context("when adding to an existing release", func() {
+				BeforeEach(func() {
+					mdata.Release = nil
+					mdata.ExistingRelease = &metadata.ExistingRelease{ID: pivnetRelease.ID}
+
+					pivnetClient.ArtifactReferencesForReleaseReturns([]pivnet.ArtifactReference{
+						{ID: 9876, Name: "my-difficult-artifact"},
+						{ID: 5555, Name: "an-old-artifact"},
+					}, nil)
+				})
+
+				It("only adds artifact references that are not already part of the release", func() {
+					err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+					Expect(err).NotTo(HaveOccurred())
+
+					slug, releaseID := pivnetClient.ArtifactReferencesForReleaseArgsForCall(0)
+					Expect(slug).To(Equal(productSlug))
+					Expect(releaseID).To(Equal(pivnetRelease.ID))
+
+					Expect(pivnetClient.AddArtifactReferenceCallCount()).To(Equal(1))
+					_, _, artifactReferenceID := pivnetClient.AddArtifactReferenceArgsForCall(0)
+					Expect(artifactReferenceID).To(Equal(1234))
+
+					Expect(pivnetClient.RemoveArtifactReferenceCallCount()).To(Equal(0))
+				})
+
+				Context("when pruning artifact references", func() {
+					BeforeEach(func() {
+						mdata.ExistingRelease.PruneArtifactReferences = true
+					})
+
+					It("removes artifact references that are no longer listed from the release", func() {
+						err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pivnetClient.RemoveArtifactReferenceCallCount()).To(Equal(1))
+						slug, releaseID, artifactReferenceID := pivnetClient.RemoveArtifactReferenceArgsForCall(0)
+						Expect(slug).To(Equal(productSlug))
+						Expect(releaseID).To(Equal(pivnetRelease.ID))
+						Expect(artifactReferenceID).To(Equal(5555))
+
+						Expect(pivnetClient.DeleteArtifactReferenceCallCount()).To(Equal(0))
+					})
+
+					Context("when removing an artifact reference returns an error", func() {
+						BeforeEach(func() {
+							pivnetClient.RemoveArtifactReferenceReturns(fmt.Errorf("some remove error"))
+						})
+
+						It("forwards the error", func() {
+							err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+							Expect(err).To(MatchError("some remove error"))
+						})
+					})
+				})
+
+				Context("when listing the artifact references of the release returns an error", func() {
+					BeforeEach(func() {
+						pivnetClient.ArtifactReferencesForReleaseReturns(nil, fmt.Errorf("some list error"))
+					})
+
+					It("forwards the error", func() {
+						err := releaseArtifactReferencesAdder.AddReleaseArtifactReferences(pivnetRelease)
+						Expect(err).To(MatchError("some list error"))
+					})
+				})
+			})
 		})
 	})
 })
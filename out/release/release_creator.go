@@ -2,10 +2,7 @@ package release
 
 import (
 	"fmt"
-	"regexp"
-	"strings"
 
-	"github.com/blang/semver"
 	pivnet "github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
@@ -13,33 +10,24 @@ import (
 )
 
 type ReleaseCreator struct {
-	pivnet          releaseClient
-	semverConverter semverConverter
-	logger          logger.Logger
-	metadata        metadata.Metadata
-	sourcesDir      string
-	productSlug     string
-	params          concourse.OutParams
-	source          concourse.Source
+	pivnet      releaseClient
+	logger      logger.Logger
+	metadata    metadata.Metadata
+	sourcesDir  string
+	productSlug string
+	params      concourse.OutParams
+	source      concourse.Source
 }
 
 //counterfeiter:generate --fake-name ReleaseClient . releaseClient
 type releaseClient interface {
-	EULAs() ([]pivnet.EULA, error)
-	ReleaseTypes() ([]pivnet.ReleaseType, error)
 	ReleasesForProductSlug(string) ([]pivnet.Release, error)
 	CreateRelease(pivnet.CreateReleaseConfig) (pivnet.Release, error)
 	DeleteRelease(productSlug string, release pivnet.Release) error
 }
 
-//counterfeiter:generate --fake-name FakeSemverConverter . semverConverter
-type semverConverter interface {
-	ToValidSemver(string) (semver.Version, error)
-}
-
 func NewReleaseCreator(
 	pivnet releaseClient,
-	semverConverter semverConverter,
 	logger logger.Logger,
 	metadata metadata.Metadata,
 	params concourse.OutParams,
@@ -48,131 +36,30 @@ func NewReleaseCreator(
 	productSlug string,
 ) ReleaseCreator {
 	return ReleaseCreator{
-		pivnet:          pivnet,
-		semverConverter: semverConverter,
-		logger:          logger,
-		metadata:        metadata,
-		sourcesDir:      sourcesDir,
-		params:          params,
-		source:          source,
-		productSlug:     productSlug,
+		pivnet:      pivnet,
+		logger:      logger,
+		metadata:    metadata,
+		sourcesDir:  sourcesDir,
+		params:      params,
+		source:      source,
+		productSlug: productSlug,
 	}
 }
 
+// Create creates the release, deleting an existing release with the same
+// version if params.override is set. The metadata has already been checked
+// against Pivnet by the PreflightValidator, so it is not checked again.
 func (rc ReleaseCreator) Create() (pivnet.Release, error) {
 	version := rc.metadata.Release.Version
 
-	if rc.source.SortBy == concourse.SortBySemver {
-		v, err := rc.semverConverter.ToValidSemver(version)
+	if rc.params.Override {
+		releases, err := rc.pivnet.ReleasesForProductSlug(rc.productSlug)
 		if err != nil {
 			return pivnet.Release{}, err
 		}
-		rc.logger.Info(fmt.Sprintf("Successfully parsed semver as: '%s'", v.String()))
-	}
-
-	if rc.source.ProductVersion != "" {
-		rc.logger.Info(fmt.Sprintf(
-			"Validating product version: '%s' against regex: '%s'",
-			version,
-			rc.source.ProductVersion,
-		))
-
-		match, err := regexp.MatchString(rc.source.ProductVersion, version)
-		if err != nil {
-			return pivnet.Release{}, err
-		}
-
-		if !match {
-			return pivnet.Release{}, fmt.Errorf(
-				"provided product version: '%s' does not match regex in source: '%s'",
-				version,
-				rc.source.ProductVersion,
-			)
-		}
-	}
-
-	eulaSlug := rc.metadata.Release.EULASlug
-
-	rc.logger.Info(fmt.Sprintf("Validating EULA: '%s'", eulaSlug))
-
-	eulas, err := rc.pivnet.EULAs()
-	if err != nil {
-		return pivnet.Release{}, err
-	}
-
-	eulaSlugs := make([]string, len(eulas))
-	for i, e := range eulas {
-		eulaSlugs[i] = e.Slug
-	}
-
-	var containsSlug bool
-	for _, slug := range eulaSlugs {
-		if eulaSlug == slug {
-			containsSlug = true
-			break
-		}
-	}
-
-	if !containsSlug {
-		eulaSlugsPrintable := fmt.Sprintf("['%s']", strings.Join(eulaSlugs, "', '"))
-		return pivnet.Release{}, fmt.Errorf(
-			"provided EULA slug: '%s' must be one of: %s",
-			eulaSlug,
-			eulaSlugsPrintable,
-		)
-	}
-
-	releaseType := pivnet.ReleaseType(rc.metadata.Release.ReleaseType)
-
-	rc.logger.Info(fmt.Sprintf("Validating release type: '%s'", releaseType))
-
-	releaseTypes, err := rc.pivnet.ReleaseTypes()
-	if err != nil {
-		return pivnet.Release{}, err
-	}
-
-	releaseTypesAsStrings := make([]string, len(releaseTypes))
-	for i, r := range releaseTypes {
-		releaseTypesAsStrings[i] = string(r)
-	}
-
-	var containsReleaseType bool
-	for _, t := range releaseTypes {
-		if releaseType == t {
-			containsReleaseType = true
-			break
-		}
-	}
-
-	if !containsReleaseType {
-		releaseTypesPrintable := fmt.Sprintf(
-			"['%s']",
-			strings.Join(releaseTypesAsStrings, "', '"),
-		)
-		return pivnet.Release{}, fmt.Errorf(
-			"provided release type: '%s' must be one of: %s",
-			releaseType,
-			releaseTypesPrintable,
-		)
-	}
-
-	if pivnet.ReleaseType(rc.source.ReleaseType) != "" &&
-		pivnet.ReleaseType(rc.source.ReleaseType) != releaseType {
-		return pivnet.Release{}, fmt.Errorf(
-			"provided release type: '%s' must match '%s' from source configuration",
-			releaseType,
-			rc.source.ReleaseType,
-		)
-	}
-
-	releases, err := rc.pivnet.ReleasesForProductSlug(rc.productSlug)
-	if err != nil {
-		return pivnet.Release{}, err
-	}
 
-	for _, r := range releases {
-		if r.Version == version {
-			if rc.params.Override {
+		for _, r := range releases {
+			if r.Version == version {
 				rc.logger.Info(fmt.Sprintf(
 					"Deleting existing release: '%s' - id: '%d'",
 					r.Version,
@@ -183,20 +70,14 @@ func (rc ReleaseCreator) Create() (pivnet.Release, error) {
 				if err != nil {
 					return pivnet.Release{}, err
 				}
-			} else {
-				return pivnet.Release{}, fmt.Errorf(
-					"Release '%s' with version '%s' already exists.",
-					rc.productSlug,
-					version,
-				)
 			}
 		}
 	}
 
 	config := pivnet.CreateReleaseConfig{
 		ProductSlug:           rc.productSlug,
-		ReleaseType:           string(releaseType),
-		EULASlug:              eulaSlug,
+		ReleaseType:           rc.metadata.Release.ReleaseType,
+		EULASlug:              rc.metadata.Release.EULASlug,
 		Version:               version,
 		Description:           rc.metadata.Release.Description,
 		ReleaseNotesURL:       rc.metadata.Release.ReleaseNotesURL,
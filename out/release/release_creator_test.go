@@ -2,10 +2,8 @@ package release_test
 
 import (
 	"errors"
-	"fmt"
 	"log"
 
-	"github.com/blang/semver"
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
 	"github.com/pivotal-cf/go-pivnet/v7/logshim"
@@ -22,21 +20,17 @@ var _ = Describe("ReleaseCreator", func() {
 	var (
 		fakeLogger logger.Logger
 
-		pivnetClient        *releasefakes.ReleaseClient
-		fakeSemverConverter *releasefakes.FakeSemverConverter
+		pivnetClient *releasefakes.ReleaseClient
 
 		creator release.ReleaseCreator
 
-		sourceReleaseType string
-		sourceVersion     string
-		sortBy            concourse.SortBy
-		copyMetadata      bool
-		releaseVersion    string
-		existingReleases  []pivnet.Release
-		eulaSlug          string
-		productSlug       string
-		releaseType       pivnet.ReleaseType
-		params            concourse.OutParams
+		copyMetadata     bool
+		releaseVersion   string
+		existingReleases []pivnet.Release
+		eulaSlug         string
+		productSlug      string
+		releaseType      pivnet.ReleaseType
+		params           concourse.OutParams
 	)
 
 	BeforeEach(func() {
@@ -44,9 +38,8 @@ var _ = Describe("ReleaseCreator", func() {
 		fakeLogger = logshim.NewLogShim(logger, logger, true)
 
 		pivnetClient = &releasefakes.ReleaseClient{}
-		fakeSemverConverter = &releasefakes.FakeSemverConverter{}
 
-		sortBy = concourse.SortByNone
+		copyMetadata = false
 
 		existingReleases = []pivnet.Release{
 			{
@@ -60,11 +53,6 @@ var _ = Describe("ReleaseCreator", func() {
 		eulaSlug = "magic-slug"
 		releaseType = "some-release-type"
 
-		sourceReleaseType = string(releaseType)
-		sourceVersion = `1\.8\..*`
-
-		pivnetClient.EULAsReturns([]pivnet.EULA{{Slug: eulaSlug}}, nil)
-		pivnetClient.ReleaseTypesReturns([]pivnet.ReleaseType{releaseType}, nil)
 		pivnetClient.ReleasesForProductSlugReturns(existingReleases, nil)
 		pivnetClient.CreateReleaseReturns(pivnet.Release{ID: 1337}, nil)
 	})
@@ -95,15 +83,11 @@ var _ = Describe("ReleaseCreator", func() {
 			}
 
 			source := concourse.Source{
-				ReleaseType:    sourceReleaseType,
-				ProductVersion: sourceVersion,
-				SortBy:         sortBy,
-				CopyMetadata:   copyMetadata,
+				CopyMetadata: copyMetadata,
 			}
 
 			creator = release.NewReleaseCreator(
 				pivnetClient,
-				fakeSemverConverter,
 				fakeLogger,
 				meta,
 				params,
@@ -113,15 +97,13 @@ var _ = Describe("ReleaseCreator", func() {
 			)
 		})
 
-		It("constructs the release", func() {
+		It("constructs the release without checking the metadata against Pivnet again", func() {
 			r, err := creator.Create()
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(r).To(Equal(pivnet.Release{ID: 1337}))
 
-			Expect(pivnetClient.EULAsCallCount()).To(Equal(1))
-
-			Expect(pivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal(productSlug))
+			Expect(pivnetClient.ReleasesForProductSlugCallCount()).To(BeZero())
 
 			Expect(pivnetClient.CreateReleaseArgsForCall(0)).To(Equal(pivnet.CreateReleaseConfig{
 				ProductSlug:     productSlug,
@@ -136,82 +118,33 @@ var _ = Describe("ReleaseCreator", func() {
 			}))
 		})
 
-		Context("when an error occurs", func() {
-			Context("when pivnet fails getting releases for a product slug", func() {
-				BeforeEach(func() {
-					pivnetClient.ReleasesForProductSlugReturns([]pivnet.Release{}, errors.New("product slug error"))
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("product slug error")))
-				})
-			})
-
-			Context("when pivnet fails fetching eulas", func() {
-				BeforeEach(func() {
-					pivnetClient.EULAsReturns([]pivnet.EULA{}, errors.New("failed getting eulas"))
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("failed getting eulas")))
-				})
-			})
-
-			Context("when the metadata does not contain the eula slug", func() {
-				BeforeEach(func() {
-					pivnetClient.EULAsReturns([]pivnet.EULA{{Slug: "a-failing-slug"}}, nil)
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("provided EULA slug: 'magic-slug' must be one of: ['a-failing-slug']")))
-				})
+		Context("when the release cannot be created", func() {
+			BeforeEach(func() {
+				pivnetClient.CreateReleaseReturns(pivnet.Release{}, errors.New("cannot create release"))
 			})
 
-			Context("when pivnet fails fetching release types", func() {
-				BeforeEach(func() {
-					pivnetClient.ReleaseTypesReturns([]pivnet.ReleaseType{}, errors.New("failed fetching release types"))
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("failed fetching release types")))
-				})
+			It("returns an error", func() {
+				_, err := creator.Create()
+				Expect(err).To(MatchError(errors.New("cannot create release")))
 			})
+		})
 
-			Context("when the metadata does not contain the release type", func() {
-				BeforeEach(func() {
-					pivnetClient.ReleaseTypesReturns([]pivnet.ReleaseType{pivnet.ReleaseType("a-missing-release-type")}, nil)
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("provided release type: 'some-release-type' must be one of: ['a-missing-release-type']")))
-				})
+		Context("when the Override parameter is set", func() {
+			BeforeEach(func() {
+				params.Override = true
 			})
 
-			Context("when the release cannot be created", func() {
-				BeforeEach(func() {
-					pivnetClient.CreateReleaseReturns(pivnet.Release{}, errors.New("cannot create release"))
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(errors.New("cannot create release")))
-				})
-			})
-		})
+			It("does not delete other releases", func() {
+				_, err := creator.Create()
+				Expect(err).NotTo(HaveOccurred())
 
-		Context("when the release already exists", func() {
-			BeforeEach(func() {
-				releaseVersion = existingReleases[0].Version
+				Expect(pivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal(productSlug))
+				Expect(pivnetClient.DeleteReleaseCallCount()).To(BeZero())
 			})
 
-			Context("when the Override parameter is set", func() {
+			Context("when the release already exists", func() {
 				BeforeEach(func() {
-					params.Override = true
+					releaseVersion = existingReleases[0].Version
 				})
 
 				It("deletes the release", func() {
@@ -244,36 +177,14 @@ var _ = Describe("ReleaseCreator", func() {
 				})
 			})
 
-			Context("when the Override parameter is turned off", func() {
-				BeforeEach(func() {
-					params.Override = false
-				})
-
-				It("returns an error", func() {
-					_, err := creator.Create()
-					Expect(err).To(MatchError(fmt.Errorf("Release '%s' with version '%s' already exists.", productSlug, releaseVersion)))
-				})
-			})
-		})
-
-		Context("when sorting by semver", func() {
-			BeforeEach(func() {
-				sortBy = concourse.SortBySemver
-			})
-
-			Context("when release is not valid semver", func() {
-				var (
-					expectedErr error
-				)
-
+			Context("when pivnet fails getting releases for a product slug", func() {
 				BeforeEach(func() {
-					expectedErr = fmt.Errorf("semver parse error")
-					fakeSemverConverter.ToValidSemverReturns(semver.Version{}, expectedErr)
+					pivnetClient.ReleasesForProductSlugReturns([]pivnet.Release{}, errors.New("product slug error"))
 				})
 
 				It("returns an error", func() {
 					_, err := creator.Create()
-					Expect(err).To(Equal(expectedErr))
+					Expect(err).To(MatchError(errors.New("product slug error")))
 				})
 			})
 		})
@@ -289,10 +200,6 @@ var _ = Describe("ReleaseCreator", func() {
 
 				Expect(r).To(Equal(pivnet.Release{ID: 1337}))
 
-				Expect(pivnetClient.EULAsCallCount()).To(Equal(1))
-
-				Expect(pivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal(productSlug))
-
 				Expect(pivnetClient.CreateReleaseArgsForCall(0)).To(Equal(pivnet.CreateReleaseConfig{
 					ProductSlug:     productSlug,
 					ReleaseType:     string(releaseType),
@@ -306,42 +213,5 @@ var _ = Describe("ReleaseCreator", func() {
 				}))
 			})
 		})
-
-		Context("when release type does not match source config", func() {
-			BeforeEach(func() {
-				sourceReleaseType = "different release type"
-				pivnetClient.ReleaseTypesReturns(
-					[]pivnet.ReleaseType{releaseType, pivnet.ReleaseType(sourceReleaseType)},
-					nil,
-				)
-			})
-
-			It("returns an error", func() {
-				_, err := creator.Create()
-				Expect(err).To(HaveOccurred())
-			})
-		})
-
-		Context("when source regex is invalid", func() {
-			BeforeEach(func() {
-				sourceVersion = `1\.[`
-			})
-
-			It("returns an error", func() {
-				_, err := creator.Create()
-				Expect(err).To(HaveOccurred())
-			})
-		})
-
-		Context("when release version does not match source regex", func() {
-			BeforeEach(func() {
-				sourceVersion = `1\.7\..*`
-			})
-
-			It("returns an error", func() {
-				_, err := creator.Create()
-				Expect(err).To(HaveOccurred())
-			})
-		})
 	})
 })
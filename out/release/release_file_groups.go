@@ -2,6 +2,8 @@ package release
 
 import (
 	"fmt"
+	"path"
+	"path/filepath"
 
 	pivnet "github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
@@ -33,41 +35,89 @@ func NewReleaseFileGroupsAdder(
 type releaseFileGroupsAdderClient interface {
 	AddFileGroup(productSlug string, releaseID int, fileGroupID int) error
 	CreateFileGroup(config pivnet.CreateFileGroupConfig) (pivnet.FileGroup, error)
+	FileGroups(productSlug string) ([]pivnet.FileGroup, error)
 	AddToFileGroup(productSlug string, fileGroupID int, productFileID int) error
+	RemoveFromFileGroup(productSlug string, fileGroupID int, productFileID int) error
+	ProductFiles(productSlug string) ([]pivnet.ProductFile, error)
+	ProductFilesForRelease(productSlug string, releaseID int) ([]pivnet.ProductFile, error)
 }
 
 func (rf ReleaseFileGroupsAdder) AddReleaseFileGroups(release pivnet.Release) error {
+	var existingFileGroups []pivnet.FileGroup
 	for _, fileGroup := range rf.metadata.FileGroups {
-		fileGroupID := fileGroup.ID
-		if fileGroupID == 0 {
-			rf.logger.Info(fmt.Sprintf(
-				"Creating file group with name: %s",
-				fileGroup.Name,
-			))
+		if fileGroup.ID == 0 {
+			rf.logger.Info("Getting existing file groups")
 
-			g, err := rf.pivnet.CreateFileGroup(pivnet.CreateFileGroupConfig{
-				ProductSlug: rf.productSlug,
-				Name:        fileGroup.Name,
-			})
+			var err error
+			existingFileGroups, err = rf.pivnet.FileGroups(rf.productSlug)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	productFileResolver := newProductFileResolver(rf.pivnet, rf.metadata, rf.productSlug, release.ID)
 
+	for _, fileGroup := range rf.metadata.FileGroups {
+		fileGroupID := fileGroup.ID
+		if fileGroupID == 0 {
+			productFileIDs, err := productFileResolver.resolve(fileGroup.ProductFiles)
 			if err != nil {
 				return err
 			}
 
-			fileGroupID = g.ID
+			existingFileGroup, found := fileGroupByName(existingFileGroups, fileGroup.Name)
+			if found {
+				rf.logger.Info(fmt.Sprintf(
+					"Reusing existing file group with name: %s and ID: %d",
+					existingFileGroup.Name,
+					existingFileGroup.ID,
+				))
 
-			for _, pf := range fileGroup.ProductFiles {
+				fileGroupID = existingFileGroup.ID
+
+				if len(productFileIDs) > 0 {
+					err = rf.syncFileGroupMembership(existingFileGroup, productFileIDs)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
 				rf.logger.Info(fmt.Sprintf(
-					"Adding product file %d to file group with ID: %d",
-					pf.ID,
-					fileGroupID,
+					"Creating file group with name: %s",
+					fileGroup.Name,
 				))
 
-				err := rf.pivnet.AddToFileGroup(rf.productSlug, fileGroupID, pf.ID)
+				g, err := rf.pivnet.CreateFileGroup(pivnet.CreateFileGroupConfig{
+					ProductSlug: rf.productSlug,
+					Name:        fileGroup.Name,
+				})
 
 				if err != nil {
 					return err
 				}
+
+				fileGroupID = g.ID
+
+				for _, productFileID := range productFileIDs {
+					rf.logger.Info(fmt.Sprintf(
+						"Adding product file %d to file group with ID: %d",
+						productFileID,
+						fileGroupID,
+					))
+
+					err := rf.pivnet.AddToFileGroup(rf.productSlug, fileGroupID, productFileID)
+
+					if err != nil {
+						return err
+					}
+				}
+
+				existingFileGroups = append(existingFileGroups, pivnet.FileGroup{
+					ID:   fileGroupID,
+					Name: fileGroup.Name,
+				})
 			}
 		}
 
@@ -83,3 +133,238 @@ func (rf ReleaseFileGroupsAdder) AddReleaseFileGroups(release pivnet.Release) er
 
 	return nil
 }
+
+// syncFileGroupMembership adds and removes product files so that the file
+// group contains exactly the provided product files.
+func (rf ReleaseFileGroupsAdder) syncFileGroupMembership(fileGroup pivnet.FileGroup, productFileIDs []int) error {
+	wanted := make(map[int]bool, len(productFileIDs))
+	for _, id := range productFileIDs {
+		wanted[id] = true
+	}
+
+	existing := make(map[int]bool, len(fileGroup.ProductFiles))
+	for _, pf := range fileGroup.ProductFiles {
+		existing[pf.ID] = true
+
+		if !wanted[pf.ID] {
+			rf.logger.Info(fmt.Sprintf(
+				"Removing product file %d from file group with ID: %d",
+				pf.ID,
+				fileGroup.ID,
+			))
+
+			err := rf.pivnet.RemoveFromFileGroup(rf.productSlug, fileGroup.ID, pf.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, id := range productFileIDs {
+		if existing[id] {
+			continue
+		}
+
+		rf.logger.Info(fmt.Sprintf(
+			"Adding product file %d to file group with ID: %d",
+			id,
+			fileGroup.ID,
+		))
+
+		err := rf.pivnet.AddToFileGroup(rf.productSlug, fileGroup.ID, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func fileGroupByName(fileGroups []pivnet.FileGroup, name string) (pivnet.FileGroup, bool) {
+	var found pivnet.FileGroup
+	for _, g := range fileGroups {
+		if g.Name != name {
+			continue
+		}
+
+		// Prefer the oldest group when duplicates already exist
+		if found.ID == 0 || g.ID < found.ID {
+			found = g
+		}
+	}
+
+	return found, found.ID != 0
+}
+
+type productFilesClient interface {
+	ProductFiles(productSlug string) ([]pivnet.ProductFile, error)
+}
+
+type releaseProductFilesClient interface {
+	productFilesClient
+	ProductFilesForRelease(productSlug string, releaseID int) ([]pivnet.ProductFile, error)
+}
+
+// productFileResolver looks up product files referenced from metadata by ID
+// or by name. Product files of the release are preferred over other product
+// files of the product, so that files uploaded in the same put can be
+// referred to unambiguously.
+type productFileResolver struct {
+	pivnet              productFilesClient
+	metadata            metadata.Metadata
+	productSlug         string
+	listReleaseProducts func() ([]pivnet.ProductFile, error)
+
+	releaseProductFiles []pivnet.ProductFile
+	releaseListed       bool
+	productFiles        []pivnet.ProductFile
+	productFilesListed  bool
+}
+
+func newProductFileResolver(
+	pivnetClient releaseProductFilesClient,
+	metadata metadata.Metadata,
+	productSlug string,
+	releaseID int,
+) *productFileResolver {
+	return &productFileResolver{
+		pivnet:      pivnetClient,
+		metadata:    metadata,
+		productSlug: productSlug,
+		listReleaseProducts: func() ([]pivnet.ProductFile, error) {
+			return pivnetClient.ProductFilesForRelease(productSlug, releaseID)
+		},
+	}
+}
+
+// newPlannedProductFileResolver returns a resolver for a release that has not
+// been created yet, whose product files will be the files uploaded from
+// exactGlobs. They are named as the release uploader names them, so that
+// names resolve as they will once the files are uploaded. The other product
+// files of the product have already been listed, so it makes no requests.
+func newPlannedProductFileResolver(
+	metadata metadata.Metadata,
+	exactGlobs []string,
+	productFiles []pivnet.ProductFile,
+) *productFileResolver {
+	planned := make([]pivnet.ProductFile, len(exactGlobs))
+	for i, exactGlob := range exactGlobs {
+		name := filepath.Base(exactGlob)
+		for _, f := range metadata.ProductFiles {
+			if f.File == exactGlob && f.UploadAs != "" {
+				name = f.UploadAs
+			}
+		}
+
+		planned[i] = pivnet.ProductFile{
+			Name:         name,
+			AWSObjectKey: filepath.Base(exactGlob),
+		}
+	}
+
+	return &productFileResolver{
+		metadata:            metadata,
+		releaseProductFiles: planned,
+		releaseListed:       true,
+		productFiles:        productFiles,
+		productFilesListed:  true,
+	}
+}
+
+func (r *productFileResolver) resolve(refs []metadata.FileGroupProductFile) ([]int, error) {
+	ids := make([]int, len(refs))
+	for i, ref := range refs {
+		id, err := r.resolveRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+func (r *productFileResolver) resolveRef(ref metadata.FileGroupProductFile) (int, error) {
+	if ref.ID != 0 {
+		return ref.ID, nil
+	}
+
+	if ref.Name == "" {
+		return 0, fmt.Errorf("product file reference must have an id or a name")
+	}
+
+	return r.resolveName(ref.Name)
+}
+
+func (r *productFileResolver) resolveName(name string) (int, error) {
+	names := []string{name}
+	for _, f := range r.metadata.ProductFiles {
+		if f.File == name && f.UploadAs != "" {
+			names = append(names, f.UploadAs)
+		}
+	}
+
+	if !r.releaseListed {
+		var err error
+		r.releaseProductFiles, err = r.listReleaseProducts()
+		if err != nil {
+			return 0, err
+		}
+		r.releaseListed = true
+	}
+
+	matches := productFilesByName(r.releaseProductFiles, names)
+	if len(matches) == 0 {
+		if !r.productFilesListed {
+			var err error
+			r.productFiles, err = r.pivnet.ProductFiles(r.productSlug)
+			if err != nil {
+				return 0, err
+			}
+			r.productFilesListed = true
+		}
+
+		matches = productFilesByName(r.productFiles, names)
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("no product file found with name: '%s'", name)
+	case 1:
+		return matches[0].ID, nil
+	default:
+		ids := make([]int, len(matches))
+		for i, m := range matches {
+			// Files to be uploaded have no ID yet
+			if m.ID == 0 {
+				return 0, fmt.Errorf(
+					"product file name: '%s' is ambiguous - matches several files to be uploaded",
+					name,
+				)
+			}
+			ids[i] = m.ID
+		}
+		return 0, fmt.Errorf(
+			"product file name: '%s' is ambiguous - matches product files with IDs: %v",
+			name,
+			ids,
+		)
+	}
+}
+
+// productFilesByName returns the product files whose display name or file
+// name equals any of the provided names.
+func productFilesByName(productFiles []pivnet.ProductFile, names []string) []pivnet.ProductFile {
+	var matches []pivnet.ProductFile
+	for _, pf := range productFiles {
+		fileName := path.Base(pf.AWSObjectKey)
+		for _, name := range names {
+			if pf.Name == name || fileName == path.Base(name) {
+				matches = append(matches, pf)
+				break
+			}
+		}
+	}
+
+	return matches
+}
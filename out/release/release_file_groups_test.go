@@ -162,6 +162,191 @@ var _ = Describe("ReleaseFileGroupsAdder", func() {
 						})
 					})
 				})
+
+				Context("when a file group with the same name already exists", func() {
+					BeforeEach(func() {
+						mdata.FileGroups[1] = metadata.FileGroup{
+							Name: "new-file-group",
+							ProductFiles: []metadata.FileGroupProductFile{
+								{ID: 1212},
+								{ID: 2121},
+							},
+						}
+
+						pivnetClient.FileGroupsReturns([]pivnet.FileGroup{
+							{ID: 555, Name: "other-file-group"},
+							{
+								ID:   4444,
+								Name: "new-file-group",
+								ProductFiles: []pivnet.ProductFile{
+									{ID: 1212},
+									{ID: 3333},
+								},
+							},
+						}, nil)
+					})
+
+					It("reuses the existing file group and syncs its product files", func() {
+						err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pivnetClient.FileGroupsArgsForCall(0)).To(Equal(productSlug))
+						Expect(pivnetClient.CreateFileGroupCallCount()).To(Equal(0))
+
+						Expect(pivnetClient.RemoveFromFileGroupCallCount()).To(Equal(1))
+						slug, fileGroupID, productFileID := pivnetClient.RemoveFromFileGroupArgsForCall(0)
+						Expect(slug).To(Equal(productSlug))
+						Expect(fileGroupID).To(Equal(4444))
+						Expect(productFileID).To(Equal(3333))
+
+						Expect(pivnetClient.AddToFileGroupCallCount()).To(Equal(1))
+						slug, fileGroupID, productFileID = pivnetClient.AddToFileGroupArgsForCall(0)
+						Expect(slug).To(Equal(productSlug))
+						Expect(fileGroupID).To(Equal(4444))
+						Expect(productFileID).To(Equal(2121))
+
+						_, releaseID, fileGroupID := pivnetClient.AddFileGroupArgsForCall(1)
+						Expect(releaseID).To(Equal(pivnetRelease.ID))
+						Expect(fileGroupID).To(Equal(4444))
+					})
+
+					Context("when no product files are listed", func() {
+						BeforeEach(func() {
+							mdata.FileGroups[1].ProductFiles = nil
+						})
+
+						It("leaves the existing membership untouched", func() {
+							err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(pivnetClient.RemoveFromFileGroupCallCount()).To(Equal(0))
+							Expect(pivnetClient.AddToFileGroupCallCount()).To(Equal(0))
+							Expect(pivnetClient.AddFileGroupCallCount()).To(Equal(2))
+						})
+					})
+
+					Context("when removing a product file returns an error", func() {
+						var (
+							expectedErr error
+						)
+
+						BeforeEach(func() {
+							expectedErr = fmt.Errorf("some remove product file error")
+							pivnetClient.RemoveFromFileGroupReturns(expectedErr)
+						})
+
+						It("forwards the error", func() {
+							err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+							Expect(err).To(Equal(expectedErr))
+						})
+					})
+				})
+
+				Context("when the same new file group is listed twice", func() {
+					BeforeEach(func() {
+						mdata.FileGroups[0] = metadata.FileGroup{Name: "new-file-group"}
+						pivnetClient.CreateFileGroupReturns(pivnet.FileGroup{ID: 7777, Name: "new-file-group"}, nil)
+					})
+
+					It("creates the file group only once", func() {
+						err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(pivnetClient.CreateFileGroupCallCount()).To(Equal(1))
+						Expect(pivnetClient.AddFileGroupCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when listing existing file groups returns an error", func() {
+					var (
+						expectedErr error
+					)
+
+					BeforeEach(func() {
+						expectedErr = fmt.Errorf("some list file groups error")
+						pivnetClient.FileGroupsReturns(nil, expectedErr)
+					})
+
+					It("forwards the error", func() {
+						err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+						Expect(err).To(Equal(expectedErr))
+					})
+				})
+
+				Context("when product files are referred to by name", func() {
+					BeforeEach(func() {
+						mdata.ProductFiles = []metadata.ProductFile{
+							{File: "some/path/tile.pivotal", UploadAs: "Some Tile"},
+						}
+
+						mdata.FileGroups[1] = metadata.FileGroup{
+							Name: "new-file-group",
+							ProductFiles: []metadata.FileGroupProductFile{
+								{Name: "some/path/tile.pivotal"},
+								{Name: "Release Notes"},
+								{Name: "old-docs.pdf"},
+							},
+						}
+
+						pivnetClient.CreateFileGroupReturns(pivnet.FileGroup{ID: 7777}, nil)
+						pivnetClient.ProductFilesForReleaseReturns([]pivnet.ProductFile{
+							{ID: 11, Name: "Some Tile", AWSObjectKey: "product/tile.pivotal"},
+							{ID: 12, Name: "Release Notes", AWSObjectKey: "product/notes.txt"},
+						}, nil)
+						pivnetClient.ProductFilesReturns([]pivnet.ProductFile{
+							{ID: 11, Name: "Some Tile", AWSObjectKey: "product/tile.pivotal"},
+							{ID: 12, Name: "Release Notes", AWSObjectKey: "product/notes.txt"},
+							{ID: 13, Name: "Old Docs", AWSObjectKey: "product/old-docs.pdf"},
+						}, nil)
+					})
+
+					It("resolves the names, preferring product files of the release", func() {
+						err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+						Expect(err).NotTo(HaveOccurred())
+
+						slug, releaseID := pivnetClient.ProductFilesForReleaseArgsForCall(0)
+						Expect(slug).To(Equal(productSlug))
+						Expect(releaseID).To(Equal(pivnetRelease.ID))
+
+						Expect(pivnetClient.AddToFileGroupCallCount()).To(Equal(3))
+
+						_, _, productFileID := pivnetClient.AddToFileGroupArgsForCall(0)
+						Expect(productFileID).To(Equal(11))
+						_, _, productFileID = pivnetClient.AddToFileGroupArgsForCall(1)
+						Expect(productFileID).To(Equal(12))
+						_, _, productFileID = pivnetClient.AddToFileGroupArgsForCall(2)
+						Expect(productFileID).To(Equal(13))
+					})
+
+					Context("when a name matches no product file", func() {
+						BeforeEach(func() {
+							mdata.FileGroups[1].ProductFiles = []metadata.FileGroupProductFile{
+								{Name: "missing-file"},
+							}
+						})
+
+						It("returns an error", func() {
+							err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+							Expect(err).To(MatchError("no product file found with name: 'missing-file'"))
+
+							Expect(pivnetClient.CreateFileGroupCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when a name matches several product files", func() {
+						BeforeEach(func() {
+							pivnetClient.ProductFilesForReleaseReturns([]pivnet.ProductFile{
+								{ID: 11, Name: "Some Tile", AWSObjectKey: "product/tile.pivotal"},
+								{ID: 14, Name: "Some Tile", AWSObjectKey: "product/other-tile.pivotal"},
+							}, nil)
+						})
+
+						It("returns an error", func() {
+							err := releaseFileGroupsAdder.AddReleaseFileGroups(pivnetRelease)
+							Expect(err).To(MatchError("product file name: 'some/path/tile.pivotal' is ambiguous - matches product files with IDs: [11 14]"))
+						})
+					})
+				})
 			})
 		})
 	})
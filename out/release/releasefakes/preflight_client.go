@@ -0,0 +1,561 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasefakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type PreflightClient struct {
+	ArtifactReferencesStub        func(string) ([]pivnet.ArtifactReference, error)
+	artifactReferencesMutex       sync.RWMutex
+	artifactReferencesArgsForCall []struct {
+		arg1 string
+	}
+	artifactReferencesReturns struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}
+	artifactReferencesReturnsOnCall map[int]struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}
+	EULAsStub        func() ([]pivnet.EULA, error)
+	eULAsMutex       sync.RWMutex
+	eULAsArgsForCall []struct {
+	}
+	eULAsReturns struct {
+		result1 []pivnet.EULA
+		result2 error
+	}
+	eULAsReturnsOnCall map[int]struct {
+		result1 []pivnet.EULA
+		result2 error
+	}
+	FileGroupsStub        func(string) ([]pivnet.FileGroup, error)
+	fileGroupsMutex       sync.RWMutex
+	fileGroupsArgsForCall []struct {
+		arg1 string
+	}
+	fileGroupsReturns struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	fileGroupsReturnsOnCall map[int]struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	ListUserGroupsStub        func() ([]pivnet.UserGroup, error)
+	listUserGroupsMutex       sync.RWMutex
+	listUserGroupsArgsForCall []struct {
+	}
+	listUserGroupsReturns struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}
+	listUserGroupsReturnsOnCall map[int]struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}
+	ProductFilesStub        func(string) ([]pivnet.ProductFile, error)
+	productFilesMutex       sync.RWMutex
+	productFilesArgsForCall []struct {
+		arg1 string
+	}
+	productFilesReturns struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	productFilesReturnsOnCall map[int]struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	ReleaseTypesStub        func() ([]pivnet.ReleaseType, error)
+	releaseTypesMutex       sync.RWMutex
+	releaseTypesArgsForCall []struct {
+	}
+	releaseTypesReturns struct {
+		result1 []pivnet.ReleaseType
+		result2 error
+	}
+	releaseTypesReturnsOnCall map[int]struct {
+		result1 []pivnet.ReleaseType
+		result2 error
+	}
+	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
+	releasesForProductSlugMutex       sync.RWMutex
+	releasesForProductSlugArgsForCall []struct {
+		arg1 string
+	}
+	releasesForProductSlugReturns struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	releasesForProductSlugReturnsOnCall map[int]struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PreflightClient) ArtifactReferences(arg1 string) ([]pivnet.ArtifactReference, error) {
+	fake.artifactReferencesMutex.Lock()
+	ret, specificReturn := fake.artifactReferencesReturnsOnCall[len(fake.artifactReferencesArgsForCall)]
+	fake.artifactReferencesArgsForCall = append(fake.artifactReferencesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ArtifactReferencesStub
+	fakeReturns := fake.artifactReferencesReturns
+	fake.recordInvocation("ArtifactReferences", []interface{}{arg1})
+	fake.artifactReferencesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) ArtifactReferencesCallCount() int {
+	fake.artifactReferencesMutex.RLock()
+	defer fake.artifactReferencesMutex.RUnlock()
+	return len(fake.artifactReferencesArgsForCall)
+}
+
+func (fake *PreflightClient) ArtifactReferencesCalls(stub func(string) ([]pivnet.ArtifactReference, error)) {
+	fake.artifactReferencesMutex.Lock()
+	defer fake.artifactReferencesMutex.Unlock()
+	fake.ArtifactReferencesStub = stub
+}
+
+func (fake *PreflightClient) ArtifactReferencesArgsForCall(i int) string {
+	fake.artifactReferencesMutex.RLock()
+	defer fake.artifactReferencesMutex.RUnlock()
+	argsForCall := fake.artifactReferencesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PreflightClient) ArtifactReferencesReturns(result1 []pivnet.ArtifactReference, result2 error) {
+	fake.artifactReferencesMutex.Lock()
+	defer fake.artifactReferencesMutex.Unlock()
+	fake.ArtifactReferencesStub = nil
+	fake.artifactReferencesReturns = struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ArtifactReferencesReturnsOnCall(i int, result1 []pivnet.ArtifactReference, result2 error) {
+	fake.artifactReferencesMutex.Lock()
+	defer fake.artifactReferencesMutex.Unlock()
+	fake.ArtifactReferencesStub = nil
+	if fake.artifactReferencesReturnsOnCall == nil {
+		fake.artifactReferencesReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ArtifactReference
+			result2 error
+		})
+	}
+	fake.artifactReferencesReturnsOnCall[i] = struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) EULAs() ([]pivnet.EULA, error) {
+	fake.eULAsMutex.Lock()
+	ret, specificReturn := fake.eULAsReturnsOnCall[len(fake.eULAsArgsForCall)]
+	fake.eULAsArgsForCall = append(fake.eULAsArgsForCall, struct {
+	}{})
+	stub := fake.EULAsStub
+	fakeReturns := fake.eULAsReturns
+	fake.recordInvocation("EULAs", []interface{}{})
+	fake.eULAsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) EULAsCallCount() int {
+	fake.eULAsMutex.RLock()
+	defer fake.eULAsMutex.RUnlock()
+	return len(fake.eULAsArgsForCall)
+}
+
+func (fake *PreflightClient) EULAsCalls(stub func() ([]pivnet.EULA, error)) {
+	fake.eULAsMutex.Lock()
+	defer fake.eULAsMutex.Unlock()
+	fake.EULAsStub = stub
+}
+
+func (fake *PreflightClient) EULAsReturns(result1 []pivnet.EULA, result2 error) {
+	fake.eULAsMutex.Lock()
+	defer fake.eULAsMutex.Unlock()
+	fake.EULAsStub = nil
+	fake.eULAsReturns = struct {
+		result1 []pivnet.EULA
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) EULAsReturnsOnCall(i int, result1 []pivnet.EULA, result2 error) {
+	fake.eULAsMutex.Lock()
+	defer fake.eULAsMutex.Unlock()
+	fake.EULAsStub = nil
+	if fake.eULAsReturnsOnCall == nil {
+		fake.eULAsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.EULA
+			result2 error
+		})
+	}
+	fake.eULAsReturnsOnCall[i] = struct {
+		result1 []pivnet.EULA
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) FileGroups(arg1 string) ([]pivnet.FileGroup, error) {
+	fake.fileGroupsMutex.Lock()
+	ret, specificReturn := fake.fileGroupsReturnsOnCall[len(fake.fileGroupsArgsForCall)]
+	fake.fileGroupsArgsForCall = append(fake.fileGroupsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FileGroupsStub
+	fakeReturns := fake.fileGroupsReturns
+	fake.recordInvocation("FileGroups", []interface{}{arg1})
+	fake.fileGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) FileGroupsCallCount() int {
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	return len(fake.fileGroupsArgsForCall)
+}
+
+func (fake *PreflightClient) FileGroupsCalls(stub func(string) ([]pivnet.FileGroup, error)) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = stub
+}
+
+func (fake *PreflightClient) FileGroupsArgsForCall(i int) string {
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	argsForCall := fake.fileGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PreflightClient) FileGroupsReturns(result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = nil
+	fake.fileGroupsReturns = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) FileGroupsReturnsOnCall(i int, result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = nil
+	if fake.fileGroupsReturnsOnCall == nil {
+		fake.fileGroupsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.FileGroup
+			result2 error
+		})
+	}
+	fake.fileGroupsReturnsOnCall[i] = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ListUserGroups() ([]pivnet.UserGroup, error) {
+	fake.listUserGroupsMutex.Lock()
+	ret, specificReturn := fake.listUserGroupsReturnsOnCall[len(fake.listUserGroupsArgsForCall)]
+	fake.listUserGroupsArgsForCall = append(fake.listUserGroupsArgsForCall, struct {
+	}{})
+	stub := fake.ListUserGroupsStub
+	fakeReturns := fake.listUserGroupsReturns
+	fake.recordInvocation("ListUserGroups", []interface{}{})
+	fake.listUserGroupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) ListUserGroupsCallCount() int {
+	fake.listUserGroupsMutex.RLock()
+	defer fake.listUserGroupsMutex.RUnlock()
+	return len(fake.listUserGroupsArgsForCall)
+}
+
+func (fake *PreflightClient) ListUserGroupsCalls(stub func() ([]pivnet.UserGroup, error)) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = stub
+}
+
+func (fake *PreflightClient) ListUserGroupsReturns(result1 []pivnet.UserGroup, result2 error) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = nil
+	fake.listUserGroupsReturns = struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ListUserGroupsReturnsOnCall(i int, result1 []pivnet.UserGroup, result2 error) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = nil
+	if fake.listUserGroupsReturnsOnCall == nil {
+		fake.listUserGroupsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.UserGroup
+			result2 error
+		})
+	}
+	fake.listUserGroupsReturnsOnCall[i] = struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ProductFiles(arg1 string) ([]pivnet.ProductFile, error) {
+	fake.productFilesMutex.Lock()
+	ret, specificReturn := fake.productFilesReturnsOnCall[len(fake.productFilesArgsForCall)]
+	fake.productFilesArgsForCall = append(fake.productFilesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ProductFilesStub
+	fakeReturns := fake.productFilesReturns
+	fake.recordInvocation("ProductFiles", []interface{}{arg1})
+	fake.productFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) ProductFilesCallCount() int {
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	return len(fake.productFilesArgsForCall)
+}
+
+func (fake *PreflightClient) ProductFilesCalls(stub func(string) ([]pivnet.ProductFile, error)) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = stub
+}
+
+func (fake *PreflightClient) ProductFilesArgsForCall(i int) string {
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	argsForCall := fake.productFilesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PreflightClient) ProductFilesReturns(result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = nil
+	fake.productFilesReturns = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ProductFilesReturnsOnCall(i int, result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = nil
+	if fake.productFilesReturnsOnCall == nil {
+		fake.productFilesReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ProductFile
+			result2 error
+		})
+	}
+	fake.productFilesReturnsOnCall[i] = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ReleaseTypes() ([]pivnet.ReleaseType, error) {
+	fake.releaseTypesMutex.Lock()
+	ret, specificReturn := fake.releaseTypesReturnsOnCall[len(fake.releaseTypesArgsForCall)]
+	fake.releaseTypesArgsForCall = append(fake.releaseTypesArgsForCall, struct {
+	}{})
+	stub := fake.ReleaseTypesStub
+	fakeReturns := fake.releaseTypesReturns
+	fake.recordInvocation("ReleaseTypes", []interface{}{})
+	fake.releaseTypesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) ReleaseTypesCallCount() int {
+	fake.releaseTypesMutex.RLock()
+	defer fake.releaseTypesMutex.RUnlock()
+	return len(fake.releaseTypesArgsForCall)
+}
+
+func (fake *PreflightClient) ReleaseTypesCalls(stub func() ([]pivnet.ReleaseType, error)) {
+	fake.releaseTypesMutex.Lock()
+	defer fake.releaseTypesMutex.Unlock()
+	fake.ReleaseTypesStub = stub
+}
+
+func (fake *PreflightClient) ReleaseTypesReturns(result1 []pivnet.ReleaseType, result2 error) {
+	fake.releaseTypesMutex.Lock()
+	defer fake.releaseTypesMutex.Unlock()
+	fake.ReleaseTypesStub = nil
+	fake.releaseTypesReturns = struct {
+		result1 []pivnet.ReleaseType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ReleaseTypesReturnsOnCall(i int, result1 []pivnet.ReleaseType, result2 error) {
+	fake.releaseTypesMutex.Lock()
+	defer fake.releaseTypesMutex.Unlock()
+	fake.ReleaseTypesStub = nil
+	if fake.releaseTypesReturnsOnCall == nil {
+		fake.releaseTypesReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ReleaseType
+			result2 error
+		})
+	}
+	fake.releaseTypesReturnsOnCall[i] = struct {
+		result1 []pivnet.ReleaseType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
+	fake.releasesForProductSlugMutex.Lock()
+	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
+	fake.releasesForProductSlugArgsForCall = append(fake.releasesForProductSlugArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleasesForProductSlugStub
+	fakeReturns := fake.releasesForProductSlugReturns
+	fake.recordInvocation("ReleasesForProductSlug", []interface{}{arg1})
+	fake.releasesForProductSlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PreflightClient) ReleasesForProductSlugCallCount() int {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	return len(fake.releasesForProductSlugArgsForCall)
+}
+
+func (fake *PreflightClient) ReleasesForProductSlugCalls(stub func(string) ([]pivnet.Release, error)) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = stub
+}
+
+func (fake *PreflightClient) ReleasesForProductSlugArgsForCall(i int) string {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	argsForCall := fake.releasesForProductSlugArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PreflightClient) ReleasesForProductSlugReturns(result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	fake.releasesForProductSlugReturns = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) ReleasesForProductSlugReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	if fake.releasesForProductSlugReturnsOnCall == nil {
+		fake.releasesForProductSlugReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.Release
+			result2 error
+		})
+	}
+	fake.releasesForProductSlugReturnsOnCall[i] = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PreflightClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.artifactReferencesMutex.RLock()
+	defer fake.artifactReferencesMutex.RUnlock()
+	fake.eULAsMutex.RLock()
+	defer fake.eULAsMutex.RUnlock()
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	fake.listUserGroupsMutex.RLock()
+	defer fake.listUserGroupsMutex.RUnlock()
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	fake.releaseTypesMutex.RLock()
+	defer fake.releaseTypesMutex.RUnlock()
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PreflightClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -48,6 +48,20 @@ type ReleaseArtifactReferencesAdderClient struct {
 		result1 []pivnet.ArtifactReference
 		result2 error
 	}
+	ArtifactReferencesForReleaseStub        func(string, int) ([]pivnet.ArtifactReference, error)
+	artifactReferencesForReleaseMutex       sync.RWMutex
+	artifactReferencesForReleaseArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	artifactReferencesForReleaseReturns struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}
+	artifactReferencesForReleaseReturnsOnCall map[int]struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}
 	CreateArtifactReferenceStub        func(pivnet.CreateArtifactReferenceConfig) (pivnet.ArtifactReference, error)
 	createArtifactReferenceMutex       sync.RWMutex
 	createArtifactReferenceArgsForCall []struct {
@@ -89,6 +103,33 @@ type ReleaseArtifactReferencesAdderClient struct {
 		result1 pivnet.ArtifactReference
 		result2 error
 	}
+	RemoveArtifactReferenceStub        func(string, int, int) error
+	removeArtifactReferenceMutex       sync.RWMutex
+	removeArtifactReferenceArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}
+	removeArtifactReferenceReturns struct {
+		result1 error
+	}
+	removeArtifactReferenceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateArtifactReferenceStub        func(string, pivnet.ArtifactReference) (pivnet.ArtifactReference, error)
+	updateArtifactReferenceMutex       sync.RWMutex
+	updateArtifactReferenceArgsForCall []struct {
+		arg1 string
+		arg2 pivnet.ArtifactReference
+	}
+	updateArtifactReferenceReturns struct {
+		result1 pivnet.ArtifactReference
+		result2 error
+	}
+	updateArtifactReferenceReturnsOnCall map[int]struct {
+		result1 pivnet.ArtifactReference
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -285,6 +326,71 @@ func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForDigestRet
 	}{result1, result2}
 }
 
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForRelease(arg1 string, arg2 int) ([]pivnet.ArtifactReference, error) {
+	fake.artifactReferencesForReleaseMutex.Lock()
+	ret, specificReturn := fake.artifactReferencesForReleaseReturnsOnCall[len(fake.artifactReferencesForReleaseArgsForCall)]
+	fake.artifactReferencesForReleaseArgsForCall = append(fake.artifactReferencesForReleaseArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ArtifactReferencesForReleaseStub
+	fakeReturns := fake.artifactReferencesForReleaseReturns
+	fake.recordInvocation("ArtifactReferencesForRelease", []interface{}{arg1, arg2})
+	fake.artifactReferencesForReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForReleaseCallCount() int {
+	fake.artifactReferencesForReleaseMutex.RLock()
+	defer fake.artifactReferencesForReleaseMutex.RUnlock()
+	return len(fake.artifactReferencesForReleaseArgsForCall)
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForReleaseCalls(stub func(string, int) ([]pivnet.ArtifactReference, error)) {
+	fake.artifactReferencesForReleaseMutex.Lock()
+	defer fake.artifactReferencesForReleaseMutex.Unlock()
+	fake.ArtifactReferencesForReleaseStub = stub
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForReleaseArgsForCall(i int) (string, int) {
+	fake.artifactReferencesForReleaseMutex.RLock()
+	defer fake.artifactReferencesForReleaseMutex.RUnlock()
+	argsForCall := fake.artifactReferencesForReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForReleaseReturns(result1 []pivnet.ArtifactReference, result2 error) {
+	fake.artifactReferencesForReleaseMutex.Lock()
+	defer fake.artifactReferencesForReleaseMutex.Unlock()
+	fake.ArtifactReferencesForReleaseStub = nil
+	fake.artifactReferencesForReleaseReturns = struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) ArtifactReferencesForReleaseReturnsOnCall(i int, result1 []pivnet.ArtifactReference, result2 error) {
+	fake.artifactReferencesForReleaseMutex.Lock()
+	defer fake.artifactReferencesForReleaseMutex.Unlock()
+	fake.ArtifactReferencesForReleaseStub = nil
+	if fake.artifactReferencesForReleaseReturnsOnCall == nil {
+		fake.artifactReferencesForReleaseReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ArtifactReference
+			result2 error
+		})
+	}
+	fake.artifactReferencesForReleaseReturnsOnCall[i] = struct {
+		result1 []pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ReleaseArtifactReferencesAdderClient) CreateArtifactReference(arg1 pivnet.CreateArtifactReferenceConfig) (pivnet.ArtifactReference, error) {
 	fake.createArtifactReferenceMutex.Lock()
 	ret, specificReturn := fake.createArtifactReferenceReturnsOnCall[len(fake.createArtifactReferenceArgsForCall)]
@@ -479,6 +585,134 @@ func (fake *ReleaseArtifactReferencesAdderClient) GetArtifactReferenceReturnsOnC
 	}{result1, result2}
 }
 
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReference(arg1 string, arg2 int, arg3 int) error {
+	fake.removeArtifactReferenceMutex.Lock()
+	ret, specificReturn := fake.removeArtifactReferenceReturnsOnCall[len(fake.removeArtifactReferenceArgsForCall)]
+	fake.removeArtifactReferenceArgsForCall = append(fake.removeArtifactReferenceArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveArtifactReferenceStub
+	fakeReturns := fake.removeArtifactReferenceReturns
+	fake.recordInvocation("RemoveArtifactReference", []interface{}{arg1, arg2, arg3})
+	fake.removeArtifactReferenceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReferenceCallCount() int {
+	fake.removeArtifactReferenceMutex.RLock()
+	defer fake.removeArtifactReferenceMutex.RUnlock()
+	return len(fake.removeArtifactReferenceArgsForCall)
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReferenceCalls(stub func(string, int, int) error) {
+	fake.removeArtifactReferenceMutex.Lock()
+	defer fake.removeArtifactReferenceMutex.Unlock()
+	fake.RemoveArtifactReferenceStub = stub
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReferenceArgsForCall(i int) (string, int, int) {
+	fake.removeArtifactReferenceMutex.RLock()
+	defer fake.removeArtifactReferenceMutex.RUnlock()
+	argsForCall := fake.removeArtifactReferenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReferenceReturns(result1 error) {
+	fake.removeArtifactReferenceMutex.Lock()
+	defer fake.removeArtifactReferenceMutex.Unlock()
+	fake.RemoveArtifactReferenceStub = nil
+	fake.removeArtifactReferenceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) RemoveArtifactReferenceReturnsOnCall(i int, result1 error) {
+	fake.removeArtifactReferenceMutex.Lock()
+	defer fake.removeArtifactReferenceMutex.Unlock()
+	fake.RemoveArtifactReferenceStub = nil
+	if fake.removeArtifactReferenceReturnsOnCall == nil {
+		fake.removeArtifactReferenceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeArtifactReferenceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReference(arg1 string, arg2 pivnet.ArtifactReference) (pivnet.ArtifactReference, error) {
+	fake.updateArtifactReferenceMutex.Lock()
+	ret, specificReturn := fake.updateArtifactReferenceReturnsOnCall[len(fake.updateArtifactReferenceArgsForCall)]
+	fake.updateArtifactReferenceArgsForCall = append(fake.updateArtifactReferenceArgsForCall, struct {
+		arg1 string
+		arg2 pivnet.ArtifactReference
+	}{arg1, arg2})
+	stub := fake.UpdateArtifactReferenceStub
+	fakeReturns := fake.updateArtifactReferenceReturns
+	fake.recordInvocation("UpdateArtifactReference", []interface{}{arg1, arg2})
+	fake.updateArtifactReferenceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReferenceCallCount() int {
+	fake.updateArtifactReferenceMutex.RLock()
+	defer fake.updateArtifactReferenceMutex.RUnlock()
+	return len(fake.updateArtifactReferenceArgsForCall)
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReferenceCalls(stub func(string, pivnet.ArtifactReference) (pivnet.ArtifactReference, error)) {
+	fake.updateArtifactReferenceMutex.Lock()
+	defer fake.updateArtifactReferenceMutex.Unlock()
+	fake.UpdateArtifactReferenceStub = stub
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReferenceArgsForCall(i int) (string, pivnet.ArtifactReference) {
+	fake.updateArtifactReferenceMutex.RLock()
+	defer fake.updateArtifactReferenceMutex.RUnlock()
+	argsForCall := fake.updateArtifactReferenceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReferenceReturns(result1 pivnet.ArtifactReference, result2 error) {
+	fake.updateArtifactReferenceMutex.Lock()
+	defer fake.updateArtifactReferenceMutex.Unlock()
+	fake.UpdateArtifactReferenceStub = nil
+	fake.updateArtifactReferenceReturns = struct {
+		result1 pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseArtifactReferencesAdderClient) UpdateArtifactReferenceReturnsOnCall(i int, result1 pivnet.ArtifactReference, result2 error) {
+	fake.updateArtifactReferenceMutex.Lock()
+	defer fake.updateArtifactReferenceMutex.Unlock()
+	fake.UpdateArtifactReferenceStub = nil
+	if fake.updateArtifactReferenceReturnsOnCall == nil {
+		fake.updateArtifactReferenceReturnsOnCall = make(map[int]struct {
+			result1 pivnet.ArtifactReference
+			result2 error
+		})
+	}
+	fake.updateArtifactReferenceReturnsOnCall[i] = struct {
+		result1 pivnet.ArtifactReference
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ReleaseArtifactReferencesAdderClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -488,12 +722,18 @@ func (fake *ReleaseArtifactReferencesAdderClient) Invocations() map[string][][]i
 	defer fake.artifactReferencesMutex.RUnlock()
 	fake.artifactReferencesForDigestMutex.RLock()
 	defer fake.artifactReferencesForDigestMutex.RUnlock()
+	fake.artifactReferencesForReleaseMutex.RLock()
+	defer fake.artifactReferencesForReleaseMutex.RUnlock()
 	fake.createArtifactReferenceMutex.RLock()
 	defer fake.createArtifactReferenceMutex.RUnlock()
 	fake.deleteArtifactReferenceMutex.RLock()
 	defer fake.deleteArtifactReferenceMutex.RUnlock()
 	fake.getArtifactReferenceMutex.RLock()
 	defer fake.getArtifactReferenceMutex.RUnlock()
+	fake.removeArtifactReferenceMutex.RLock()
+	defer fake.removeArtifactReferenceMutex.RUnlock()
+	fake.updateArtifactReferenceMutex.RLock()
+	defer fake.updateArtifactReferenceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
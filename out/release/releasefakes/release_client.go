@@ -33,30 +33,6 @@ type ReleaseClient struct {
 	deleteReleaseReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EULAsStub        func() ([]pivnet.EULA, error)
-	eULAsMutex       sync.RWMutex
-	eULAsArgsForCall []struct {
-	}
-	eULAsReturns struct {
-		result1 []pivnet.EULA
-		result2 error
-	}
-	eULAsReturnsOnCall map[int]struct {
-		result1 []pivnet.EULA
-		result2 error
-	}
-	ReleaseTypesStub        func() ([]pivnet.ReleaseType, error)
-	releaseTypesMutex       sync.RWMutex
-	releaseTypesArgsForCall []struct {
-	}
-	releaseTypesReturns struct {
-		result1 []pivnet.ReleaseType
-		result2 error
-	}
-	releaseTypesReturnsOnCall map[int]struct {
-		result1 []pivnet.ReleaseType
-		result2 error
-	}
 	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
 	releasesForProductSlugMutex       sync.RWMutex
 	releasesForProductSlugArgsForCall []struct {
@@ -200,118 +176,6 @@ func (fake *ReleaseClient) DeleteReleaseReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *ReleaseClient) EULAs() ([]pivnet.EULA, error) {
-	fake.eULAsMutex.Lock()
-	ret, specificReturn := fake.eULAsReturnsOnCall[len(fake.eULAsArgsForCall)]
-	fake.eULAsArgsForCall = append(fake.eULAsArgsForCall, struct {
-	}{})
-	stub := fake.EULAsStub
-	fakeReturns := fake.eULAsReturns
-	fake.recordInvocation("EULAs", []interface{}{})
-	fake.eULAsMutex.Unlock()
-	if stub != nil {
-		return stub()
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *ReleaseClient) EULAsCallCount() int {
-	fake.eULAsMutex.RLock()
-	defer fake.eULAsMutex.RUnlock()
-	return len(fake.eULAsArgsForCall)
-}
-
-func (fake *ReleaseClient) EULAsCalls(stub func() ([]pivnet.EULA, error)) {
-	fake.eULAsMutex.Lock()
-	defer fake.eULAsMutex.Unlock()
-	fake.EULAsStub = stub
-}
-
-func (fake *ReleaseClient) EULAsReturns(result1 []pivnet.EULA, result2 error) {
-	fake.eULAsMutex.Lock()
-	defer fake.eULAsMutex.Unlock()
-	fake.EULAsStub = nil
-	fake.eULAsReturns = struct {
-		result1 []pivnet.EULA
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *ReleaseClient) EULAsReturnsOnCall(i int, result1 []pivnet.EULA, result2 error) {
-	fake.eULAsMutex.Lock()
-	defer fake.eULAsMutex.Unlock()
-	fake.EULAsStub = nil
-	if fake.eULAsReturnsOnCall == nil {
-		fake.eULAsReturnsOnCall = make(map[int]struct {
-			result1 []pivnet.EULA
-			result2 error
-		})
-	}
-	fake.eULAsReturnsOnCall[i] = struct {
-		result1 []pivnet.EULA
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *ReleaseClient) ReleaseTypes() ([]pivnet.ReleaseType, error) {
-	fake.releaseTypesMutex.Lock()
-	ret, specificReturn := fake.releaseTypesReturnsOnCall[len(fake.releaseTypesArgsForCall)]
-	fake.releaseTypesArgsForCall = append(fake.releaseTypesArgsForCall, struct {
-	}{})
-	stub := fake.ReleaseTypesStub
-	fakeReturns := fake.releaseTypesReturns
-	fake.recordInvocation("ReleaseTypes", []interface{}{})
-	fake.releaseTypesMutex.Unlock()
-	if stub != nil {
-		return stub()
-	}
-	if specificReturn {
-		return ret.result1, ret.result2
-	}
-	return fakeReturns.result1, fakeReturns.result2
-}
-
-func (fake *ReleaseClient) ReleaseTypesCallCount() int {
-	fake.releaseTypesMutex.RLock()
-	defer fake.releaseTypesMutex.RUnlock()
-	return len(fake.releaseTypesArgsForCall)
-}
-
-func (fake *ReleaseClient) ReleaseTypesCalls(stub func() ([]pivnet.ReleaseType, error)) {
-	fake.releaseTypesMutex.Lock()
-	defer fake.releaseTypesMutex.Unlock()
-	fake.ReleaseTypesStub = stub
-}
-
-func (fake *ReleaseClient) ReleaseTypesReturns(result1 []pivnet.ReleaseType, result2 error) {
-	fake.releaseTypesMutex.Lock()
-	defer fake.releaseTypesMutex.Unlock()
-	fake.ReleaseTypesStub = nil
-	fake.releaseTypesReturns = struct {
-		result1 []pivnet.ReleaseType
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *ReleaseClient) ReleaseTypesReturnsOnCall(i int, result1 []pivnet.ReleaseType, result2 error) {
-	fake.releaseTypesMutex.Lock()
-	defer fake.releaseTypesMutex.Unlock()
-	fake.ReleaseTypesStub = nil
-	if fake.releaseTypesReturnsOnCall == nil {
-		fake.releaseTypesReturnsOnCall = make(map[int]struct {
-			result1 []pivnet.ReleaseType
-			result2 error
-		})
-	}
-	fake.releaseTypesReturnsOnCall[i] = struct {
-		result1 []pivnet.ReleaseType
-		result2 error
-	}{result1, result2}
-}
-
 func (fake *ReleaseClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
 	fake.releasesForProductSlugMutex.Lock()
 	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
@@ -383,10 +247,6 @@ func (fake *ReleaseClient) Invocations() map[string][][]interface{} {
 	defer fake.createReleaseMutex.RUnlock()
 	fake.deleteReleaseMutex.RLock()
 	defer fake.deleteReleaseMutex.RUnlock()
-	fake.eULAsMutex.RLock()
-	defer fake.eULAsMutex.RUnlock()
-	fake.releaseTypesMutex.RLock()
-	defer fake.releaseTypesMutex.RUnlock()
 	fake.releasesForProductSlugMutex.RLock()
 	defer fake.releasesForProductSlugMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
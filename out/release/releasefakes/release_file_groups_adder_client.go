@@ -47,6 +47,59 @@ type ReleaseFileGroupsAdderClient struct {
 		result1 pivnet.FileGroup
 		result2 error
 	}
+	FileGroupsStub        func(string) ([]pivnet.FileGroup, error)
+	fileGroupsMutex       sync.RWMutex
+	fileGroupsArgsForCall []struct {
+		arg1 string
+	}
+	fileGroupsReturns struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	fileGroupsReturnsOnCall map[int]struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}
+	ProductFilesStub        func(string) ([]pivnet.ProductFile, error)
+	productFilesMutex       sync.RWMutex
+	productFilesArgsForCall []struct {
+		arg1 string
+	}
+	productFilesReturns struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	productFilesReturnsOnCall map[int]struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	ProductFilesForReleaseStub        func(string, int) ([]pivnet.ProductFile, error)
+	productFilesForReleaseMutex       sync.RWMutex
+	productFilesForReleaseArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	productFilesForReleaseReturns struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	productFilesForReleaseReturnsOnCall map[int]struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}
+	RemoveFromFileGroupStub        func(string, int, int) error
+	removeFromFileGroupMutex       sync.RWMutex
+	removeFromFileGroupArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}
+	removeFromFileGroupReturns struct {
+		result1 error
+	}
+	removeFromFileGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -241,6 +294,262 @@ func (fake *ReleaseFileGroupsAdderClient) CreateFileGroupReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *ReleaseFileGroupsAdderClient) FileGroups(arg1 string) ([]pivnet.FileGroup, error) {
+	fake.fileGroupsMutex.Lock()
+	ret, specificReturn := fake.fileGroupsReturnsOnCall[len(fake.fileGroupsArgsForCall)]
+	fake.fileGroupsArgsForCall = append(fake.fileGroupsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FileGroupsStub
+	fakeReturns := fake.fileGroupsReturns
+	fake.recordInvocation("FileGroups", []interface{}{arg1})
+	fake.fileGroupsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReleaseFileGroupsAdderClient) FileGroupsCallCount() int {
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	return len(fake.fileGroupsArgsForCall)
+}
+
+func (fake *ReleaseFileGroupsAdderClient) FileGroupsCalls(stub func(string) ([]pivnet.FileGroup, error)) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = stub
+}
+
+func (fake *ReleaseFileGroupsAdderClient) FileGroupsArgsForCall(i int) string {
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	argsForCall := fake.fileGroupsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ReleaseFileGroupsAdderClient) FileGroupsReturns(result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = nil
+	fake.fileGroupsReturns = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) FileGroupsReturnsOnCall(i int, result1 []pivnet.FileGroup, result2 error) {
+	fake.fileGroupsMutex.Lock()
+	defer fake.fileGroupsMutex.Unlock()
+	fake.FileGroupsStub = nil
+	if fake.fileGroupsReturnsOnCall == nil {
+		fake.fileGroupsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.FileGroup
+			result2 error
+		})
+	}
+	fake.fileGroupsReturnsOnCall[i] = struct {
+		result1 []pivnet.FileGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFiles(arg1 string) ([]pivnet.ProductFile, error) {
+	fake.productFilesMutex.Lock()
+	ret, specificReturn := fake.productFilesReturnsOnCall[len(fake.productFilesArgsForCall)]
+	fake.productFilesArgsForCall = append(fake.productFilesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ProductFilesStub
+	fakeReturns := fake.productFilesReturns
+	fake.recordInvocation("ProductFiles", []interface{}{arg1})
+	fake.productFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesCallCount() int {
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	return len(fake.productFilesArgsForCall)
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesCalls(stub func(string) ([]pivnet.ProductFile, error)) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = stub
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesArgsForCall(i int) string {
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	argsForCall := fake.productFilesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesReturns(result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = nil
+	fake.productFilesReturns = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesReturnsOnCall(i int, result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesMutex.Lock()
+	defer fake.productFilesMutex.Unlock()
+	fake.ProductFilesStub = nil
+	if fake.productFilesReturnsOnCall == nil {
+		fake.productFilesReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ProductFile
+			result2 error
+		})
+	}
+	fake.productFilesReturnsOnCall[i] = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForRelease(arg1 string, arg2 int) ([]pivnet.ProductFile, error) {
+	fake.productFilesForReleaseMutex.Lock()
+	ret, specificReturn := fake.productFilesForReleaseReturnsOnCall[len(fake.productFilesForReleaseArgsForCall)]
+	fake.productFilesForReleaseArgsForCall = append(fake.productFilesForReleaseArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ProductFilesForReleaseStub
+	fakeReturns := fake.productFilesForReleaseReturns
+	fake.recordInvocation("ProductFilesForRelease", []interface{}{arg1, arg2})
+	fake.productFilesForReleaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForReleaseCallCount() int {
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
+	return len(fake.productFilesForReleaseArgsForCall)
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForReleaseCalls(stub func(string, int) ([]pivnet.ProductFile, error)) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = stub
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForReleaseArgsForCall(i int) (string, int) {
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
+	argsForCall := fake.productFilesForReleaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForReleaseReturns(result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = nil
+	fake.productFilesForReleaseReturns = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) ProductFilesForReleaseReturnsOnCall(i int, result1 []pivnet.ProductFile, result2 error) {
+	fake.productFilesForReleaseMutex.Lock()
+	defer fake.productFilesForReleaseMutex.Unlock()
+	fake.ProductFilesForReleaseStub = nil
+	if fake.productFilesForReleaseReturnsOnCall == nil {
+		fake.productFilesForReleaseReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ProductFile
+			result2 error
+		})
+	}
+	fake.productFilesForReleaseReturnsOnCall[i] = struct {
+		result1 []pivnet.ProductFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroup(arg1 string, arg2 int, arg3 int) error {
+	fake.removeFromFileGroupMutex.Lock()
+	ret, specificReturn := fake.removeFromFileGroupReturnsOnCall[len(fake.removeFromFileGroupArgsForCall)]
+	fake.removeFromFileGroupArgsForCall = append(fake.removeFromFileGroupArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveFromFileGroupStub
+	fakeReturns := fake.removeFromFileGroupReturns
+	fake.recordInvocation("RemoveFromFileGroup", []interface{}{arg1, arg2, arg3})
+	fake.removeFromFileGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroupCallCount() int {
+	fake.removeFromFileGroupMutex.RLock()
+	defer fake.removeFromFileGroupMutex.RUnlock()
+	return len(fake.removeFromFileGroupArgsForCall)
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroupCalls(stub func(string, int, int) error) {
+	fake.removeFromFileGroupMutex.Lock()
+	defer fake.removeFromFileGroupMutex.Unlock()
+	fake.RemoveFromFileGroupStub = stub
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroupArgsForCall(i int) (string, int, int) {
+	fake.removeFromFileGroupMutex.RLock()
+	defer fake.removeFromFileGroupMutex.RUnlock()
+	argsForCall := fake.removeFromFileGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroupReturns(result1 error) {
+	fake.removeFromFileGroupMutex.Lock()
+	defer fake.removeFromFileGroupMutex.Unlock()
+	fake.RemoveFromFileGroupStub = nil
+	fake.removeFromFileGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ReleaseFileGroupsAdderClient) RemoveFromFileGroupReturnsOnCall(i int, result1 error) {
+	fake.removeFromFileGroupMutex.Lock()
+	defer fake.removeFromFileGroupMutex.Unlock()
+	fake.RemoveFromFileGroupStub = nil
+	if fake.removeFromFileGroupReturnsOnCall == nil {
+		fake.removeFromFileGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeFromFileGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ReleaseFileGroupsAdderClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -250,6 +559,14 @@ func (fake *ReleaseFileGroupsAdderClient) Invocations() map[string][][]interface
 	defer fake.addToFileGroupMutex.RUnlock()
 	fake.createFileGroupMutex.RLock()
 	defer fake.createFileGroupMutex.RUnlock()
+	fake.fileGroupsMutex.RLock()
+	defer fake.fileGroupsMutex.RUnlock()
+	fake.productFilesMutex.RLock()
+	defer fake.productFilesMutex.RUnlock()
+	fake.productFilesForReleaseMutex.RLock()
+	defer fake.productFilesForReleaseMutex.RUnlock()
+	fake.removeFromFileGroupMutex.RLock()
+	defer fake.removeFromFileGroupMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
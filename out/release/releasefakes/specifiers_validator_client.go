@@ -0,0 +1,193 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package releasefakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type SpecifiersValidatorClient struct {
+	FindProductForSlugStub        func(string) (pivnet.Product, error)
+	findProductForSlugMutex       sync.RWMutex
+	findProductForSlugArgsForCall []struct {
+		arg1 string
+	}
+	findProductForSlugReturns struct {
+		result1 pivnet.Product
+		result2 error
+	}
+	findProductForSlugReturnsOnCall map[int]struct {
+		result1 pivnet.Product
+		result2 error
+	}
+	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
+	releasesForProductSlugMutex       sync.RWMutex
+	releasesForProductSlugArgsForCall []struct {
+		arg1 string
+	}
+	releasesForProductSlugReturns struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	releasesForProductSlugReturnsOnCall map[int]struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlug(arg1 string) (pivnet.Product, error) {
+	fake.findProductForSlugMutex.Lock()
+	ret, specificReturn := fake.findProductForSlugReturnsOnCall[len(fake.findProductForSlugArgsForCall)]
+	fake.findProductForSlugArgsForCall = append(fake.findProductForSlugArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FindProductForSlugStub
+	fakeReturns := fake.findProductForSlugReturns
+	fake.recordInvocation("FindProductForSlug", []interface{}{arg1})
+	fake.findProductForSlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlugCallCount() int {
+	fake.findProductForSlugMutex.RLock()
+	defer fake.findProductForSlugMutex.RUnlock()
+	return len(fake.findProductForSlugArgsForCall)
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlugCalls(stub func(string) (pivnet.Product, error)) {
+	fake.findProductForSlugMutex.Lock()
+	defer fake.findProductForSlugMutex.Unlock()
+	fake.FindProductForSlugStub = stub
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlugArgsForCall(i int) string {
+	fake.findProductForSlugMutex.RLock()
+	defer fake.findProductForSlugMutex.RUnlock()
+	argsForCall := fake.findProductForSlugArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlugReturns(result1 pivnet.Product, result2 error) {
+	fake.findProductForSlugMutex.Lock()
+	defer fake.findProductForSlugMutex.Unlock()
+	fake.FindProductForSlugStub = nil
+	fake.findProductForSlugReturns = struct {
+		result1 pivnet.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpecifiersValidatorClient) FindProductForSlugReturnsOnCall(i int, result1 pivnet.Product, result2 error) {
+	fake.findProductForSlugMutex.Lock()
+	defer fake.findProductForSlugMutex.Unlock()
+	fake.FindProductForSlugStub = nil
+	if fake.findProductForSlugReturnsOnCall == nil {
+		fake.findProductForSlugReturnsOnCall = make(map[int]struct {
+			result1 pivnet.Product
+			result2 error
+		})
+	}
+	fake.findProductForSlugReturnsOnCall[i] = struct {
+		result1 pivnet.Product
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
+	fake.releasesForProductSlugMutex.Lock()
+	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
+	fake.releasesForProductSlugArgsForCall = append(fake.releasesForProductSlugArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleasesForProductSlugStub
+	fakeReturns := fake.releasesForProductSlugReturns
+	fake.recordInvocation("ReleasesForProductSlug", []interface{}{arg1})
+	fake.releasesForProductSlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlugCallCount() int {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	return len(fake.releasesForProductSlugArgsForCall)
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlugCalls(stub func(string) ([]pivnet.Release, error)) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = stub
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlugArgsForCall(i int) string {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	argsForCall := fake.releasesForProductSlugArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlugReturns(result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	fake.releasesForProductSlugReturns = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpecifiersValidatorClient) ReleasesForProductSlugReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	if fake.releasesForProductSlugReturnsOnCall == nil {
+		fake.releasesForProductSlugReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.Release
+			result2 error
+		})
+	}
+	fake.releasesForProductSlugReturnsOnCall[i] = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SpecifiersValidatorClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findProductForSlugMutex.RLock()
+	defer fake.findProductForSlugMutex.RUnlock()
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SpecifiersValidatorClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -21,6 +21,18 @@ type UserGroupsUpdaterClient struct {
 	addUserGroupReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ListUserGroupsStub        func() ([]pivnet.UserGroup, error)
+	listUserGroupsMutex       sync.RWMutex
+	listUserGroupsArgsForCall []struct {
+	}
+	listUserGroupsReturns struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}
+	listUserGroupsReturnsOnCall map[int]struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}
 	UpdateReleaseStub        func(string, pivnet.Release) (pivnet.Release, error)
 	updateReleaseMutex       sync.RWMutex
 	updateReleaseArgsForCall []struct {
@@ -102,6 +114,62 @@ func (fake *UserGroupsUpdaterClient) AddUserGroupReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *UserGroupsUpdaterClient) ListUserGroups() ([]pivnet.UserGroup, error) {
+	fake.listUserGroupsMutex.Lock()
+	ret, specificReturn := fake.listUserGroupsReturnsOnCall[len(fake.listUserGroupsArgsForCall)]
+	fake.listUserGroupsArgsForCall = append(fake.listUserGroupsArgsForCall, struct {
+	}{})
+	stub := fake.ListUserGroupsStub
+	fakeReturns := fake.listUserGroupsReturns
+	fake.recordInvocation("ListUserGroups", []interface{}{})
+	fake.listUserGroupsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *UserGroupsUpdaterClient) ListUserGroupsCallCount() int {
+	fake.listUserGroupsMutex.RLock()
+	defer fake.listUserGroupsMutex.RUnlock()
+	return len(fake.listUserGroupsArgsForCall)
+}
+
+func (fake *UserGroupsUpdaterClient) ListUserGroupsCalls(stub func() ([]pivnet.UserGroup, error)) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = stub
+}
+
+func (fake *UserGroupsUpdaterClient) ListUserGroupsReturns(result1 []pivnet.UserGroup, result2 error) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = nil
+	fake.listUserGroupsReturns = struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *UserGroupsUpdaterClient) ListUserGroupsReturnsOnCall(i int, result1 []pivnet.UserGroup, result2 error) {
+	fake.listUserGroupsMutex.Lock()
+	defer fake.listUserGroupsMutex.Unlock()
+	fake.ListUserGroupsStub = nil
+	if fake.listUserGroupsReturnsOnCall == nil {
+		fake.listUserGroupsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.UserGroup
+			result2 error
+		})
+	}
+	fake.listUserGroupsReturnsOnCall[i] = struct {
+		result1 []pivnet.UserGroup
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *UserGroupsUpdaterClient) UpdateRelease(arg1 string, arg2 pivnet.Release) (pivnet.Release, error) {
 	fake.updateReleaseMutex.Lock()
 	ret, specificReturn := fake.updateReleaseReturnsOnCall[len(fake.updateReleaseArgsForCall)]
@@ -172,6 +240,8 @@ func (fake *UserGroupsUpdaterClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addUserGroupMutex.RLock()
 	defer fake.addUserGroupMutex.RUnlock()
+	fake.listUserGroupsMutex.RLock()
+	defer fake.listUserGroupsMutex.RUnlock()
 	fake.updateReleaseMutex.RLock()
 	defer fake.updateReleaseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,166 @@
+package release
+
+import (
+	"fmt"
+	"strings"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+)
+
+type SpecifiersValidator struct {
+	logger      logger.Logger
+	pivnet      specifiersValidatorClient
+	matcher     specifierMatcher
+	metadata    metadata.Metadata
+	productSlug string
+}
+
+func NewSpecifiersValidator(
+	logger logger.Logger,
+	pivnetClient specifiersValidatorClient,
+	matcher specifierMatcher,
+	metadata metadata.Metadata,
+	productSlug string,
+) SpecifiersValidator {
+	return SpecifiersValidator{
+		logger:      logger,
+		pivnet:      pivnetClient,
+		matcher:     matcher,
+		metadata:    metadata,
+		productSlug: productSlug,
+	}
+}
+
+//counterfeiter:generate --fake-name SpecifiersValidatorClient . specifiersValidatorClient
+type specifiersValidatorClient interface {
+	FindProductForSlug(slug string) (pivnet.Product, error)
+	ReleasesForProductSlug(productSlug string) ([]pivnet.Release, error)
+}
+
+//counterfeiter:generate --fake-name FakeSpecifierMatcher . specifierMatcher
+type specifierMatcher interface {
+	ReleasesMatching(releases []pivnet.Release, specifier string) ([]pivnet.Release, error)
+}
+
+// ValidateSpecifiers checks that every dependent product exists and that
+// every dependency and upgrade path specifier matches at least one release,
+// before anything is created on Pivnet. All problems are reported together.
+func (v SpecifiersValidator) ValidateSpecifiers() error {
+	var problems []string
+
+	releasesBySlug := map[string][]pivnet.Release{}
+	missingProducts := map[string]bool{}
+
+	for i, d := range v.metadata.DependencySpecifiers {
+		if missingProducts[d.ProductSlug] {
+			continue
+		}
+
+		releases, ok := releasesBySlug[d.ProductSlug]
+		if !ok {
+			v.logger.Info(fmt.Sprintf(
+				"Validating dependent product: '%s'",
+				d.ProductSlug,
+			))
+
+			_, err := v.pivnet.FindProductForSlug(d.ProductSlug)
+			if err != nil {
+				missingProducts[d.ProductSlug] = true
+				problems = append(problems, fmt.Sprintf(
+					"dependency_specifiers[%d]: product '%s' could not be found: %s",
+					i,
+					d.ProductSlug,
+					err,
+				))
+				continue
+			}
+
+			releases, err = v.pivnet.ReleasesForProductSlug(d.ProductSlug)
+			if err != nil {
+				return err
+			}
+			releasesBySlug[d.ProductSlug] = releases
+		}
+
+		problem := v.resolve(
+			fmt.Sprintf("dependency_specifiers[%d]", i),
+			d.ProductSlug,
+			d.Specifier,
+			releases,
+		)
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(v.metadata.UpgradePathSpecifiers) > 0 {
+		releases, ok := releasesBySlug[v.productSlug]
+		if !ok {
+			var err error
+			releases, err = v.pivnet.ReleasesForProductSlug(v.productSlug)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, u := range v.metadata.UpgradePathSpecifiers {
+			problem := v.resolve(
+				fmt.Sprintf("upgrade_path_specifiers[%d]", i),
+				v.productSlug,
+				u.Specifier,
+				releases,
+			)
+			if problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf(
+			"invalid specifiers:\n  %s",
+			strings.Join(problems, "\n  "),
+		)
+	}
+
+	return nil
+}
+
+// resolve logs the releases matching the specifier and returns a description
+// of the problem if there are none.
+func (v SpecifiersValidator) resolve(
+	field string,
+	productSlug string,
+	specifier string,
+	releases []pivnet.Release,
+) string {
+	matching, err := v.matcher.ReleasesMatching(releases, specifier)
+	if err != nil {
+		return fmt.Sprintf("%s: %s", field, err)
+	}
+
+	if len(matching) == 0 {
+		return fmt.Sprintf(
+			"%s: specifier '%s' matches no releases of product '%s'",
+			field,
+			specifier,
+			productSlug,
+		)
+	}
+
+	versions := make([]string, len(matching))
+	for i, r := range matching {
+		versions[i] = r.Version
+	}
+
+	v.logger.Info(fmt.Sprintf(
+		"Specifier '%s' for product '%s' matches releases: %s",
+		specifier,
+		productSlug,
+		strings.Join(versions, ", "),
+	))
+
+	return ""
+}
@@ -0,0 +1,148 @@
+package release_test
+
+import (
+	"errors"
+	"log"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release/releasefakes"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/specifier"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpecifiersValidator", func() {
+	Describe("ValidateSpecifiers", func() {
+		var (
+			fakeLogger logger.Logger
+
+			pivnetClient *releasefakes.SpecifiersValidatorClient
+
+			mdata metadata.Metadata
+
+			productSlug string
+
+			specifiersValidator release.SpecifiersValidator
+		)
+
+		BeforeEach(func() {
+			logger := log.New(GinkgoWriter, "", log.LstdFlags)
+			fakeLogger = logshim.NewLogShim(logger, logger, true)
+
+			pivnetClient = &releasefakes.SpecifiersValidatorClient{}
+
+			productSlug = "some-product-slug"
+
+			mdata = metadata.Metadata{
+				Release: &metadata.Release{
+					Version:  "2.0.0",
+					EULASlug: "a_eula_slug",
+				},
+				DependencySpecifiers: []metadata.DependencySpecifier{
+					{ProductSlug: "stemcells", Specifier: "621.*"},
+					{ProductSlug: "ops-manager", Specifier: "~>2.10.0"},
+				},
+				UpgradePathSpecifiers: []metadata.UpgradePathSpecifier{
+					{Specifier: "1.*"},
+				},
+			}
+
+			pivnetClient.ReleasesForProductSlugStub = func(slug string) ([]pivnet.Release, error) {
+				switch slug {
+				case "stemcells":
+					return []pivnet.Release{{Version: "621.1"}, {Version: "456.2"}}, nil
+				case "ops-manager":
+					return []pivnet.Release{{Version: "2.10.3"}, {Version: "3.0.1"}}, nil
+				case productSlug:
+					return []pivnet.Release{{Version: "1.9.0"}, {Version: "0.9.0"}}, nil
+				}
+				return nil, errors.New("unexpected product slug")
+			}
+		})
+
+		JustBeforeEach(func() {
+			specifiersValidator = release.NewSpecifiersValidator(
+				fakeLogger,
+				pivnetClient,
+				specifier.NewMatcher(semver.NewSemverConverter(fakeLogger)),
+				mdata,
+				productSlug,
+			)
+		})
+
+		It("checks every dependent product and specifier", func() {
+			err := specifiersValidator.ValidateSpecifiers()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pivnetClient.FindProductForSlugCallCount()).To(Equal(2))
+			Expect(pivnetClient.FindProductForSlugArgsForCall(0)).To(Equal("stemcells"))
+			Expect(pivnetClient.FindProductForSlugArgsForCall(1)).To(Equal("ops-manager"))
+
+			Expect(pivnetClient.ReleasesForProductSlugCallCount()).To(Equal(3))
+			Expect(pivnetClient.ReleasesForProductSlugArgsForCall(2)).To(Equal(productSlug))
+		})
+
+		Context("when several specifiers refer to the same product", func() {
+			BeforeEach(func() {
+				mdata.DependencySpecifiers = append(mdata.DependencySpecifiers, metadata.DependencySpecifier{
+					ProductSlug: "stemcells", Specifier: "456.*",
+				})
+			})
+
+			It("looks the product up once", func() {
+				err := specifiersValidator.ValidateSpecifiers()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pivnetClient.FindProductForSlugCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when there are problems", func() {
+			BeforeEach(func() {
+				mdata.DependencySpecifiers = []metadata.DependencySpecifier{
+					{ProductSlug: "stemcell", Specifier: "621.*"},
+					{ProductSlug: "ops-manager", Specifier: "~>2.11.0"},
+					{ProductSlug: "ops-manager", Specifier: ">=bad"},
+				}
+				mdata.UpgradePathSpecifiers = []metadata.UpgradePathSpecifier{
+					{Specifier: "3.*"},
+				}
+
+				pivnetClient.FindProductForSlugStub = func(slug string) (pivnet.Product, error) {
+					if slug == "stemcell" {
+						return pivnet.Product{}, errors.New("not found")
+					}
+					return pivnet.Product{Slug: slug}, nil
+				}
+			})
+
+			It("reports all of them together", func() {
+				err := specifiersValidator.ValidateSpecifiers()
+				Expect(err).To(HaveOccurred())
+
+				Expect(err.Error()).To(ContainSubstring("dependency_specifiers[0]: product 'stemcell' could not be found: not found"))
+				Expect(err.Error()).To(ContainSubstring("dependency_specifiers[1]: specifier '~>2.11.0' matches no releases of product 'ops-manager'"))
+				Expect(err.Error()).To(ContainSubstring("dependency_specifiers[2]: invalid specifier: '>=bad'"))
+				Expect(err.Error()).To(ContainSubstring("upgrade_path_specifiers[0]: specifier '3.*' matches no releases of product 'some-product-slug'"))
+			})
+		})
+
+		Context("when getting releases fails", func() {
+			BeforeEach(func() {
+				pivnetClient.ReleasesForProductSlugStub = nil
+				pivnetClient.ReleasesForProductSlugReturns(nil, errors.New("some releases error"))
+			})
+
+			It("returns the error", func() {
+				err := specifiersValidator.ValidateSpecifiers()
+				Expect(err).To(MatchError("some releases error"))
+			})
+		})
+	})
+})
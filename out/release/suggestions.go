@@ -0,0 +1,117 @@
+package release
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const maxSuggestions = 5
+
+// closestMatches returns the candidates that look like plausible typos of
+// the input, ordered from most to least similar. A candidate is considered
+// close if it contains (or is contained by) the input, ignoring case, or if
+// its edit distance from the input is small relative to the input length.
+func closestMatches(input string, candidates []string) []string {
+	type match struct {
+		candidate string
+		distance  int
+	}
+
+	lowerInput := strings.ToLower(input)
+
+	threshold := len(input) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+
+	var matches []match
+	for _, c := range candidates {
+		lowerCandidate := strings.ToLower(c)
+
+		d := levenshtein(lowerInput, lowerCandidate)
+		if d <= threshold ||
+			(lowerInput != "" && strings.Contains(lowerCandidate, lowerInput)) ||
+			(lowerCandidate != "" && strings.Contains(lowerInput, lowerCandidate)) {
+			matches = append(matches, match{candidate: c, distance: d})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	closest := make([]string, len(matches))
+	for i, m := range matches {
+		closest[i] = m.candidate
+	}
+
+	return closest
+}
+
+// notFoundError describes a value that does not exist on Pivnet. If any
+// candidates are similar to the value they are suggested, otherwise every
+// candidate is listed.
+func notFoundError(kind string, value string, candidates []string) error {
+	suggestions := closestMatches(value, candidates)
+	if len(suggestions) > 0 {
+		return fmt.Errorf(
+			"provided %s: '%s' not found - did you mean one of: %s",
+			kind,
+			value,
+			printable(suggestions),
+		)
+	}
+
+	return fmt.Errorf(
+		"provided %s: '%s' must be one of: %s",
+		kind,
+		value,
+		printable(candidates),
+	)
+}
+
+func printable(values []string) string {
+	return fmt.Sprintf("['%s']", strings.Join(values, "', '"))
+}
+
+func levenshtein(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(
+				previous[j]+1,
+				minInt(current[j-1]+1, previous[j-1]+cost),
+			)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
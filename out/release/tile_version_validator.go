@@ -0,0 +1,77 @@
+package release
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+)
+
+type TileVersionValidator struct {
+	logger     logger.Logger
+	inspector  tileInspector
+	sourcesDir string
+	metadata   metadata.Metadata
+}
+
+func NewTileVersionValidator(
+	logger logger.Logger,
+	inspector tileInspector,
+	sourcesDir string,
+	metadata metadata.Metadata,
+) TileVersionValidator {
+	return TileVersionValidator{
+		logger:     logger,
+		inspector:  inspector,
+		sourcesDir: sourcesDir,
+		metadata:   metadata,
+	}
+}
+
+//counterfeiter:generate --fake-name FakeTileInspector . tileInspector
+type tileInspector interface {
+	Inspect(tilePath string) (tile.Tile, error)
+}
+
+// ValidateTileVersions checks that every tile to be uploaded was built with
+// the version of the release being created, so that a tile left over from a
+// previous build is not uploaded to the new release. All mismatched tiles are
+// reported together.
+func (v TileVersionValidator) ValidateTileVersions(exactGlobs []string) error {
+	version := v.metadata.Release.Version
+
+	var problems []string
+	for _, exactGlob := range exactGlobs {
+		if !strings.HasSuffix(exactGlob, ".pivotal") {
+			continue
+		}
+
+		v.logger.Info(fmt.Sprintf("Validating version of tile: '%s'", exactGlob))
+
+		t, err := v.inspector.Inspect(filepath.Join(v.sourcesDir, exactGlob))
+		if err != nil {
+			return err
+		}
+
+		if !tile.VersionMatches(t.ProductVersion, version) {
+			problems = append(problems, fmt.Sprintf(
+				"tile '%s' has product_version: '%s'",
+				exactGlob,
+				t.ProductVersion,
+			))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf(
+			"tiles do not match release version: '%s':\n%s",
+			version,
+			strings.Join(problems, "\n"),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package release_test
+
+import (
+	"errors"
+	"log"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release/releasefakes"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TileVersionValidator", func() {
+	Describe("ValidateTileVersions", func() {
+		var (
+			fakeLogger logger.Logger
+
+			fakeTileInspector *releasefakes.FakeTileInspector
+
+			mdata      metadata.Metadata
+			exactGlobs []string
+
+			tileVersions map[string]string
+
+			tileVersionValidator release.TileVersionValidator
+		)
+
+		BeforeEach(func() {
+			logger := log.New(GinkgoWriter, "", log.LstdFlags)
+			fakeLogger = logshim.NewLogShim(logger, logger, true)
+
+			fakeTileInspector = &releasefakes.FakeTileInspector{}
+
+			mdata = metadata.Metadata{
+				Release: &metadata.Release{
+					Version: "2.10.3",
+				},
+			}
+
+			exactGlobs = []string{"tiles/cf-2.10.3.pivotal", "tiles/notes.txt", "tiles/srt-2.10.3.pivotal"}
+
+			tileVersions = map[string]string{
+				"some/sources/dir/tiles/cf-2.10.3.pivotal":  "2.10.3-build.4",
+				"some/sources/dir/tiles/srt-2.10.3.pivotal": "2.10.3",
+			}
+
+			fakeTileInspector.InspectStub = func(tilePath string) (tile.Tile, error) {
+				return tile.Tile{ProductVersion: tileVersions[tilePath]}, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			tileVersionValidator = release.NewTileVersionValidator(
+				fakeLogger,
+				fakeTileInspector,
+				"some/sources/dir",
+				mdata,
+			)
+		})
+
+		It("inspects every tile to be uploaded", func() {
+			err := tileVersionValidator.ValidateTileVersions(exactGlobs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTileInspector.InspectCallCount()).To(Equal(2))
+			Expect(fakeTileInspector.InspectArgsForCall(0)).To(Equal("some/sources/dir/tiles/cf-2.10.3.pivotal"))
+			Expect(fakeTileInspector.InspectArgsForCall(1)).To(Equal("some/sources/dir/tiles/srt-2.10.3.pivotal"))
+		})
+
+		Context("when tiles were built for another version", func() {
+			BeforeEach(func() {
+				tileVersions["some/sources/dir/tiles/cf-2.10.3.pivotal"] = "2.10.2-build.7"
+				tileVersions["some/sources/dir/tiles/srt-2.10.3.pivotal"] = "2.10.2"
+			})
+
+			It("returns one error for all of them", func() {
+				err := tileVersionValidator.ValidateTileVersions(exactGlobs)
+				Expect(err).To(HaveOccurred())
+
+				Expect(err.Error()).To(ContainSubstring("tiles do not match release version: '2.10.3'"))
+				Expect(err.Error()).To(ContainSubstring("tile 'tiles/cf-2.10.3.pivotal' has product_version: '2.10.2-build.7'"))
+				Expect(err.Error()).To(ContainSubstring("tile 'tiles/srt-2.10.3.pivotal' has product_version: '2.10.2'"))
+			})
+		})
+
+		Context("when inspecting a tile fails", func() {
+			var (
+				inspectErr error
+			)
+
+			BeforeEach(func() {
+				inspectErr = errors.New("some inspect error")
+				fakeTileInspector.InspectStub = nil
+				fakeTileInspector.InspectReturns(tile.Tile{}, inspectErr)
+			})
+
+			It("returns the error", func() {
+				err := tileVersionValidator.ValidateTileVersions(exactGlobs)
+				Expect(err).To(Equal(inspectErr))
+			})
+		})
+	})
+})
@@ -34,6 +34,7 @@ func NewUserGroupsUpdater(
 type userGroupsUpdaterClient interface {
 	UpdateRelease(productSlug string, release pivnet.Release) (pivnet.Release, error)
 	AddUserGroup(productSlug string, releaseID int, userGroupID int) error
+	ListUserGroups() ([]pivnet.UserGroup, error)
 }
 
 func (rf UserGroupsUpdater) UpdateUserGroups(release pivnet.Release) (pivnet.Release, error) {
@@ -58,14 +59,34 @@ func (rf UserGroupsUpdater) UpdateUserGroups(release pivnet.Release) (pivnet.Rel
 		}
 
 		if availability == "Selected User Groups Only" {
-			userGroupIDs := rf.metadata.Release.UserGroupIDs
-
-			for _, userGroupIDString := range userGroupIDs {
+			var userGroupIDs []int
+			for _, userGroupIDString := range rf.metadata.Release.UserGroupIDs {
 				userGroupID, err := strconv.Atoi(userGroupIDString)
 				if err != nil {
 					return pivnet.Release{}, err
 				}
 
+				userGroupIDs = append(userGroupIDs, userGroupID)
+			}
+
+			userGroupNames := rf.metadata.Release.UserGroups
+			if len(userGroupNames) > 0 {
+				rf.logger.Info("Resolving user group names")
+
+				userGroups, err := rf.pivnet.ListUserGroups()
+				if err != nil {
+					return pivnet.Release{}, err
+				}
+
+				resolvedIDs, err := resolveUserGroupIDs(userGroupNames, userGroups)
+				if err != nil {
+					return pivnet.Release{}, err
+				}
+
+				userGroupIDs = append(userGroupIDs, resolvedIDs...)
+			}
+
+			for _, userGroupID := range userGroupIDs {
 				rf.logger.Info(fmt.Sprintf(
 					"Adding user group with ID: %d",
 					userGroupID,
@@ -80,3 +101,25 @@ func (rf UserGroupsUpdater) UpdateUserGroups(release pivnet.Release) (pivnet.Rel
 
 	return release, nil
 }
+
+// resolveUserGroupIDs returns the IDs of the user groups with the provided
+// names. If any name is unknown, the returned error suggests close matches.
+func resolveUserGroupIDs(names []string, userGroups []pivnet.UserGroup) ([]int, error) {
+	userGroupIDsByName := make(map[string]int, len(userGroups))
+	userGroupNames := make([]string, len(userGroups))
+	for i, g := range userGroups {
+		userGroupIDsByName[g.Name] = g.ID
+		userGroupNames[i] = g.Name
+	}
+
+	ids := make([]int, len(names))
+	for i, name := range names {
+		id, ok := userGroupIDsByName[name]
+		if !ok {
+			return nil, notFoundError("user group", name, userGroupNames)
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
@@ -120,6 +120,59 @@ var _ = Describe("UserGroupsUpdater", func() {
 				Expect(response.Version).To(Equal("another-version"))
 			})
 
+			Context("when user groups are provided by name", func() {
+				BeforeEach(func() {
+					mdata.Release.UserGroupIDs = []string{"111"}
+					mdata.Release.UserGroups = []string{"Some Partners", "Beta Testers"}
+
+					pivnetClient.ListUserGroupsReturns([]pivnet.UserGroup{
+						{ID: 333, Name: "Beta Testers"},
+						{ID: 444, Name: "Some Partners"},
+					}, nil)
+				})
+
+				It("resolves the names and adds the user groups", func() {
+					_, err := userGroupsUpdater.UpdateUserGroups(pivnetRelease)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(pivnetClient.ListUserGroupsCallCount()).To(Equal(1))
+					Expect(pivnetClient.AddUserGroupCallCount()).To(Equal(3))
+
+					_, _, userGroupID := pivnetClient.AddUserGroupArgsForCall(0)
+					Expect(userGroupID).To(Equal(111))
+
+					_, _, userGroupID = pivnetClient.AddUserGroupArgsForCall(1)
+					Expect(userGroupID).To(Equal(444))
+
+					_, _, userGroupID = pivnetClient.AddUserGroupArgsForCall(2)
+					Expect(userGroupID).To(Equal(333))
+				})
+
+				Context("when a user group name is unknown", func() {
+					BeforeEach(func() {
+						mdata.Release.UserGroups = []string{"Beta Tester"}
+					})
+
+					It("returns an error with close matches", func() {
+						_, err := userGroupsUpdater.UpdateUserGroups(pivnetRelease)
+						Expect(err).To(MatchError("provided user group: 'Beta Tester' not found - did you mean one of: ['Beta Testers']"))
+
+						Expect(pivnetClient.AddUserGroupCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when listing user groups fails", func() {
+					BeforeEach(func() {
+						pivnetClient.ListUserGroupsReturns(nil, errors.New("failed to list user groups"))
+					})
+
+					It("returns an error", func() {
+						_, err := userGroupsUpdater.UpdateUserGroups(pivnetRelease)
+						Expect(err).To(MatchError(errors.New("failed to list user groups")))
+					})
+				})
+			})
+
 			Context("when an error occurs", func() {
 				Context("when a user group ID cannpt be converted to a number", func() {
 					BeforeEach(func() {
@@ -0,0 +1,144 @@
+package redact
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+)
+
+// signedURLParams matches the query parameters that authorize presigned S3
+// and CloudFront URLs, so that download URLs are redacted even when they were
+// not added to a registry, e.g. after a redirect.
+var signedURLParams = regexp.MustCompile(
+	`(?i)\b(X-Amz-Signature|X-Amz-Credential|X-Amz-Security-Token|Signature|AWSAccessKeyId|Policy|Key-Pair-Id)=[^&\s"'<>]+`,
+)
+
+// Registry holds every secret known to the resource, so that they can be
+// redacted from all log and error output. Steps that obtain a secret, e.g. a
+// token exchange, add it to the registry as soon as it is known.
+type Registry struct {
+	mu      sync.RWMutex
+	secrets map[string]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		secrets: map[string]string{},
+	}
+}
+
+// Add registers a secret. It is replaced by '***REDACTED-<name>***'. Empty
+// secrets are ignored.
+func (r *Registry) Add(name string, secret string) {
+	if secret == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.secrets[secret] = fmt.Sprintf("***REDACTED-%s***", name)
+}
+
+// Redact replaces every registered secret, and the signatures of presigned
+// URLs, in s.
+func (r *Registry) Redact(s string) string {
+	r.mu.RLock()
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+
+	// Longer secrets first, in case one secret contains another
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	for _, secret := range secrets {
+		s = strings.Replace(s, secret, r.secrets[secret], -1)
+	}
+	r.mu.RUnlock()
+
+	return signedURLParams.ReplaceAllString(s, "$1=***REDACTED***")
+}
+
+// Writer returns a writer that redacts everything written to w.
+func (r *Registry) Writer(w io.Writer) io.Writer {
+	return writer{registry: r, sink: w}
+}
+
+type writer struct {
+	registry *Registry
+	sink     io.Writer
+}
+
+func (w writer) Write(p []byte) (int, error) {
+	_, err := w.sink.Write([]byte(w.registry.Redact(string(p))))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Error returns err with every secret redacted from its message.
+func (r *Registry) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	redacted := r.Redact(err.Error())
+	if redacted == err.Error() {
+		return err
+	}
+	return redactedError(redacted)
+}
+
+type redactedError string
+
+func (e redactedError) Error() string {
+	return string(e)
+}
+
+// TokenService adds every access token obtained by s, e.g. by exchanging a
+// refresh token, to the registry.
+func (r *Registry) TokenService(s pivnet.AccessTokenService) pivnet.AccessTokenService {
+	return tokenService{registry: r, tokenService: s}
+}
+
+type tokenService struct {
+	registry     *Registry
+	tokenService pivnet.AccessTokenService
+}
+
+func (s tokenService) AccessToken() (string, error) {
+	token, err := s.tokenService.AccessToken()
+	s.registry.Add("PIVNET_ACCESS_TOKEN", token)
+	return token, s.registry.Error(err)
+}
+
+// Transport adds the URLs that Pivnet redirects to, e.g. presigned download
+// URLs, to the registry.
+func (r *Registry) Transport(t http.RoundTripper) http.RoundTripper {
+	return transport{registry: r, transport: t}
+}
+
+type transport struct {
+	registry  *Registry
+	transport http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	t.registry.Add("DOWNLOAD_URL", resp.Header.Get("Location"))
+	return resp, nil
+}
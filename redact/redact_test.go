@@ -0,0 +1,136 @@
+package redact_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/pivotal-cf/go-pivnet/v7/go-pivnetfakes"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *redact.Registry
+
+	BeforeEach(func() {
+		registry = redact.NewRegistry()
+	})
+
+	It("redacts every registered secret", func() {
+		registry.Add("PIVNET_API_TOKEN", "some-refresh-token")
+		registry.Add("AWS_SECRET_ACCESS_KEY", "some-secret-access-key")
+		registry.Add("AWS_SESSION_TOKEN", "some-session-token")
+
+		Expect(registry.Redact(
+			"token: some-refresh-token, key: some-secret-access-key, session: some-session-token",
+		)).To(Equal(
+			"token: ***REDACTED-PIVNET_API_TOKEN***, key: ***REDACTED-AWS_SECRET_ACCESS_KEY***, session: ***REDACTED-AWS_SESSION_TOKEN***",
+		))
+	})
+
+	It("redacts longer secrets first", func() {
+		registry.Add("SHORT", "secret")
+		registry.Add("LONG", "secret-and-more")
+
+		Expect(registry.Redact("secret-and-more secret")).To(Equal("***REDACTED-LONG*** ***REDACTED-SHORT***"))
+	})
+
+	It("ignores empty secrets", func() {
+		registry.Add("EMPTY", "")
+		Expect(registry.Redact("nothing to hide")).To(Equal("nothing to hide"))
+	})
+
+	It("redacts the signatures of presigned URLs", func() {
+		s3URL := "https://bucket.s3.amazonaws.com/file?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=AKIA%2F20200101&X-Amz-Security-Token=some-token&X-Amz-Signature=abc123"
+		cloudFrontURL := "https://d1.cloudfront.net/file?Expires=1&Signature=abc~123&Key-Pair-Id=APKA"
+
+		Expect(registry.Redact(`Head "` + s3URL + `": timeout`)).To(Equal(
+			`Head "https://bucket.s3.amazonaws.com/file?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=***REDACTED***&X-Amz-Security-Token=***REDACTED***&X-Amz-Signature=***REDACTED***": timeout`,
+		))
+		Expect(registry.Redact(cloudFrontURL)).To(Equal(
+			"https://d1.cloudfront.net/file?Expires=1&Signature=***REDACTED***&Key-Pair-Id=***REDACTED***",
+		))
+	})
+
+	Describe("Writer", func() {
+		It("redacts everything written", func() {
+			buffer := &bytes.Buffer{}
+			registry.Add("PIVNET_API_TOKEN", "some-refresh-token")
+
+			logger := log.New(registry.Writer(buffer), "", 0)
+			logger.Printf("using token: %s", "some-refresh-token")
+
+			Expect(buffer.String()).To(Equal("using token: ***REDACTED-PIVNET_API_TOKEN***\n"))
+		})
+
+		It("redacts secrets added after it was created", func() {
+			buffer := &bytes.Buffer{}
+			w := registry.Writer(buffer)
+
+			registry.Add("AWS_SESSION_TOKEN", "some-session-token")
+			fmt.Fprint(w, "some-session-token")
+
+			Expect(buffer.String()).To(Equal("***REDACTED-AWS_SESSION_TOKEN***"))
+		})
+	})
+
+	Describe("Error", func() {
+		It("redacts the message of the error", func() {
+			registry.Add("AWS_SECRET_ACCESS_KEY", "some-secret-access-key")
+
+			err := registry.Error(errors.New("signature mismatch for some-secret-access-key"))
+			Expect(err).To(MatchError("signature mismatch for ***REDACTED-AWS_SECRET_ACCESS_KEY***"))
+		})
+
+		It("returns errors without secrets as they are", func() {
+			original := errors.New("not found")
+			Expect(registry.Error(original)).To(BeIdenticalTo(original))
+			Expect(registry.Error(nil)).To(BeNil())
+		})
+	})
+
+	Describe("TokenService", func() {
+		It("adds the access token obtained", func() {
+			fakeTokenService := &gopivnetfakes.FakeAccessTokenService{}
+			fakeTokenService.AccessTokenReturns("some-access-token", nil)
+
+			token, err := registry.TokenService(fakeTokenService).AccessToken()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("some-access-token"))
+
+			Expect(registry.Redact("Authorization: Bearer some-access-token")).To(Equal(
+				"Authorization: Bearer ***REDACTED-PIVNET_ACCESS_TOKEN***",
+			))
+		})
+	})
+
+	Describe("Transport", func() {
+		It("adds the URLs redirected to", func() {
+			downloadURL := "https://downloads.example.com/file?token=some-download-token"
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				http.Redirect(w, req, downloadURL, http.StatusFound)
+			}))
+			defer server.Close()
+
+			client := &http.Client{
+				Transport: registry.Transport(http.DefaultTransport),
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			}
+
+			resp, err := client.Post(server.URL, "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(registry.Redact("downloading " + downloadURL)).To(Equal("downloading ***REDACTED-DOWNLOAD_URL***"))
+		})
+	})
+})
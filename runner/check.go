@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"github.com/pivotal-cf/pivnet-resource/v3/cache"
+	"github.com/pivotal-cf/pivnet-resource/v3/check"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/filter"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/sorter"
+	"github.com/pivotal-cf/pivnet-resource/v3/specifier"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
+	"github.com/pivotal-cf/pivnet-resource/v3/validator"
+)
+
+// Check runs check. Other log files in the directory of logFilePath are
+// removed, and the release cache is kept in cacheDir.
+func (r Runner) Check(input concourse.CheckRequest, logFilePath string, cacheDir string) (concourse.CheckResponse, error) {
+	ls := r.newLogger(input.Source, "check", false)
+
+	err := validator.NewCheckValidator(input).Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := transport.New(input.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.newClient(input.Source, "check", ls, t)
+
+	f := filter.NewFilter(ls)
+
+	semverConverter, err := semver.NewNormalizingSemverConverter(
+		ls,
+		input.Source.VersionNormalization,
+		input.Source.VersionPattern,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := sorter.NewSorter(ls, semverConverter)
+
+	return check.NewCheckCommand(
+		ls,
+		r.version,
+		f,
+		client,
+		s,
+		specifier.NewMatcher(semverConverter),
+		cache.NewFileCache(cacheDir, ls),
+		logFilePath,
+	).Run(input)
+}
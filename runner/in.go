@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"os"
+
+	"github.com/pivotal-cf/go-pivnet/v7/md5sum"
+	"github.com/pivotal-cf/go-pivnet/v7/sha256sum"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/downloader"
+	"github.com/pivotal-cf/pivnet-resource/v3/filter"
+	"github.com/pivotal-cf/pivnet-resource/v3/in"
+	"github.com/pivotal-cf/pivnet-resource/v3/in/filesystem"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/sorter"
+	"github.com/pivotal-cf/pivnet-resource/v3/specifier"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
+	"github.com/pivotal-cf/pivnet-resource/v3/validator"
+)
+
+// In runs in, downloading into downloadDir, which is created if necessary.
+func (r Runner) In(input concourse.InRequest, downloadDir string) (concourse.InResponse, error) {
+	ls := r.newLogger(input.Source, "get", input.Source.Verbose)
+
+	ls.Debug("Verbose output enabled")
+	r.logger.Printf("Creating download directory: %s", downloadDir)
+
+	err := os.MkdirAll(downloadDir, os.ModePerm)
+	if err != nil {
+		return concourse.InResponse{}, err
+	}
+
+	err = validator.NewInValidator(input).Validate()
+	if err != nil {
+		return concourse.InResponse{}, err
+	}
+
+	r.printTokenDeprecation(input.Source, ls)
+
+	t, err := transport.New(input.Source)
+	if err != nil {
+		return concourse.InResponse{}, err
+	}
+
+	client := r.newClient(input.Source, "get", ls, t)
+
+	d := downloader.NewDownloader(client, downloadDir, ls, r.outputWriter(input.Source))
+
+	fs := sha256sum.NewFileSummer()
+	md5fs := md5sum.NewFileSummer()
+
+	f := filter.NewFilter(ls)
+
+	fileWriter := filesystem.NewFileWriter(downloadDir, ls)
+	archive := &in.Archive{}
+
+	semverConverter, err := semver.NewNormalizingSemverConverter(
+		ls,
+		input.Source.VersionNormalization,
+		input.Source.VersionPattern,
+	)
+	if err != nil {
+		return concourse.InResponse{}, err
+	}
+
+	dependencyResolver := in.NewDependencyResolver(
+		ls,
+		client,
+		specifier.NewMatcher(semverConverter),
+		sorter.NewSorter(ls, semverConverter),
+	)
+
+	return in.NewInCommand(
+		ls,
+		client,
+		f,
+		d,
+		fs,
+		md5fs,
+		fileWriter,
+		archive,
+		dependencyResolver,
+		upgradegraph.NewBuilder(ls, client, semverConverter),
+		tile.NewInspector(ls),
+	).Run(input)
+}
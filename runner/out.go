@@ -0,0 +1,318 @@
+package runner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/go-pivnet/v7/md5sum"
+	"github.com/pivotal-cf/go-pivnet/v7/sha256sum"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/deriver"
+	"github.com/pivotal-cf/pivnet-resource/v3/filter"
+	"github.com/pivotal-cf/pivnet-resource/v3/globs"
+	"github.com/pivotal-cf/pivnet-resource/v3/metadata"
+	"github.com/pivotal-cf/pivnet-resource/v3/out"
+	"github.com/pivotal-cf/pivnet-resource/v3/out/release"
+	"github.com/pivotal-cf/pivnet-resource/v3/s3"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/specifier"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
+	"github.com/pivotal-cf/pivnet-resource/v3/uploader"
+	"github.com/pivotal-cf/pivnet-resource/v3/validator"
+)
+
+// Out runs out, uploading files from sourcesDir. outDir is the directory of
+// the out binary.
+func (r Runner) Out(input concourse.OutRequest, sourcesDir string, outDir string) (concourse.OutResponse, error) {
+	ls := r.newLogger(input.Source, "put", input.Source.Verbose)
+	ls.Debug("Verbose output enabled")
+
+	var m metadata.Metadata
+	if input.Params.MetadataFile == "" {
+		return concourse.OutResponse{}, fmt.Errorf("params.metadata_file must be provided")
+	}
+
+	metadataFilepath := filepath.Join(sourcesDir, input.Params.MetadataFile)
+	metadataBytes, err := ioutil.ReadFile(metadataFilepath)
+	if err != nil {
+		return concourse.OutResponse{}, fmt.Errorf("params.metadata_file could not be read: %s", err.Error())
+	}
+
+	if input.Params.RenderMetadataFile {
+		renderer := metadata.NewRenderer(sourcesDir, time.Now())
+		metadataBytes, err = renderer.Render(input.Params.MetadataFile, metadataBytes)
+		if err != nil {
+			return concourse.OutResponse{}, fmt.Errorf("params.metadata_file could not be rendered: %s", err.Error())
+		}
+	}
+
+	m, err = metadata.Decode(metadataBytes)
+	if err != nil {
+		return concourse.OutResponse{}, fmt.Errorf("params.metadata_file could not be parsed: %s", err.Error())
+	}
+
+	globber := globs.NewGlobber(globs.GlobberConfig{
+		FileGlob:   input.Params.FileGlob,
+		SourcesDir: sourcesDir,
+		Logger:     ls,
+	})
+
+	skipUpload := input.Params.FileGlob == ""
+
+	if input.Params.MigrateMetadata {
+		var notes []string
+		m, notes, err = m.Migrate(input.Params.DropReadOnlyMetadata)
+		if err != nil {
+			return concourse.OutResponse{}, fmt.Errorf("params.metadata_file could not be migrated: %s", err.Error())
+		}
+
+		for _, note := range notes {
+			ls.Info(note)
+		}
+
+		migrated, err := yaml.Marshal(m)
+		if err != nil {
+			return concourse.OutResponse{}, err
+		}
+		r.logger.Printf("Migrated metadata:\n%s", migrated)
+	}
+
+	if input.Params.DeriveMetadata {
+		var exactGlobs []string
+		if !skipUpload {
+			exactGlobs, err = globber.ExactGlobs()
+			if err != nil {
+				return concourse.OutResponse{}, err
+			}
+		}
+
+		d := deriver.NewDeriver(ls, tile.NewInspector(ls), sourcesDir, input.Params.VersionFile)
+		m, err = d.Derive(m, exactGlobs)
+		if err != nil {
+			return concourse.OutResponse{}, fmt.Errorf("params.metadata_file could not be derived: %s", err.Error())
+		}
+
+		effective, err := yaml.Marshal(m)
+		if err != nil {
+			return concourse.OutResponse{}, err
+		}
+		r.logger.Printf("Effective metadata:\n%s", effective)
+	}
+
+	deprecations, err := m.Validate()
+	if err != nil {
+		return concourse.OutResponse{}, fmt.Errorf("params.metadata_file is invalid: %s", err.Error())
+	}
+
+	r.printTokenDeprecation(input.Source, ls)
+
+	t, err := transport.New(input.Source)
+	if err != nil {
+		return concourse.OutResponse{}, err
+	}
+
+	client := r.newClient(input.Source, "put", ls, t)
+
+	federationToken, err := client.GetFederationToken(input.Source.ProductSlug)
+	if err != nil {
+		return concourse.OutResponse{}, fmt.Errorf("Unable to generate Federation Token")
+	}
+
+	r.registry.Add("AWS_ACCESS_KEY_ID", federationToken.AccessKeyID)
+	r.registry.Add("AWS_SECRET_ACCESS_KEY", federationToken.SecretAccessKey)
+	r.registry.Add("AWS_SESSION_TOKEN", federationToken.SessionToken)
+
+	s3Client := s3.NewClient(s3.NewClientConfig{
+		AccessKeyID:       federationToken.AccessKeyID,
+		SecretAccessKey:   federationToken.SecretAccessKey,
+		SessionToken:      federationToken.SessionToken,
+		RegionName:        federationToken.Region,
+		Bucket:            federationToken.Bucket,
+		Stderr:            r.outputWriter(input.Source),
+		Logger:            ls,
+		SkipSSLValidation: input.Source.SkipSSLValidation,
+		FileSizeGetter:    s3.FileSizeGetter{},
+		HTTPClient:        &http.Client{Transport: t},
+	})
+
+	prefixFetcher := uploader.NewPrefixFetcher(client, input.Source.ProductSlug)
+	filePrefix, err := prefixFetcher.GetPrefix()
+	if err != nil {
+		return concourse.OutResponse{}, fmt.Errorf("Could not find product prefix")
+	}
+
+	uploaderClient := uploader.NewClient(uploader.Config{
+		FilepathPrefix: filePrefix,
+		SourcesDir:     sourcesDir,
+		Transport:      s3Client,
+	})
+
+	for _, deprecation := range deprecations {
+		r.printDeprecation(input.Source, ls, deprecation)
+	}
+
+	validation := validator.NewOutValidator(input)
+	semverConverter, err := semver.NewNormalizingSemverConverter(
+		ls,
+		input.Source.VersionNormalization,
+		input.Source.VersionPattern,
+	)
+	if err != nil {
+		return concourse.OutResponse{}, err
+	}
+
+	sha256Summer := sha256sum.NewFileSummer()
+	md5summer := md5sum.NewFileSummer()
+
+	f := filter.NewFilter(ls)
+
+	releaseCreator := release.NewReleaseCreator(
+		client,
+		ls,
+		m,
+		input.Params,
+		input.Source,
+		sourcesDir,
+		input.Source.ProductSlug,
+	)
+
+	releaseFinder := release.NewReleaseFinder(
+		client,
+		input.Source.ProductSlug,
+	)
+
+	asyncTimeout := 1 * time.Hour
+	pollFrequency := 5 * time.Second
+	releaseUploader := release.NewReleaseUploader(
+		uploaderClient,
+		client,
+		ls,
+		sha256Summer,
+		md5summer,
+		m,
+		sourcesDir,
+		input.Source.ProductSlug,
+		asyncTimeout,
+		pollFrequency,
+		input.Params.SkipProductFilePolling,
+	)
+
+	releaseUserGroupsUpdater := release.NewUserGroupsUpdater(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+	)
+
+	releaseFileGroupsAdder := release.NewReleaseFileGroupsAdder(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+	)
+
+	releaseArtifactReferencesAdder := release.NewReleaseArtifactReferencesAdder(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+		5*time.Second,
+		time.Hour,
+	)
+
+	releaseDependenciesAdder := release.NewReleaseDependenciesAdder(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+	)
+
+	specifiersValidator := release.NewSpecifiersValidator(
+		ls,
+		client,
+		specifier.NewMatcher(semverConverter),
+		m,
+		input.Source.ProductSlug,
+	)
+
+	preflightValidator := release.NewPreflightValidator(
+		ls,
+		client,
+		semverConverter,
+		m,
+		input.Params,
+		input.Source,
+		input.Source.ProductSlug,
+	)
+
+	tileVersionValidator := release.NewTileVersionValidator(
+		ls,
+		tile.NewInspector(ls),
+		sourcesDir,
+		m,
+	)
+
+	dependencySpecifiersCreator := release.NewDependencySpecifiersCreator(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+	)
+
+	releaseUpgradePathsAdder := release.NewReleaseUpgradePathsAdder(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+		f,
+	)
+
+	upgradePathSpecifiersCreator := release.NewUpgradePathSpecifiersCreator(
+		ls,
+		client,
+		m,
+		input.Source.ProductSlug,
+	)
+
+	releaseFinalizer := release.NewFinalizer(
+		client,
+		ls,
+		input.Params,
+		m,
+		sourcesDir,
+		input.Source.ProductSlug,
+	)
+
+	outCmd := out.NewOutCommand(out.OutCommandConfig{
+		Logger:                         ls,
+		OutDir:                         outDir,
+		SourcesDir:                     sourcesDir,
+		GlobClient:                     globber,
+		Validation:                     validation,
+		Creator:                        releaseCreator,
+		Finder:                         releaseFinder,
+		Uploader:                       releaseUploader,
+		UserGroupsUpdater:              releaseUserGroupsUpdater,
+		ReleaseFileGroupsAdder:         releaseFileGroupsAdder,
+		ReleaseArtifactReferencesAdder: releaseArtifactReferencesAdder,
+		ReleaseDependenciesAdder:       releaseDependenciesAdder,
+		SpecifiersValidator:            specifiersValidator,
+		PreflightValidator:             preflightValidator,
+		TileVersionValidator:           tileVersionValidator,
+		DependencySpecifiersCreator:    dependencySpecifiersCreator,
+		ReleaseUpgradePathsAdder:       releaseUpgradePathsAdder,
+		UpgradePathSpecifiersCreator:   upgradePathSpecifiersCreator,
+		Finalizer:                      releaseFinalizer,
+		M:                              m,
+		SkipUpload:                     skipUpload,
+		FilesOnly:                      m.ExistingRelease != nil,
+		VerifyTileVersion:              input.Params.VerifyTileVersion,
+	})
+	return outCmd.Run(input)
+}
@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// ReadRequest reads a check, in or out request from a YAML or JSON file with
+// the same structure as the JSON sent by Concourse, e.g. with 'source' and
+// 'params' keys. Unknown keys are rejected.
+func ReadRequest(path string, request interface{}) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s could not be read: %s", path, err.Error())
+	}
+
+	var decoded interface{}
+	err = yaml3.Unmarshal(contents, &decoded)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed: %s", path, err.Error())
+	}
+
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed: %s", path, err.Error())
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(request)
+	if err != nil {
+		return fmt.Errorf("%s could not be parsed: %s", path, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/gp"
+	"github.com/pivotal-cf/pivnet-resource/v3/jsonlog"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
+	"github.com/pivotal-cf/pivnet-resource/v3/useragent"
+)
+
+// Runner builds check, in and out with everything they depend on and runs
+// them, so that the Concourse entrypoints and the pivnet-resource CLI are
+// wired the same way. Errors are returned rather than exiting.
+type Runner struct {
+	version       string
+	logger        *log.Logger
+	logWriter     io.Writer
+	uiPrinter     *ui.UIPrinter
+	registry      *redact.Registry
+	correlationID string
+}
+
+// NewRunner returns a Runner that logs to logWriter through logger, and
+// prints deprecations with uiPrinter. Every secret obtained is added to
+// registry, which everything written to logWriter passes through. Every run
+// gets a new correlation ID, which is logged and sent to Pivnet.
+func NewRunner(
+	version string,
+	logger *log.Logger,
+	logWriter io.Writer,
+	uiPrinter *ui.UIPrinter,
+	registry *redact.Registry,
+) Runner {
+	return Runner{
+		version:       version,
+		logger:        logger,
+		logWriter:     registry.Writer(logWriter),
+		uiPrinter:     uiPrinter,
+		registry:      registry,
+		correlationID: jsonlog.NewCorrelationID(),
+	}
+}
+
+// newLogger returns the logger for the log_format of the source. Lines
+// written to the log.Logger of the runner are also written in that format.
+func (r Runner) newLogger(source concourse.Source, command string, verbose bool) logger.Logger {
+	r.registry.Add("PIVNET_API_TOKEN", source.APIToken)
+	r.registry.Add("CLIENT_KEY", source.ClientKey)
+	r.registry.Add("HTTP_PROXY_PASSWORD", proxyPassword(source.HTTPProxy))
+	r.registry.Add("HTTPS_PROXY_PASSWORD", proxyPassword(source.HTTPSProxy))
+	w := r.logWriter
+
+	if source.LogFormat == concourse.LogFormatJSON {
+		jsonLogger := jsonlog.NewLogger(w, verbose, jsonlog.Fields{
+			Command:       command,
+			ProductSlug:   source.ProductSlug,
+			CorrelationID: r.correlationID,
+			Build:         useragent.CurrentBuild(),
+		}, time.Now)
+
+		r.logger.SetOutput(jsonLogger)
+		r.logger.SetFlags(0)
+		return jsonLogger
+	}
+
+	r.logger.SetOutput(w)
+	r.logger.Printf("Correlation ID: %s", r.correlationID)
+	return logshim.NewLogShim(r.logger, r.logger, verbose)
+}
+
+// proxyPassword returns the password of a proxy setting, if any.
+func proxyPassword(proxy string) string {
+	if proxy == "" {
+		return ""
+	}
+
+	u, err := transport.ProxyURL(proxy)
+	if err != nil {
+		return ""
+	}
+
+	password, _ := u.User.Password()
+	return password
+}
+
+// newClient returns a client that makes every request, including the token
+// exchange and downloads, with t.
+func (r Runner) newClient(source concourse.Source, command string, ls logger.Logger, t http.RoundTripper) *gp.Client {
+	var endpoint string
+	if source.Endpoint != "" {
+		endpoint = source.Endpoint
+	} else {
+		endpoint = pivnet.DefaultHost
+	}
+
+	token := gp.NewAccessTokenOrLegacyToken(source.APIToken, endpoint, t, "Pivnet Resource")
+
+	client := NewPivnetClientWithToken(
+		r.registry.TokenService(token),
+		endpoint,
+		source.SkipSSLValidation,
+		useragent.UserAgent(r.version, command, source.ProductSlug),
+		ls,
+	)
+	client.UseTransport(t)
+	client.AddHeader(jsonlog.CorrelationIDHeader, r.correlationID)
+	client.WrapTransport(r.registry.Transport)
+
+	return client
+}
+
+// outputWriter returns the writer for the raw output of downloads and
+// uploads, e.g. progress bars. It cannot be logged as JSON lines, so it is
+// discarded with the json log_format.
+func (r Runner) outputWriter(source concourse.Source) io.Writer {
+	if source.LogFormat == concourse.LogFormatJSON {
+		return ioutil.Discard
+	}
+
+	return r.logWriter
+}
+
+// printDeprecation prints a deprecation with the UI printer, or logs it with
+// the json log_format.
+func (r Runner) printDeprecation(source concourse.Source, ls logger.Logger, deprecation string) {
+	if source.LogFormat == concourse.LogFormatJSON {
+		ls.Info("WARNING: " + deprecation)
+		return
+	}
+
+	r.uiPrinter.PrintDeprecationln(deprecation)
+}
+
+func (r Runner) printTokenDeprecation(source concourse.Source, ls logger.Logger) {
+	if len(source.APIToken) < 20 {
+		r.printDeprecation(source, ls, "The use of static Pivnet API tokens is deprecated and will be removed. Please see https://network.pivotal.io/docs/api#how-to-authenticate for details.")
+	}
+}
+
+func NewPivnetClientWithToken(token pivnet.AccessTokenService, host string, skipSSLValidation bool, userAgent string, logger logger.Logger) *gp.Client {
+	clientConfig := pivnet.ClientConfig{
+		Host:              host,
+		UserAgent:         userAgent,
+		SkipSSLValidation: skipSSLValidation,
+	}
+
+	return gp.NewClient(
+		token,
+		clientConfig,
+		logger,
+	)
+}
@@ -0,0 +1,446 @@
+package runner_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/redact"
+	"github.com/pivotal-cf/pivnet-resource/v3/runner"
+	"github.com/pivotal-cf/pivnet-resource/v3/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runner", func() {
+	var (
+		tempDir   string
+		logWriter *bytes.Buffer
+		logger    *log.Logger
+
+		source concourse.Source
+
+		registry  *redact.Registry
+		uiPrinter *ui.UIPrinter
+
+		r runner.Runner
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "pivnet-resource-runner")
+		Expect(err).NotTo(HaveOccurred())
+
+		logWriter = &bytes.Buffer{}
+		logger = log.New(logWriter, "", 0)
+
+		source = concourse.Source{
+			APIToken:    "some-api-token-that-is-long-enough",
+			ProductSlug: "some-product",
+		}
+
+		registry = redact.NewRegistry()
+		uiPrinter = ui.NewUIPrinter(registry.Writer(logWriter))
+		r = runner.NewRunner("some-version", logger, logWriter, uiPrinter, registry)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	Describe("Check", func() {
+		It("returns validation errors", func() {
+			source.ProductSlug = ""
+
+			_, err := r.Check(concourse.CheckRequest{Source: source}, filepath.Join(tempDir, "check.log"), filepath.Join(tempDir, "cache"))
+			Expect(err).To(MatchError("product_slug must be provided"))
+		})
+
+		It("sends the correlation ID to Pivnet", func() {
+			var correlationIDs []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				correlationIDs = append(correlationIDs, req.Header.Get("X-Correlation-ID"))
+				w.Write([]byte(`{"releases": [{"id": 1, "version": "1.0.0"}]}`))
+			}))
+			defer server.Close()
+
+			source.APIToken = "legacy-token"
+			source.Endpoint = server.URL
+
+			_, err := r.Check(concourse.CheckRequest{Source: source}, filepath.Join(tempDir, "check.log"), filepath.Join(tempDir, "cache"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(correlationIDs).NotTo(BeEmpty())
+			Expect(correlationIDs[0]).NotTo(BeEmpty())
+			Expect(logWriter.String()).To(ContainSubstring("Correlation ID: " + correlationIDs[0]))
+		})
+
+		It("exchanges the token and calls Pivnet trusting ca_certs", func() {
+			var paths []string
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				paths = append(paths, req.URL.Path)
+				if req.URL.Path == "/api/v2/authentication/access_tokens" {
+					w.Write([]byte(`{"access_token": "some-access-token-that-is-long-enough"}`))
+					return
+				}
+				w.Write([]byte(`{"releases": [{"id": 1, "version": "1.0.0"}]}`))
+			}))
+			defer server.Close()
+
+			source.Endpoint = server.URL
+			source.CACerts = string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: server.Certificate().Raw,
+			}))
+
+			_, err := r.Check(concourse.CheckRequest{Source: source}, filepath.Join(tempDir, "check.log"), filepath.Join(tempDir, "cache"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(paths).To(ContainElement("/api/v2/authentication/access_tokens"))
+			Expect(paths).To(ContainElement("/api/v2/products/some-product/releases"))
+		})
+
+		It("keeps the cache in the cache directory, independently of the log file", func() {
+			releaseRequests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/api/v2/products/some-product/releases" {
+					releaseRequests++
+				}
+				w.Write([]byte(`{"releases": [{"id": 1, "version": "1.0.0"}]}`))
+			}))
+			defer server.Close()
+
+			source.APIToken = "legacy-token"
+			source.Endpoint = server.URL
+			source.CacheTTL = "5m"
+
+			cacheDir := filepath.Join(tempDir, "cache")
+			for _, logDir := range []string{"first", "second"} {
+				Expect(os.Mkdir(filepath.Join(tempDir, logDir), os.ModePerm)).To(Succeed())
+
+				_, err := r.Check(concourse.CheckRequest{Source: source}, filepath.Join(tempDir, logDir, "check.log"), cacheDir)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(releaseRequests).To(Equal(1))
+			Expect(cacheDir).To(BeADirectory())
+		})
+
+		It("redacts the client key", func() {
+			source.ClientCert = "some-client-cert"
+			source.ClientKey = "some-client-key"
+
+			_, err := r.Check(concourse.CheckRequest{Source: source}, filepath.Join(tempDir, "check.log"), filepath.Join(tempDir, "cache"))
+			Expect(err).To(MatchError(ContainSubstring("client_cert and client_key are invalid")))
+
+			logger.Printf("key: %s", source.ClientKey)
+			Expect(logWriter.String()).NotTo(ContainSubstring(source.ClientKey))
+		})
+	})
+
+	Context("when the log format is json", func() {
+		BeforeEach(func() {
+			source.LogFormat = concourse.LogFormatJSON
+		})
+
+		It("writes every line as JSON", func() {
+			downloadDir := filepath.Join(tempDir, "download")
+
+			_, err := r.In(concourse.InRequest{Source: source}, downloadDir)
+			Expect(err).To(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(logWriter.String()), "\n")
+			Expect(lines).NotTo(BeEmpty())
+
+			var line map[string]interface{}
+			Expect(json.Unmarshal([]byte(lines[0]), &line)).To(Succeed())
+			Expect(line).To(HaveKeyWithValue("message", "Creating download directory: "+downloadDir))
+			Expect(line).To(HaveKeyWithValue("command", "get"))
+			Expect(line).To(HaveKeyWithValue("product_slug", "some-product"))
+			Expect(line).To(HaveKey("correlation_id"))
+		})
+		It("writes every line of a download as JSON", func() {
+			var unexpected []string
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/api/v2/products/some-product/releases":
+					w.Write([]byte(`{"releases": [{"id": 1, "version": "1.0.0"}]}`))
+				case "/api/v2/products/some-product/releases/1":
+					w.Write([]byte(`{"id": 1, "version": "1.0.0", "eula": {"slug": "some-eula"}}`))
+				case "/api/v2/products/some-product/releases/1/pivnet_resource_eula_acceptance":
+					w.Write([]byte(`{}`))
+				case "/api/v2/products/some-product/releases/1/product_files",
+					"/api/v2/products/some-product/releases/1/product_files/10":
+					productFile := fmt.Sprintf(`{
+						"id": 10,
+						"name": "Some File",
+						"aws_object_key": "product-files/some-file.txt",
+						"_links": {"download": {"href": "%s/api/v2/products/some-product/releases/1/product_files/10/download"}}
+					}`, server.URL)
+					if strings.HasSuffix(req.URL.Path, "/10") {
+						w.Write([]byte(`{"product_file": ` + productFile + `}`))
+					} else {
+						w.Write([]byte(`{"product_files": [` + productFile + `]}`))
+					}
+				case "/api/v2/products/some-product/releases/1/file_groups":
+					w.Write([]byte(`{"file_groups": []}`))
+				case "/api/v2/products/some-product/releases/1/artifact_references":
+					w.Write([]byte(`{"artifact_references": []}`))
+				case "/api/v2/products/some-product/releases/1/dependencies":
+					w.Write([]byte(`{"dependencies": []}`))
+				case "/api/v2/products/some-product/releases/1/upgrade_paths":
+					w.Write([]byte(`{"upgrade_paths": []}`))
+				case "/api/v2/products/some-product/releases/1/dependency_specifiers":
+					w.Write([]byte(`{"dependency_specifiers": []}`))
+				case "/api/v2/products/some-product/releases/1/upgrade_path_specifiers":
+					w.Write([]byte(`{"upgrade_path_specifiers": []}`))
+				case "/api/v2/products/some-product/releases/1/product_files/10/download":
+					w.Header().Set("Location", server.URL+"/files/some-file.txt")
+					w.WriteHeader(http.StatusFound)
+				case "/files/some-file.txt":
+					http.ServeContent(w, req, "some-file.txt", time.Time{}, strings.NewReader("some file contents"))
+				default:
+					unexpected = append(unexpected, req.Method+" "+req.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			source.APIToken = "legacy-token"
+			source.Endpoint = server.URL
+
+			downloadDir := filepath.Join(tempDir, "download")
+			_, err := r.In(concourse.InRequest{
+				Source:  source,
+				Version: concourse.Version{ProductVersion: "1.0.0"},
+			}, downloadDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unexpected).To(BeEmpty())
+
+			Expect(filepath.Join(downloadDir, "some-file.txt")).To(BeARegularFile())
+
+			for _, line := range strings.Split(strings.TrimSpace(logWriter.String()), "\n") {
+				var decoded map[string]interface{}
+				Expect(json.Unmarshal([]byte(line), &decoded)).To(Succeed(), line)
+			}
+			Expect(logWriter.String()).To(ContainSubstring("WARNING: The use of static Pivnet API tokens is deprecated"))
+		})
+	})
+
+	Describe("In", func() {
+		It("creates the download directory before validating", func() {
+			downloadDir := filepath.Join(tempDir, "download")
+
+			_, err := r.In(concourse.InRequest{Source: source}, downloadDir)
+			Expect(err).To(MatchError("product_version must be provided"))
+
+			Expect(downloadDir).To(BeADirectory())
+			Expect(logWriter.String()).To(ContainSubstring("Creating download directory: " + downloadDir))
+		})
+
+		It("sanitizes the logger", func() {
+			_, err := r.In(concourse.InRequest{Source: source}, tempDir)
+			Expect(err).To(HaveOccurred())
+
+			logger.Printf("token: %s", source.APIToken)
+			Expect(logWriter.String()).NotTo(ContainSubstring(source.APIToken))
+		})
+	})
+
+	Describe("Out", func() {
+		var input concourse.OutRequest
+
+		BeforeEach(func() {
+			input = concourse.OutRequest{
+				Source: source,
+				Params: concourse.OutParams{MetadataFile: "metadata.yml"},
+			}
+		})
+
+		Context("when no metadata file is provided", func() {
+			BeforeEach(func() {
+				input.Params.MetadataFile = ""
+			})
+
+			It("returns an error", func() {
+				_, err := r.Out(input, tempDir, tempDir)
+				Expect(err).To(MatchError("params.metadata_file must be provided"))
+			})
+		})
+
+		Context("when the metadata file is invalid", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "metadata.yml"), []byte("release: {}\n"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				_, err := r.Out(input, tempDir, tempDir)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("params.metadata_file is invalid: "))
+			})
+		})
+	})
+
+	Describe("redacting secrets", func() {
+		const (
+			accessToken     = "some-access-token-from-the-exchange"
+			secretAccessKey = "some-secret-access-key"
+			sessionToken    = "some-session-token"
+		)
+
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				fmt.Fprintln(GinkgoWriter, req.Method, req.URL.Path)
+				switch req.URL.Path {
+				case "/api/v2/authentication/access_tokens":
+					w.Write([]byte(`{"access_token": "` + accessToken + `"}`))
+				case "/api/v2/federation_token":
+					w.Write([]byte(`{
+						"access_key_id": "some-access-key-id",
+						"secret_access_key": "` + secretAccessKey + `",
+						"session_token": "` + sessionToken + `"
+					}`))
+				default:
+					// An error that echoes the credentials of the request
+					w.WriteHeader(http.StatusForbidden)
+					w.Write([]byte("denied: " + req.Header.Get("Authorization")))
+				}
+			}))
+
+			source.Endpoint = server.URL
+			source.Verbose = true
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("redacts the refresh token and the access token it is exchanged for", func() {
+			input := concourse.InRequest{
+				Source:  source,
+				Version: concourse.Version{ProductVersion: "1.0.0"},
+			}
+
+			_, err := r.In(input, tempDir)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(accessToken))
+
+			uiPrinter.PrintErrorln(err)
+			logger.Printf("refresh token: %s", source.APIToken)
+
+			Expect(logWriter.String()).To(ContainSubstring("***REDACTED-PIVNET_ACCESS_TOKEN***"))
+			Expect(logWriter.String()).To(ContainSubstring("***REDACTED-PIVNET_API_TOKEN***"))
+			Expect(logWriter.String()).NotTo(ContainSubstring(accessToken))
+			Expect(logWriter.String()).NotTo(ContainSubstring(source.APIToken))
+		})
+
+		It("redacts the federation token", func() {
+			err := ioutil.WriteFile(filepath.Join(tempDir, "metadata.yml"), []byte(`---
+release:
+  version: 1.0.0
+  release_type: Major Release
+  eula_slug: some-eula
+`), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			input := concourse.OutRequest{
+				Source: source,
+				Params: concourse.OutParams{MetadataFile: "metadata.yml"},
+			}
+
+			_, err = r.Out(input, tempDir, tempDir)
+			Expect(err).To(MatchError("Could not find product prefix"))
+
+			uiPrinter.PrintErrorlnf("S3 error for %s with %s", secretAccessKey, sessionToken)
+
+			Expect(logWriter.String()).To(ContainSubstring("***REDACTED-AWS_SECRET_ACCESS_KEY***"))
+			Expect(logWriter.String()).To(ContainSubstring("***REDACTED-AWS_SESSION_TOKEN***"))
+			Expect(logWriter.String()).NotTo(ContainSubstring(secretAccessKey))
+			Expect(logWriter.String()).NotTo(ContainSubstring(sessionToken))
+		})
+	})
+
+	Describe("ReadRequest", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(tempDir, "request.yml")
+		})
+
+		It("reads a request in YAML format", func() {
+			err := ioutil.WriteFile(path, []byte(`---
+source:
+  product_slug: some-product
+  product_version: 1\..*
+version:
+  product_version: 1.2.3
+params:
+  globs: ["*.pivotal"]
+  unpack: true
+`), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			var request concourse.InRequest
+			err = runner.ReadRequest(path, &request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(request).To(Equal(concourse.InRequest{
+				Source: concourse.Source{
+					ProductSlug:    "some-product",
+					ProductVersion: concourse.StringList{`1\..*`},
+				},
+				Version: concourse.Version{ProductVersion: "1.2.3"},
+				Params: concourse.InParams{
+					Globs:  []string{"*.pivotal"},
+					Unpack: true,
+				},
+			}))
+		})
+
+		It("reads a request in JSON format", func() {
+			err := ioutil.WriteFile(path, []byte(`{"source": {"product_slug": "some-product"}}`), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			var request concourse.CheckRequest
+			err = runner.ReadRequest(path, &request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(request.Source.ProductSlug).To(Equal("some-product"))
+		})
+
+		Context("when there are unknown keys", func() {
+			It("returns an error", func() {
+				err := ioutil.WriteFile(path, []byte("source:\n  produt_slug: some-product\n"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				var request concourse.CheckRequest
+				err = runner.ReadRequest(path, &request)
+				Expect(err).To(MatchError(path + ` could not be parsed: json: unknown field "produt_slug"`))
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			It("returns an error", func() {
+				var request concourse.CheckRequest
+				err := runner.ReadRequest(path, &request)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix(path + " could not be read: "))
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 
@@ -48,6 +49,10 @@ type NewClientConfig struct {
 	Stderr            io.Writer
 	SkipSSLValidation bool
 	FileSizeGetter    fileSizeGetter
+
+	// HTTPClient, when set, replaces the client of the s3-resource, e.g. to
+	// use the CA certificates, client certificate and proxies of the source.
+	HTTPClient *http.Client
 }
 
 func NewClient(config NewClientConfig) *Client {
@@ -64,6 +69,10 @@ func NewClient(config NewClientConfig) *Client {
 		config.SkipSSLValidation,
 	)
 
+	if config.HTTPClient != nil {
+		awsConfig.HTTPClient = config.HTTPClient
+	}
+
 	s3client := s3resource.NewS3Client(
 		config.Stderr,
 		awsConfig,
@@ -2,24 +2,121 @@ package semver
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/blang/semver"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
 )
 
+const majorGroup = "major"
+
 type SemverConverter struct {
-	logger logger.Logger
+	logger        logger.Logger
+	normalization concourse.VersionNormalization
+	pattern       *regexp.Regexp
 }
 
 func NewSemverConverter(logger logger.Logger) *SemverConverter {
-	return &SemverConverter{logger}
+	return &SemverConverter{logger: logger}
+}
+
+// NewNormalizingSemverConverter returns a converter that normalizes versions
+// according to the given policy before parsing them. The pattern is only used
+// by the pattern policy.
+func NewNormalizingSemverConverter(
+	logger logger.Logger,
+	normalization concourse.VersionNormalization,
+	pattern string,
+) (*SemverConverter, error) {
+	err := ValidateNormalization(normalization, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SemverConverter{
+		logger:        logger,
+		normalization: normalization,
+	}
+
+	if normalization == concourse.VersionNormalizationPattern {
+		s.pattern = regexp.MustCompile(pattern)
+	}
+
+	return s, nil
 }
 
-// ToValidSemver attempts to return the input as valid semver.
-// If the input fails to parse as semver, it appends .0 or .0.0 to the input and retries
-// If this is still not valid semver, it returns an error
+// ValidateNormalization returns an error if the normalization policy is
+// unknown, or if the pattern policy is not given a regex with a 'major'
+// named capture group.
+func ValidateNormalization(normalization concourse.VersionNormalization, pattern string) error {
+	switch normalization {
+	case "",
+		concourse.VersionNormalizationStrict,
+		concourse.VersionNormalizationLenient,
+		concourse.VersionNormalizationFourPart:
+		if pattern != "" {
+			return fmt.Errorf("version_pattern can only be provided when version_normalization is: '%s'", concourse.VersionNormalizationPattern)
+		}
+		return nil
+	case concourse.VersionNormalizationPattern:
+	default:
+		return fmt.Errorf(
+			"version_normalization: '%s' is not one of: strict, lenient, four_part, pattern",
+			normalization,
+		)
+	}
+
+	if pattern == "" {
+		return fmt.Errorf("version_pattern must be provided when version_normalization is: '%s'", normalization)
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("version_pattern: '%s' is invalid: %s", pattern, err)
+	}
+
+	for _, name := range r.SubexpNames() {
+		if name == majorGroup {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("version_pattern: '%s' must have a named capture group: '%s'", pattern, majorGroup)
+}
+
+// ToValidSemver attempts to return the input as valid semver, normalizing it
+// according to the converter's policy:
+//
+// By default, if the input fails to parse as semver, it appends .0 or .0.0 to
+// the input and retries.
+//
+// strict only accepts valid semver.
+//
+// lenient also trims a leading 'v' and leading zeros from numeric segments,
+// e.g. 'v2019.03.1' is parsed as '2019.3.1'.
+//
+// four_part behaves like lenient, and additionally treats a fourth version
+// segment as build metadata, e.g. '1.2.3.4' is parsed as '1.2.3+4'. As build
+// metadata does not affect precedence, '1.2.3.4' and '1.2.3.5' are equal.
+//
+// pattern builds the version from the named capture groups 'major', 'minor',
+// 'patch', 'prerelease' and 'build' of the source's version_pattern.
+//
+// If the input is still not valid semver, it returns an error.
 func (s SemverConverter) ToValidSemver(input string) (semver.Version, error) {
+	switch s.normalization {
+	case concourse.VersionNormalizationStrict:
+		return semver.Parse(input)
+	case concourse.VersionNormalizationLenient:
+		return s.parseNormalized(input, false)
+	case concourse.VersionNormalizationFourPart:
+		return s.parseNormalized(input, true)
+	case concourse.VersionNormalizationPattern:
+		return s.parsePattern(input)
+	}
+
 	v, err := semver.Parse(input)
 	if err == nil {
 		return v, nil
@@ -51,3 +148,119 @@ func (s SemverConverter) ToValidSemver(input string) (semver.Version, error) {
 
 	return semver.Version{}, err
 }
+
+func (s SemverConverter) parseNormalized(input string, fourPart bool) (semver.Version, error) {
+	version := strings.TrimSpace(input)
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "v"), "V")
+
+	var build string
+	if i := strings.Index(version, "+"); i >= 0 {
+		version, build = version[:i], version[i+1:]
+	}
+
+	var prerelease string
+	if i := strings.Index(version, "-"); i >= 0 {
+		version, prerelease = version[:i], version[i+1:]
+	}
+
+	segs := strings.Split(version, ".")
+	if fourPart && len(segs) == 4 {
+		build = joinNonEmpty(segs[3], build)
+		segs = segs[:3]
+	}
+
+	for len(segs) < 3 {
+		segs = append(segs, "0")
+	}
+
+	return s.parseSegments(input, segs, prerelease, build)
+}
+
+func (s SemverConverter) parsePattern(input string) (semver.Version, error) {
+	match := s.pattern.FindStringSubmatch(input)
+	if match == nil {
+		return semver.Version{}, fmt.Errorf(
+			"version: '%s' does not match version_pattern: '%s'",
+			input,
+			s.pattern.String(),
+		)
+	}
+
+	groups := map[string]string{}
+	for i, name := range s.pattern.SubexpNames() {
+		if name != "" && match[i] != "" {
+			groups[name] = match[i]
+		}
+	}
+
+	segs := []string{groups[majorGroup], groups["minor"], groups["patch"]}
+	for i, seg := range segs {
+		if seg == "" {
+			segs[i] = "0"
+		}
+	}
+
+	return s.parseSegments(input, segs, groups["prerelease"], groups["build"])
+}
+
+// parseSegments parses the version made of the given segments, trimming
+// leading zeros from numeric segments, which semver does not allow.
+func (s SemverConverter) parseSegments(
+	input string,
+	segs []string,
+	prerelease string,
+	build string,
+) (semver.Version, error) {
+	for i, seg := range segs {
+		segs[i] = trimLeadingZeros(seg)
+	}
+
+	normalized := strings.Join(segs, ".")
+
+	if prerelease != "" {
+		ids := strings.Split(prerelease, ".")
+		for i, id := range ids {
+			ids[i] = trimLeadingZeros(id)
+		}
+		normalized += "-" + strings.Join(ids, ".")
+	}
+
+	if build != "" {
+		normalized += "+" + build
+	}
+
+	v, err := semver.Parse(normalized)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf(
+			"failed to parse version: '%s' normalized as: '%s'",
+			input,
+			normalized,
+		))
+		return semver.Version{}, err
+	}
+
+	if normalized != input {
+		s.logger.Debug(fmt.Sprintf("normalized version: '%s' as: '%s'", input, normalized))
+	}
+
+	return v, nil
+}
+
+func trimLeadingZeros(seg string) string {
+	if seg == "" || strings.Trim(seg, "0123456789") != "" {
+		return seg
+	}
+
+	trimmed := strings.TrimLeft(seg, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+func joinNonEmpty(a string, b string) string {
+	if b == "" {
+		return a
+	}
+	return a + "." + b
+}
@@ -5,20 +5,24 @@ import (
 
 	bsemver "github.com/blang/semver"
 	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
 	"github.com/pivotal-cf/pivnet-resource/v3/semver"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("SemverConverter", func() {
 	var (
+		fakeLogger *logshim.LogShim
+
 		s *semver.SemverConverter
 	)
 
 	BeforeEach(func() {
 		logger := log.New(GinkgoWriter, "", log.LstdFlags)
-		fakeLogger := logshim.NewLogShim(logger, logger, true)
+		fakeLogger = logshim.NewLogShim(logger, logger, true)
 		s = semver.NewSemverConverter(fakeLogger)
 	})
 
@@ -105,4 +109,146 @@ var _ = Describe("SemverConverter", func() {
 			})
 		})
 	})
+
+	Describe("NewNormalizingSemverConverter", func() {
+		var (
+			normalization concourse.VersionNormalization
+			pattern       string
+		)
+
+		BeforeEach(func() {
+			normalization = ""
+			pattern = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			s, err = semver.NewNormalizingSemverConverter(fakeLogger, normalization, pattern)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when no normalization is provided", func() {
+			It("appends zeros as before", func() {
+				returned, err := s.ToValidSemver("1.2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(returned).To(Equal(bsemver.MustParse("1.2.0")))
+			})
+		})
+
+		Context("when normalization is strict", func() {
+			BeforeEach(func() {
+				normalization = concourse.VersionNormalizationStrict
+			})
+
+			It("parses valid semver", func() {
+				returned, err := s.ToValidSemver("2.10.3-build.4")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(returned).To(Equal(bsemver.MustParse("2.10.3-build.4")))
+			})
+
+			It("does not append zeros", func() {
+				_, err := s.ToValidSemver("1.2")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when normalization is lenient", func() {
+			BeforeEach(func() {
+				normalization = concourse.VersionNormalizationLenient
+			})
+
+			DescribeTable("normalizes versions",
+				func(input string, expected string) {
+					returned, err := s.ToValidSemver(input)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returned).To(Equal(bsemver.MustParse(expected)))
+				},
+				Entry("leading v", "v1.2", "1.2.0"),
+				Entry("pre-release and build", "2.10.3-build.4", "2.10.3-build.4"),
+				Entry("dated version", "2019.03.01", "2019.3.1"),
+				Entry("leading zeros in pre-release", "1.0.0-rc.01", "1.0.0-rc.1"),
+				Entry("single component with build metadata", "7+20200101", "7.0.0+20200101"),
+			)
+
+			It("does not accept four components", func() {
+				_, err := s.ToValidSemver("1.2.3.4")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when normalization is four_part", func() {
+			BeforeEach(func() {
+				normalization = concourse.VersionNormalizationFourPart
+			})
+
+			DescribeTable("treats the fourth component as build metadata",
+				func(input string, expected string) {
+					returned, err := s.ToValidSemver(input)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(returned).To(Equal(bsemver.MustParse(expected)))
+				},
+				Entry("four components", "1.2.3.4", "1.2.3+4"),
+				Entry("leading v and zeros", "v2.0.1.0042", "2.0.1+0042"),
+				Entry("existing build metadata", "1.2.3.4+abc", "1.2.3+4.abc"),
+				Entry("three components", "2.10.3", "2.10.3"),
+			)
+		})
+
+		Context("when normalization is pattern", func() {
+			BeforeEach(func() {
+				normalization = concourse.VersionNormalizationPattern
+				pattern = `^(?P<major>\d+)\.(?P<minor>\d+)(\.(?P<patch>\d+))?( Build (?P<build>\d+))?$`
+			})
+
+			It("builds the version from named capture groups", func() {
+				returned, err := s.ToValidSemver("2.5 Build 0123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(returned).To(Equal(bsemver.MustParse("2.5.0+0123")))
+			})
+
+			It("uses the patch group when present", func() {
+				returned, err := s.ToValidSemver("2.5.07")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(returned).To(Equal(bsemver.MustParse("2.5.7")))
+			})
+
+			It("returns an error when the version does not match", func() {
+				_, err := s.ToValidSemver("Binary 1.0.11")
+				Expect(err).To(MatchError(ContainSubstring("does not match version_pattern")))
+			})
+		})
+	})
+
+	Describe("ValidateNormalization", func() {
+		It("accepts known policies", func() {
+			Expect(semver.ValidateNormalization("", "")).To(Succeed())
+			Expect(semver.ValidateNormalization(concourse.VersionNormalizationLenient, "")).To(Succeed())
+			Expect(semver.ValidateNormalization(concourse.VersionNormalizationPattern, `(?P<major>\d+)`)).To(Succeed())
+		})
+
+		It("rejects unknown policies", func() {
+			err := semver.ValidateNormalization("loose", "")
+			Expect(err).To(MatchError(ContainSubstring("version_normalization: 'loose' is not one of")))
+		})
+
+		It("requires a pattern for the pattern policy", func() {
+			err := semver.ValidateNormalization(concourse.VersionNormalizationPattern, "")
+			Expect(err).To(MatchError(ContainSubstring("version_pattern must be provided")))
+		})
+
+		It("rejects a pattern for other policies", func() {
+			err := semver.ValidateNormalization(concourse.VersionNormalizationStrict, `(?P<major>\d+)`)
+			Expect(err).To(MatchError(ContainSubstring("version_pattern can only be provided")))
+		})
+
+		It("rejects invalid patterns", func() {
+			err := semver.ValidateNormalization(concourse.VersionNormalizationPattern, `(?P<major>\d+`)
+			Expect(err).To(MatchError(ContainSubstring("is invalid")))
+		})
+
+		It("requires a major group", func() {
+			err := semver.ValidateNormalization(concourse.VersionNormalizationPattern, `(\d+)`)
+			Expect(err).To(MatchError(ContainSubstring("must have a named capture group: 'major'")))
+		})
+	})
 })
@@ -8,8 +8,11 @@ import (
 	"github.com/blang/semver"
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
 )
 
+const releaseDateLayout = "2006-01-02"
+
 //counterfeiter:generate --fake-name FakeSemverConverter . semverConverter
 type semverConverter interface {
 	ToValidSemver(string) (semver.Version, error)
@@ -27,6 +30,13 @@ func NewSorter(logger logger.Logger, semverConverter semverConverter) *Sorter {
 	}
 }
 
+type sortable struct {
+	release     pivnet.Release
+	semver      semver.Version
+	lastUpdated int64
+	releaseDate time.Time
+}
+
 // SortBySemver returns the provided releases, ordered by semantic versioning,
 // in descending order i.e. [4.2.3, 1.2.1, 1.2.0]
 // If a version cannot be parsed as semantic versioning, this is logged to stdout
@@ -34,61 +44,141 @@ func NewSorter(logger logger.Logger, semverConverter semverConverter) *Sorter {
 // Therefore the number of returned releases may be fewer than the number of
 // provided releases.
 func (s Sorter) SortBySemver(input []pivnet.Release) ([]pivnet.Release, error) {
-	var versions []semver.Version
-	versionsToReleases := make(map[string]pivnet.Release)
+	return s.Sort(input, []concourse.SortBy{concourse.SortBySemver})
+}
 
-	for _, release := range input {
-		asSemver, err := s.semverConverter.ToValidSemver(release.Version)
-		if err != nil {
-			s.logger.Info(fmt.Sprintf(
-				"failed to parse release version as semver: '%s'",
-				release.Version,
-			))
-			continue
-		}
+func (s Sorter) SortByLastUpdated(input []pivnet.Release) ([]pivnet.Release, error) {
+	return s.Sort(input, []concourse.SortBy{concourse.SortByLastUpdated})
+}
 
-		versionsToReleases[asSemver.String()] = release
-		versions = append(versions, asSemver)
+// Sort returns the provided releases ordered by each key in turn, in
+// descending order. Later keys are only used to order releases that are equal
+// by all earlier keys, and releases that are equal by every key keep the order
+// they were provided in.
+//
+// As with SortBySemver, releases whose versions cannot be parsed as semantic
+// versioning are not returned when sorting by semver. Releases without a valid
+// release date are ordered after all others when sorting by release date.
+func (s Sorter) Sort(input []pivnet.Release, keys []concourse.SortBy) ([]pivnet.Release, error) {
+	var comparators []func(a, b sortable) int
+	var bySemver, byLastUpdated, byReleaseDate bool
+
+	for _, key := range keys {
+		switch key {
+		case concourse.SortByNone:
+		case concourse.SortBySemver:
+			bySemver = true
+			comparators = append(comparators, func(a, b sortable) int {
+				return b.semver.Compare(a.semver)
+			})
+		case concourse.SortByLastUpdated:
+			byLastUpdated = true
+			comparators = append(comparators, func(a, b sortable) int {
+				return compareInt64(b.lastUpdated, a.lastUpdated)
+			})
+		case concourse.SortByReleaseDate:
+			byReleaseDate = true
+			comparators = append(comparators, func(a, b sortable) int {
+				return compareInt64(b.releaseDate.Unix(), a.releaseDate.Unix())
+			})
+		case concourse.SortByID:
+			comparators = append(comparators, func(a, b sortable) int {
+				return compareInt64(int64(b.release.ID), int64(a.release.ID))
+			})
+		default:
+			return nil, fmt.Errorf("unknown sort_by: '%s'", key)
+		}
 	}
 
-	semver.Sort(versions)
+	items := make([]sortable, 0, len(input))
+	for _, release := range input {
+		item := sortable{release: release}
+
+		if bySemver {
+			asSemver, err := s.semverConverter.ToValidSemver(release.Version)
+			if err != nil {
+				s.logger.Info(fmt.Sprintf(
+					"failed to parse release version as semver: '%s'",
+					release.Version,
+				))
+				continue
+			}
+			item.semver = asSemver
+		}
 
-	sortedStrings := toStrings(versions)
+		if byLastUpdated {
+			t, err := getMostRecentTimestampFromRelease(release)
+			if err != nil {
+				return nil, err
+			}
+			item.lastUpdated = t
+		}
 
-	sortedReleases := make([]pivnet.Release, len(sortedStrings))
+		if byReleaseDate {
+			d, err := time.Parse(releaseDateLayout, release.ReleaseDate)
+			if err != nil {
+				s.logger.Info(fmt.Sprintf(
+					"failed to parse release date of release '%s': '%s'",
+					release.Version,
+					release.ReleaseDate,
+				))
+				d = time.Time{}
+			}
+			item.releaseDate = d
+		}
 
-	count := len(sortedStrings)
-	for i, v := range sortedStrings {
-		// perform reversal so we return descending, not ascending
-		sortedReleases[count-i-1] = versionsToReleases[v]
+		items = append(items, item)
 	}
 
-	return sortedReleases, nil
-}
-
-func (s Sorter) SortByLastUpdated(input []pivnet.Release) ([]pivnet.Release, error) {
-	releasesMap := make(map[int64][]pivnet.Release)
-
-	for _, release := range input {
-		t, err := getMostRecentTimestampFromRelease(release)
-		if err != nil {
-			return nil, err
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, compare := range comparators {
+			if c := compare(items[i], items[j]); c != 0 {
+				return c < 0
+			}
 		}
+		return false
+	})
+
+	if bySemver {
+		s.logSemverCollisions(items)
+	}
 
-		releasesMap[t] = append(releasesMap[t], release)
+	sorted := make([]pivnet.Release, len(items))
+	for i, item := range items {
+		sorted[i] = item.release
 	}
 
-	return sortReleasesByTimestamp(releasesMap), nil
+	return sorted, nil
 }
 
-func toStrings(input semver.Versions) []string {
-	strings := make([]string, len(input))
+// logSemverCollisions logs releases with different versions that are equal
+// as semver, e.g. 1.2 and 1.2.0, as their order is then decided by any
+// further sort keys or the order they were provided in.
+func (s Sorter) logSemverCollisions(items []sortable) {
+	for i := 1; i < len(items); i++ {
+		previous := items[i-1]
+		current := items[i]
 
-	for i, v := range input {
-		strings[i] = v.String()
+		if previous.semver.Equals(current.semver) && previous.release.Version != current.release.Version {
+			s.logger.Info(fmt.Sprintf(
+				"releases '%s' and '%s' have the same semver: '%s'",
+				previous.release.Version,
+				current.release.Version,
+				current.semver.String(),
+			))
+		}
 	}
+}
 
-	return strings
+func compareInt64(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func getMostRecentTimestampFromRelease(release pivnet.Release) (int64, error) {
@@ -112,21 +202,3 @@ func getMostRecentTimestampFromRelease(release pivnet.Release) (int64, error) {
 
 	return mostRecentTimestamp, nil
 }
-
-func sortReleasesByTimestamp(releasesMap map[int64][]pivnet.Release) (result []pivnet.Release) {
-	var keys []int64
-
-	for key := range releasesMap {
-		keys = append(keys, key)
-	}
-
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] > keys[j]
-	})
-
-	for _, key := range keys {
-		result = append(result, releasesMap[key]...)
-	}
-
-	return result
-}
@@ -7,6 +7,7 @@ import (
 	bsemver "github.com/blang/semver"
 	"github.com/pivotal-cf/go-pivnet/v7"
 	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
 	"github.com/pivotal-cf/pivnet-resource/v3/sorter"
 	"github.com/pivotal-cf/pivnet-resource/v3/sorter/sorterfakes"
 
@@ -110,6 +111,86 @@ var _ = Describe("Sorter", func() {
 					[]string{"2.4.1", "2.1", "1"}))
 			})
 		})
+
+		Context("when versions are the same as semver", func() {
+			It("keeps both releases in the order provided", func() {
+				input := releasesWithVersions(
+					"2.1", "1.0.0", "2.1.0", "1",
+				)
+
+				returned, err := s.SortBySemver(input)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionsFromReleases(returned)).To(Equal(
+					[]string{"2.1", "2.1.0", "1.0.0", "1"}))
+			})
+		})
+	})
+
+	Describe("Sort", func() {
+		var (
+			input []pivnet.Release
+		)
+
+		BeforeEach(func() {
+			input = []pivnet.Release{
+				{ID: 1, Version: "2.1", ReleaseDate: "2019-01-10"},
+				{ID: 4, Version: "1.0.0", ReleaseDate: "2019-03-01"},
+				{ID: 2, Version: "2.1.0", ReleaseDate: "2019-02-15"},
+				{ID: 3, Version: "2.0.0", ReleaseDate: "2019-02-15"},
+			}
+		})
+
+		It("sorts by release date descending, keeping ties in the order provided", func() {
+			returned, err := s.Sort(input, []concourse.SortBy{concourse.SortByReleaseDate})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idsFromReleases(returned)).To(Equal([]int{4, 2, 3, 1}))
+		})
+
+		It("sorts by ID descending", func() {
+			returned, err := s.Sort(input, []concourse.SortBy{concourse.SortByID})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idsFromReleases(returned)).To(Equal([]int{4, 3, 2, 1}))
+		})
+
+		It("uses later keys to break ties in earlier keys", func() {
+			returned, err := s.Sort(input, []concourse.SortBy{
+				concourse.SortBySemver,
+				concourse.SortByReleaseDate,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idsFromReleases(returned)).To(Equal([]int{2, 1, 3, 4}))
+		})
+
+		It("returns releases in the order provided when there are no keys", func() {
+			returned, err := s.Sort(input, []concourse.SortBy{concourse.SortByNone})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(returned).To(Equal(input))
+		})
+
+		Context("when a release date cannot be parsed", func() {
+			BeforeEach(func() {
+				input[3].ReleaseDate = ""
+			})
+
+			It("orders that release last", func() {
+				returned, err := s.Sort(input, []concourse.SortBy{concourse.SortByReleaseDate})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(idsFromReleases(returned)).To(Equal([]int{4, 2, 1, 3}))
+			})
+		})
+
+		Context("when a key is unknown", func() {
+			It("returns an error", func() {
+				_, err := s.Sort(input, []concourse.SortBy{"size"})
+				Expect(err).To(MatchError("unknown sort_by: 'size'"))
+			})
+		})
 	})
 
 	Describe("Sort by last updated", func() {
@@ -174,6 +255,14 @@ func versionsFromReleases(releases []pivnet.Release) []string {
 	return versions
 }
 
+func idsFromReleases(releases []pivnet.Release) []int {
+	var ids []int
+	for _, release := range releases {
+		ids = append(ids, release.ID)
+	}
+	return ids
+}
+
 func releasesWithLastUpdated(lastUpdated ...updatePair) []pivnet.Release {
 	var releases []pivnet.Release
 	for _, timePair := range lastUpdated {
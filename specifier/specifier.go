@@ -0,0 +1,181 @@
+package specifier
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type semverConverter interface {
+	ToValidSemver(string) (semver.Version, error)
+}
+
+// Matcher evaluates Pivnet dependency and upgrade path specifiers locally.
+//
+// Supported specifiers are:
+//   - exact versions, e.g. "1.2.3" or "0.2.0-build.2050"
+//   - wildcards, e.g. "1.8.*"
+//   - pessimistic constraints of at most three segments, e.g. "~>1.9.1"
+//     (>= 1.9.1, < 1.10.0)
+//   - comparisons, e.g. ">=1.2.0", "<2.0"
+//
+// Several constraints may be combined with commas, in which case all of
+// them must be satisfied.
+type Matcher struct {
+	semverConverter semverConverter
+}
+
+func NewMatcher(semverConverter semverConverter) *Matcher {
+	return &Matcher{
+		semverConverter: semverConverter,
+	}
+}
+
+var comparisonOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+// Validate returns an error if the specifier cannot be evaluated.
+func (m Matcher) Validate(specifier string) error {
+	_, err := m.Matches(specifier, "0.0.0")
+	return err
+}
+
+// Matches returns whether the version satisfies the specifier.
+// Versions that cannot be parsed as semver only match exact, wildcard or '!='
+// specifiers.
+func (m Matcher) Matches(specifier string, version string) (bool, error) {
+	constraints := strings.Split(specifier, ",")
+
+	for _, c := range constraints {
+		matched, err := m.matchesConstraint(strings.TrimSpace(c), version)
+		if err != nil {
+			return false, err
+		}
+
+		if !matched {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// ReleasesMatching returns the releases whose version satisfies the specifier.
+func (m Matcher) ReleasesMatching(releases []pivnet.Release, specifier string) ([]pivnet.Release, error) {
+	matching := make([]pivnet.Release, 0)
+
+	for _, r := range releases {
+		matched, err := m.Matches(specifier, r.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			matching = append(matching, r)
+		}
+	}
+
+	return matching, nil
+}
+
+func (m Matcher) matchesConstraint(constraint string, version string) (bool, error) {
+	if constraint == "" {
+		return false, fmt.Errorf("empty specifier")
+	}
+
+	operator := ""
+	for _, o := range comparisonOperators {
+		if strings.HasPrefix(constraint, o) {
+			operator = o
+			break
+		}
+	}
+
+	operand := strings.TrimSpace(strings.TrimPrefix(constraint, operator))
+	if operand == "" {
+		return false, fmt.Errorf("invalid specifier: '%s'", constraint)
+	}
+
+	if operator == "" || operator == "=" {
+		if strings.Contains(operand, "*") {
+			return matchesWildcard(operand, version), nil
+		}
+
+		if operand == version {
+			return true, nil
+		}
+	}
+
+	if strings.Contains(operand, "*") {
+		return false, fmt.Errorf("invalid specifier: '%s' - wildcards cannot be combined with '%s'", constraint, operator)
+	}
+
+	if operator == "~>" && len(coreSegments(operand)) > 3 {
+		return false, fmt.Errorf("invalid specifier: '%s' - '~>' takes at most three version segments", constraint)
+	}
+
+	want, err := m.semverConverter.ToValidSemver(operand)
+	if err != nil {
+		return false, fmt.Errorf("invalid specifier: '%s' - %s", constraint, err)
+	}
+
+	// A version that is not semver is never equal to the operand, so it only
+	// satisfies '!='.
+	have, err := m.semverConverter.ToValidSemver(version)
+	if err != nil {
+		return operator == "!=", nil
+	}
+
+	switch operator {
+	case "", "=":
+		return have.Equals(want), nil
+	case "!=":
+		return !have.Equals(want), nil
+	case ">":
+		return have.GT(want), nil
+	case ">=":
+		return have.GTE(want), nil
+	case "<":
+		return have.LT(want), nil
+	case "<=":
+		return have.LTE(want), nil
+	case "~>":
+		return have.GTE(want) && have.LT(pessimisticUpperBound(operand, want)), nil
+	}
+
+	// Untested because every operator in comparisonOperators is handled above.
+	return false, fmt.Errorf("invalid specifier: '%s'", constraint)
+}
+
+// pessimisticUpperBound returns the exclusive upper bound of a pessimistic
+// constraint, which increments the second to last segment provided,
+// e.g. ~>1.9.1 is bounded by 1.10.0 and ~>1.9 by 2.0.0. A single segment has
+// no second to last segment, so like ~>1.0 the major version is incremented,
+// e.g. ~>1 is bounded by 2.0.0. Operands have at most three segments.
+func pessimisticUpperBound(operand string, lower semver.Version) semver.Version {
+	if len(coreSegments(operand)) == 3 {
+		return semver.Version{Major: lower.Major, Minor: lower.Minor + 1}
+	}
+
+	return semver.Version{Major: lower.Major + 1}
+}
+
+// coreSegments returns the dot separated segments of a version, without its
+// pre-release and build metadata.
+func coreSegments(version string) []string {
+	core := strings.SplitN(version, "-", 2)[0]
+	core = strings.SplitN(core, "+", 2)[0]
+	return strings.Split(core, ".")
+}
+
+func matchesWildcard(pattern string, version string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+
+	re := regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+	return re.MatchString(version)
+}
@@ -0,0 +1,13 @@
+package specifier_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSpecifier(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Specifier Suite")
+}
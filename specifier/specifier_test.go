@@ -0,0 +1,138 @@
+package specifier_test
+
+import (
+	"log"
+
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/specifier"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matcher", func() {
+	var (
+		m *specifier.Matcher
+	)
+
+	BeforeEach(func() {
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		fakeLogger := logshim.NewLogShim(logger, logger, true)
+		m = specifier.NewMatcher(semver.NewSemverConverter(fakeLogger))
+	})
+
+	DescribeTable("Matches",
+		func(spec string, version string, expected bool) {
+			matched, err := m.Matches(spec, version)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matched).To(Equal(expected))
+		},
+		Entry("exact version", "2.3.4", "2.3.4", true),
+		Entry("exact version with different patch", "2.3.4", "2.3.5", false),
+		Entry("exact version with build", "0.2.0-build.2050", "0.2.0-build.2050", true),
+		Entry("exact version with padding", "1.2", "1.2.0", true),
+		Entry("non-semver exact version", "Go Buildpack 1.1", "Go Buildpack 1.1", true),
+		Entry("wildcard", "1.8.*", "1.8.3", true),
+		Entry("wildcard with build", "1.8.*", "1.8.3-build.1", true),
+		Entry("wildcard on different minor", "1.8.*", "1.9.0", false),
+		Entry("wildcard does not match prefix only", "1.8.*", "11.8.0", false),
+		Entry("pessimistic with patch at lower bound", "~>1.9.1", "1.9.1", true),
+		Entry("pessimistic with patch within range", "~>1.9.1", "1.9.12", true),
+		Entry("pessimistic with patch below range", "~>1.9.1", "1.9.0", false),
+		Entry("pessimistic with patch above range", "~>1.9.1", "1.10.0", false),
+		Entry("pessimistic with minor within range", "~> 1.9", "1.12.3", true),
+		Entry("pessimistic with minor above range", "~> 1.9", "2.0.0", false),
+		Entry("pessimistic with major within range", "~>1", "1.12.3", true),
+		Entry("pessimistic with major above range", "~>1", "2.0.0", false),
+		Entry("greater than or equal", ">=1.2.0", "1.2.0", true),
+		Entry("greater than", ">1.2.0", "1.2.0", false),
+		Entry("less than", "<2.0", "1.99.0", true),
+		Entry("less than or equal", "<=2.0", "2.0.1", false),
+		Entry("not equal", "!=2.0.0", "2.0.1", true),
+		Entry("combined constraints", ">=1.2, <1.4", "1.3.7", true),
+		Entry("combined constraints out of range", ">=1.2, <1.4", "1.4.0", false),
+		Entry("comparison against non-semver version", ">=1.2", "not-a-version", false),
+		Entry("not equal against non-semver version", "!=1.2.3", "not-a-version", true),
+	)
+
+	Describe("Matches", func() {
+		Context("when the specifier is invalid", func() {
+			It("returns an error", func() {
+				_, err := m.Matches(">=not-a-version", "1.2.3")
+				Expect(err).To(MatchError(ContainSubstring("invalid specifier: '>=not-a-version'")))
+			})
+		})
+
+		Context("when a wildcard is combined with an operator", func() {
+			It("returns an error", func() {
+				_, err := m.Matches("~>1.*", "1.2.3")
+				Expect(err).To(MatchError(ContainSubstring("wildcards cannot be combined with '~>'")))
+			})
+		})
+
+		Context("when a pessimistic constraint has more than three segments", func() {
+			It("returns an error", func() {
+				_, err := m.Matches("~>1.9.1.2", "1.9.1")
+				Expect(err).To(MatchError("invalid specifier: '~>1.9.1.2' - '~>' takes at most three version segments"))
+			})
+
+			Context("when four part versions are normalized", func() {
+				BeforeEach(func() {
+					logger := log.New(GinkgoWriter, "", log.LstdFlags)
+					converter, err := semver.NewNormalizingSemverConverter(
+						logshim.NewLogShim(logger, logger, true),
+						concourse.VersionNormalizationFourPart,
+						"",
+					)
+					Expect(err).NotTo(HaveOccurred())
+
+					m = specifier.NewMatcher(converter)
+				})
+
+				It("returns an error", func() {
+					_, err := m.Matches("~>1.9.1.2", "1.9.1.3")
+					Expect(err).To(MatchError("invalid specifier: '~>1.9.1.2' - '~>' takes at most three version segments"))
+				})
+
+				It("accepts four part versions to compare", func() {
+					matched, err := m.Matches("~>1.9.1", "1.9.5.2")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(matched).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the specifier is empty", func() {
+			It("returns an error", func() {
+				_, err := m.Matches("", "1.2.3")
+				Expect(err).To(MatchError("empty specifier"))
+			})
+		})
+	})
+
+	Describe("ReleasesMatching", func() {
+		It("returns the releases matching the specifier", func() {
+			releases := []pivnet.Release{
+				{ID: 1, Version: "1.8.0"},
+				{ID: 2, Version: "1.9.3"},
+				{ID: 3, Version: "1.8.9"},
+			}
+
+			matching, err := m.ReleasesMatching(releases, "1.8.*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(Equal([]pivnet.Release{
+				{ID: 1, Version: "1.8.0"},
+				{ID: 3, Version: "1.8.9"},
+			}))
+		})
+
+		It("returns an error for an invalid specifier", func() {
+			_, err := m.ReleasesMatching([]pivnet.Release{{Version: "1.2.3"}}, ">=bad")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package tile
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"gopkg.in/yaml.v2"
+)
+
+// Tile is the part of a tile's metadata that describes what it is built
+// from and what it needs to be deployed.
+type Tile struct {
+	Name             string           `yaml:"name"`
+	ProductVersion   string           `yaml:"product_version"`
+	StemcellCriteria StemcellCriteria `yaml:"stemcell_criteria"`
+	Releases         []Release        `yaml:"releases"`
+}
+
+type StemcellCriteria struct {
+	OS          string `yaml:"os"`
+	Version     string `yaml:"version"`
+	RequiresCPI bool   `yaml:"requires_cpi"`
+}
+
+type Release struct {
+	Name    string `yaml:"name"`
+	File    string `yaml:"file"`
+	Version string `yaml:"version"`
+}
+
+type Inspector struct {
+	logger logger.Logger
+}
+
+func NewInspector(logger logger.Logger) *Inspector {
+	return &Inspector{
+		logger: logger,
+	}
+}
+
+// Inspect reads the metadata of the .pivotal file at the path. A .pivotal
+// file is a zip archive with its metadata in metadata/<name>.yml.
+func (i Inspector) Inspect(tilePath string) (Tile, error) {
+	r, err := zip.OpenReader(tilePath)
+	if err != nil {
+		return Tile{}, fmt.Errorf("failed to open tile: '%s': %s", tilePath, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		dir, name := path.Split(f.Name)
+		if dir != "metadata/" || !(strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")) {
+			continue
+		}
+
+		i.logger.Debug(fmt.Sprintf("Reading tile metadata: '%s' from tile: '%s'", f.Name, tilePath))
+
+		rc, err := f.Open()
+		if err != nil {
+			return Tile{}, err
+		}
+
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return Tile{}, err
+		}
+
+		var t Tile
+		err = yaml.Unmarshal(b, &t)
+		if err != nil {
+			return Tile{}, fmt.Errorf("failed to parse metadata: '%s' of tile: '%s': %s", f.Name, tilePath, err)
+		}
+
+		return t, nil
+	}
+
+	return Tile{}, fmt.Errorf("no metadata/*.yml file found in tile: '%s'", tilePath)
+}
+
+// VersionMatches returns whether a tile's product version is the release
+// version, allowing for the pre-release or build suffix tiles are often
+// built with, e.g. '2.10.3-build.4' matches '2.10.3'.
+func VersionMatches(tileVersion string, releaseVersion string) bool {
+	if tileVersion == releaseVersion {
+		return true
+	}
+
+	return strings.HasPrefix(tileVersion, releaseVersion+"-") || strings.HasPrefix(tileVersion, releaseVersion+"+")
+}
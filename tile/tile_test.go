@@ -0,0 +1,138 @@
+package tile_test
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/tile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inspector", func() {
+	var (
+		tempDir string
+
+		inspector *tile.Inspector
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		inspector = tile.NewInspector(logshim.NewLogShim(logger, logger, true))
+	})
+
+	AfterEach(func() {
+		err := os.RemoveAll(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	writeTile := func(files map[string]string) string {
+		tilePath := filepath.Join(tempDir, "some-tile.pivotal")
+
+		f, err := os.Create(tilePath)
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		w := zip.NewWriter(f)
+		for name, contents := range files {
+			fw, err := w.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = fw.Write([]byte(contents))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(w.Close()).To(Succeed())
+
+		return tilePath
+	}
+
+	It("reads the tile's metadata", func() {
+		tilePath := writeTile(map[string]string{
+			"releases/cf-2.0.0.tgz": "some-release",
+			"metadata/cf.yml": `---
+name: cf
+product_version: 2.10.3-build.4
+stemcell_criteria:
+  os: ubuntu-jammy
+  version: "1.100"
+  requires_cpi: false
+releases:
+- name: cf
+  file: cf-2.0.0.tgz
+  version: 2.0.0
+- name: routing
+  file: routing-0.200.0.tgz
+  version: 0.200.0
+`,
+		})
+
+		t, err := inspector.Inspect(tilePath)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(t).To(Equal(tile.Tile{
+			Name:           "cf",
+			ProductVersion: "2.10.3-build.4",
+			StemcellCriteria: tile.StemcellCriteria{
+				OS:      "ubuntu-jammy",
+				Version: "1.100",
+			},
+			Releases: []tile.Release{
+				{Name: "cf", File: "cf-2.0.0.tgz", Version: "2.0.0"},
+				{Name: "routing", File: "routing-0.200.0.tgz", Version: "0.200.0"},
+			},
+		}))
+	})
+
+	Context("when the tile has no metadata", func() {
+		It("returns an error", func() {
+			tilePath := writeTile(map[string]string{
+				"migrations/v1/some-migration.js": "",
+			})
+
+			_, err := inspector.Inspect(tilePath)
+			Expect(err).To(MatchError(ContainSubstring("no metadata/*.yml file found in tile")))
+		})
+	})
+
+	Context("when the metadata cannot be parsed", func() {
+		It("returns an error", func() {
+			tilePath := writeTile(map[string]string{
+				"metadata/cf.yml": "releases: {",
+			})
+
+			_, err := inspector.Inspect(tilePath)
+			Expect(err).To(MatchError(ContainSubstring("failed to parse metadata: 'metadata/cf.yml'")))
+		})
+	})
+
+	Context("when the file is not a zip archive", func() {
+		It("returns an error", func() {
+			tilePath := filepath.Join(tempDir, "not-a-tile.pivotal")
+			Expect(ioutil.WriteFile(tilePath, []byte("not a zip"), os.ModePerm)).To(Succeed())
+
+			_, err := inspector.Inspect(tilePath)
+			Expect(err).To(MatchError(ContainSubstring("failed to open tile")))
+		})
+	})
+})
+
+var _ = DescribeTable("VersionMatches",
+	func(tileVersion string, releaseVersion string, expected bool) {
+		Expect(tile.VersionMatches(tileVersion, releaseVersion)).To(Equal(expected))
+	},
+	Entry("same version", "2.10.3", "2.10.3", true),
+	Entry("build suffix", "2.10.3-build.4", "2.10.3", true),
+	Entry("build metadata", "2.10.3+abc", "2.10.3", true),
+	Entry("older tile", "2.10.2", "2.10.3", false),
+	Entry("longer version", "2.10.31", "2.10.3", false),
+)
@@ -0,0 +1,162 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// New returns the transport for every connection the resource makes, to
+// Pivnet, to download links and to S3. It trusts the system CA certificates
+// and ca_certs, presents client_cert when asked to, and uses http_proxy,
+// https_proxy and no_proxy when any of them is set, or the proxy environment
+// variables otherwise.
+func New(source concourse.Source) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(source)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := newProxy(source)
+	if err != nil {
+		return nil, err
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = tlsConfig
+	t.Proxy = proxy
+
+	return t, nil
+}
+
+func newTLSConfig(source concourse.Source) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: source.SkipSSLValidation,
+	}
+
+	if source.CACerts != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		certs, err := parseCertificates(source.CACerts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cert := range certs {
+			pool.AddCert(cert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (source.ClientCert == "") != (source.ClientKey == "") {
+		return nil, fmt.Errorf("client_cert and client_key must be provided together")
+	}
+
+	if source.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(source.ClientCert), []byte(source.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("client_cert and client_key are invalid: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func parseCertificates(caCerts string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(caCerts)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("ca_certs: PEM block %d is a '%s', not a CERTIFICATE", len(certs)+1, block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("ca_certs: certificate %d is invalid: %s", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("ca_certs: no PEM certificates found")
+	}
+
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, fmt.Errorf("ca_certs: unexpected content after certificate %d", len(certs))
+	}
+
+	return certs, nil
+}
+
+func newProxy(source concourse.Source) (func(*http.Request) (*url.URL, error), error) {
+	if source.HTTPProxy == "" && source.HTTPSProxy == "" && source.NoProxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxies := []struct {
+		name  string
+		value string
+	}{
+		{"http_proxy", source.HTTPProxy},
+		{"https_proxy", source.HTTPSProxy},
+	}
+
+	for _, p := range proxies {
+		if p.value == "" {
+			continue
+		}
+
+		// The proxy URL is left out of the error as it may hold credentials.
+		_, err := ProxyURL(p.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid proxy URL", p.name)
+		}
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  source.HTTPProxy,
+		HTTPSProxy: source.HTTPSProxy,
+		NoProxy:    source.NoProxy,
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// ProxyURL parses a proxy setting, which like the proxy environment variables
+// defaults to the http scheme.
+func ProxyURL(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+
+	return u, nil
+}
@@ -0,0 +1,266 @@
+package upgradegraph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+)
+
+//counterfeiter:generate --fake-name FakePivnetClient . pivnetClient
+type pivnetClient interface {
+	ReleasesForProductSlug(productSlug string) ([]pivnet.Release, error)
+	ReleaseUpgradePaths(productSlug string, releaseID int) ([]pivnet.ReleaseUpgradePath, error)
+}
+
+//counterfeiter:generate --fake-name FakeSemverConverter . semverConverter
+type semverConverter interface {
+	ToValidSemver(string) (semver.Version, error)
+}
+
+// Graph is the set of releases of a product and the upgrade paths between
+// them. Releases are ordered by semver, newest first, followed by any
+// releases whose versions are not semver in the order returned by Pivnet.
+type Graph struct {
+	ProductSlug  string    `json:"product_slug"`
+	Releases     []Release `json:"releases"`
+	UpgradePaths []Edge    `json:"upgrade_paths"`
+}
+
+type Release struct {
+	ID      int    `json:"id"`
+	Version string `json:"version"`
+}
+
+// Edge is an upgrade path from the release with version From to the release
+// with version To.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type Builder struct {
+	logger          logger.Logger
+	pivnetClient    pivnetClient
+	semverConverter semverConverter
+}
+
+func NewBuilder(
+	logger logger.Logger,
+	pivnetClient pivnetClient,
+	semverConverter semverConverter,
+) *Builder {
+	return &Builder{
+		logger:          logger,
+		pivnetClient:    pivnetClient,
+		semverConverter: semverConverter,
+	}
+}
+
+// Build walks the upgrade paths of every release of the product.
+func (b Builder) Build(productSlug string) (Graph, error) {
+	b.logger.Info(fmt.Sprintf("Getting all releases for product slug: '%s'", productSlug))
+
+	releases, err := b.pivnetClient.ReleasesForProductSlug(productSlug)
+	if err != nil {
+		return Graph{}, err
+	}
+
+	graph := Graph{
+		ProductSlug: productSlug,
+	}
+
+	parsed := b.parseSemvers(nil, releaseVersions(releases))
+
+	for _, r := range sortBySemver(releases, parsed) {
+		graph.Releases = append(graph.Releases, Release{
+			ID:      r.ID,
+			Version: r.Version,
+		})
+	}
+
+	b.logger.Info(fmt.Sprintf("Getting upgrade paths for %d releases", len(releases)))
+
+	for _, r := range graph.Releases {
+		upgradePaths, err := b.pivnetClient.ReleaseUpgradePaths(productSlug, r.ID)
+		if err != nil {
+			return Graph{}, err
+		}
+
+		var edges []Edge
+		var froms []string
+		for _, u := range upgradePaths {
+			edges = append(edges, Edge{
+				From: u.Release.Version,
+				To:   r.Version,
+			})
+			froms = append(froms, u.Release.Version)
+		}
+
+		parsed = b.parseSemvers(parsed, froms)
+
+		sort.SliceStable(edges, func(i, j int) bool {
+			return parsed.newer(edges[i].From, edges[j].From)
+		})
+
+		graph.UpgradePaths = append(graph.UpgradePaths, edges...)
+	}
+
+	return graph, nil
+}
+
+// ShortestPath returns the versions to upgrade through, including from and
+// to, to get from one version to another with the fewest upgrades. When
+// multiple paths are equally short the one taking the largest steps first is
+// returned.
+func (b Builder) ShortestPath(graph Graph, from string, to string) ([]string, error) {
+	versions := map[string]bool{}
+	for _, r := range graph.Releases {
+		versions[r.Version] = true
+	}
+
+	if !versions[from] {
+		return nil, fmt.Errorf("version '%s' not found in releases of product: '%s'", from, graph.ProductSlug)
+	}
+
+	if !versions[to] {
+		return nil, fmt.Errorf("version '%s' not found in releases of product: '%s'", to, graph.ProductSlug)
+	}
+
+	upgradesFrom := map[string][]string{}
+	var targets []string
+	for _, e := range graph.UpgradePaths {
+		upgradesFrom[e.From] = append(upgradesFrom[e.From], e.To)
+		targets = append(targets, e.To)
+	}
+
+	parsed := b.parseSemvers(nil, targets)
+
+	for v := range upgradesFrom {
+		targets := upgradesFrom[v]
+		sort.SliceStable(targets, func(i, j int) bool {
+			return parsed.newer(targets[i], targets[j])
+		})
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			var path []string
+			for v := to; v != ""; v = previous[v] {
+				path = append([]string{v}, path...)
+			}
+			return path, nil
+		}
+
+		for _, next := range upgradesFrom[current] {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, fmt.Errorf("no upgrade path found from version '%s' to version '%s'", from, to)
+}
+
+// DOT renders the graph in the Graphviz DOT language. Versions on the
+// provided path are highlighted.
+func (g Graph) DOT(path []string) string {
+	onPath := map[Edge]bool{}
+	for i := 1; i < len(path); i++ {
+		onPath[Edge{From: path[i-1], To: path[i]}] = true
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %q {\n", g.ProductSlug)
+	fmt.Fprintln(&b, "  rankdir=LR;")
+
+	for _, r := range g.Releases {
+		fmt.Fprintf(&b, "  %q;\n", r.Version)
+	}
+
+	for _, e := range g.UpgradePaths {
+		if onPath[e] {
+			fmt.Fprintf(&b, "  %q -> %q [color=red, penwidth=2];\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %q -> %q;\n", e.From, e.To)
+	}
+
+	fmt.Fprintln(&b, "}")
+
+	return b.String()
+}
+
+func sortBySemver(releases []pivnet.Release, parsed semvers) []pivnet.Release {
+	sorted := make([]pivnet.Release, len(releases))
+	copy(sorted, releases)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return parsed.newer(sorted[i].Version, sorted[j].Version)
+	})
+
+	return sorted
+}
+
+func releaseVersions(releases []pivnet.Release) []string {
+	versions := make([]string, len(releases))
+	for i, r := range releases {
+		versions[i] = r.Version
+	}
+	return versions
+}
+
+// semvers holds versions parsed as semver, so that each version is parsed
+// once rather than on every comparison while sorting. Versions that are not
+// semver are held as nil.
+type semvers map[string]*semver.Version
+
+// parseSemvers adds the versions not already in parsed, creating it if it is
+// nil.
+func (b Builder) parseSemvers(parsed semvers, versions []string) semvers {
+	if parsed == nil {
+		parsed = make(semvers, len(versions))
+	}
+
+	for _, v := range versions {
+		if _, ok := parsed[v]; ok {
+			continue
+		}
+
+		asSemver, err := b.semverConverter.ToValidSemver(v)
+		if err != nil {
+			parsed[v] = nil
+			continue
+		}
+		parsed[v] = &asSemver
+	}
+
+	return parsed
+}
+
+// newer orders versions by semver, descending. Versions that are not semver
+// are ordered after all semver versions and otherwise keep their order.
+func (s semvers) newer(a string, c string) bool {
+	aSemver, cSemver := s[a], s[c]
+
+	switch {
+	case aSemver == nil:
+		return false
+	case cSemver == nil:
+		return true
+	default:
+		return aSemver.GT(*cSemver)
+	}
+}
@@ -0,0 +1,13 @@
+package upgradegraph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestUpgradeGraph(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "UpgradeGraph Suite")
+}
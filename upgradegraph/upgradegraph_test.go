@@ -0,0 +1,223 @@
+package upgradegraph_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/go-pivnet/v7"
+	"github.com/pivotal-cf/go-pivnet/v7/logger"
+	"github.com/pivotal-cf/go-pivnet/v7/logshim"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph"
+	"github.com/pivotal-cf/pivnet-resource/v3/upgradegraph/upgradegraphfakes"
+)
+
+var _ = Describe("Builder", func() {
+	var (
+		fakeLogger       logger.Logger
+		fakePivnetClient *upgradegraphfakes.FakePivnetClient
+
+		releases     []pivnet.Release
+		upgradePaths map[int][]pivnet.ReleaseUpgradePath
+
+		releasesErr     error
+		upgradePathsErr error
+
+		builder *upgradegraph.Builder
+	)
+
+	upgradePathFrom := func(r pivnet.Release) pivnet.ReleaseUpgradePath {
+		return pivnet.ReleaseUpgradePath{
+			Release: pivnet.UpgradePathRelease{
+				ID:      r.ID,
+				Version: r.Version,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		logger := log.New(GinkgoWriter, "", log.LstdFlags)
+		fakeLogger = logshim.NewLogShim(logger, logger, true)
+
+		fakePivnetClient = &upgradegraphfakes.FakePivnetClient{}
+
+		releases = []pivnet.Release{
+			{ID: 4, Version: "2.10.5"},
+			{ID: 1, Version: "2.13.2"},
+			{ID: 5, Version: "beta"},
+			{ID: 3, Version: "2.9.14"},
+			{ID: 2, Version: "2.11.0"},
+		}
+
+		upgradePaths = map[int][]pivnet.ReleaseUpgradePath{
+			1: {upgradePathFrom(releases[0]), upgradePathFrom(releases[4])},
+			2: {upgradePathFrom(releases[3]), upgradePathFrom(releases[0])},
+			4: {upgradePathFrom(releases[3])},
+		}
+
+		releasesErr = nil
+		upgradePathsErr = nil
+
+		builder = upgradegraph.NewBuilder(
+			fakeLogger,
+			fakePivnetClient,
+			semver.NewSemverConverter(fakeLogger),
+		)
+	})
+
+	JustBeforeEach(func() {
+		fakePivnetClient.ReleasesForProductSlugReturns(releases, releasesErr)
+		fakePivnetClient.ReleaseUpgradePathsStub = func(productSlug string, releaseID int) ([]pivnet.ReleaseUpgradePath, error) {
+			return upgradePaths[releaseID], upgradePathsErr
+		}
+	})
+
+	Describe("Build", func() {
+		It("builds the graph ordered by semver", func() {
+			graph, err := builder.Build("some-product")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(graph).To(Equal(upgradegraph.Graph{
+				ProductSlug: "some-product",
+				Releases: []upgradegraph.Release{
+					{ID: 1, Version: "2.13.2"},
+					{ID: 2, Version: "2.11.0"},
+					{ID: 4, Version: "2.10.5"},
+					{ID: 3, Version: "2.9.14"},
+					{ID: 5, Version: "beta"},
+				},
+				UpgradePaths: []upgradegraph.Edge{
+					{From: "2.11.0", To: "2.13.2"},
+					{From: "2.10.5", To: "2.13.2"},
+					{From: "2.10.5", To: "2.11.0"},
+					{From: "2.9.14", To: "2.11.0"},
+					{From: "2.9.14", To: "2.10.5"},
+				},
+			}))
+
+			Expect(fakePivnetClient.ReleasesForProductSlugArgsForCall(0)).To(Equal("some-product"))
+			Expect(fakePivnetClient.ReleaseUpgradePathsCallCount()).To(Equal(len(releases)))
+		})
+
+		It("parses each version as semver once", func() {
+			fakeSemverConverter := &upgradegraphfakes.FakeSemverConverter{}
+			fakeSemverConverter.ToValidSemverStub = semver.NewSemverConverter(fakeLogger).ToValidSemver
+
+			builder = upgradegraph.NewBuilder(fakeLogger, fakePivnetClient, fakeSemverConverter)
+
+			graph, err := builder.Build("some-product")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSemverConverter.ToValidSemverCallCount()).To(Equal(len(releases)))
+
+			_, err = builder.ShortestPath(graph, "2.9.14", "2.13.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSemverConverter.ToValidSemverCallCount()).To(Equal(len(releases) + 3))
+		})
+
+		Context("when getting releases returns an error", func() {
+			BeforeEach(func() {
+				releasesErr = errors.New("some releases error")
+			})
+
+			It("returns the error", func() {
+				_, err := builder.Build("some-product")
+				Expect(err).To(Equal(releasesErr))
+			})
+		})
+
+		Context("when getting upgrade paths returns an error", func() {
+			BeforeEach(func() {
+				upgradePathsErr = errors.New("some upgrade paths error")
+			})
+
+			It("returns the error", func() {
+				_, err := builder.Build("some-product")
+				Expect(err).To(Equal(upgradePathsErr))
+			})
+		})
+	})
+
+	Describe("ShortestPath", func() {
+		var graph upgradegraph.Graph
+
+		JustBeforeEach(func() {
+			var err error
+			graph, err = builder.Build("some-product")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the fewest upgrades, preferring the largest steps", func() {
+			path, err := builder.ShortestPath(graph, "2.9.14", "2.13.2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(path).To(Equal([]string{"2.9.14", "2.11.0", "2.13.2"}))
+		})
+
+		It("returns a direct upgrade when one exists", func() {
+			path, err := builder.ShortestPath(graph, "2.10.5", "2.13.2")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(path).To(Equal([]string{"2.10.5", "2.13.2"}))
+		})
+
+		It("returns only the version when upgrading to itself", func() {
+			path, err := builder.ShortestPath(graph, "2.11.0", "2.11.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(path).To(Equal([]string{"2.11.0"}))
+		})
+
+		Context("when there is no upgrade path", func() {
+			It("returns an error", func() {
+				_, err := builder.ShortestPath(graph, "beta", "2.13.2")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("no upgrade path found from version 'beta' to version '2.13.2'"))
+			})
+		})
+
+		Context("when a version is not a release of the product", func() {
+			It("returns an error", func() {
+				_, err := builder.ShortestPath(graph, "1.0.0", "2.13.2")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("version '1.0.0' not found"))
+			})
+		})
+	})
+})
+
+var _ = Describe("Graph", func() {
+	Describe("DOT", func() {
+		var graph upgradegraph.Graph
+
+		BeforeEach(func() {
+			graph = upgradegraph.Graph{
+				ProductSlug: "some-product",
+				Releases: []upgradegraph.Release{
+					{ID: 2, Version: "1.1.0"},
+					{ID: 1, Version: "1.0.0"},
+				},
+				UpgradePaths: []upgradegraph.Edge{
+					{From: "1.0.0", To: "1.1.0"},
+				},
+			}
+		})
+
+		It("renders the graph", func() {
+			Expect(graph.DOT(nil)).To(Equal(`digraph "some-product" {
+  rankdir=LR;
+  "1.1.0";
+  "1.0.0";
+  "1.0.0" -> "1.1.0";
+}
+`))
+		})
+
+		It("highlights the upgrade path", func() {
+			Expect(graph.DOT([]string{"1.0.0", "1.1.0"})).To(ContainSubstring(
+				`"1.0.0" -> "1.1.0" [color=red, penwidth=2];`,
+			))
+		})
+	})
+})
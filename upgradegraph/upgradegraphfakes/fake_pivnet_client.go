@@ -0,0 +1,195 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package upgradegraphfakes
+
+import (
+	"sync"
+
+	pivnet "github.com/pivotal-cf/go-pivnet/v7"
+)
+
+type FakePivnetClient struct {
+	ReleaseUpgradePathsStub        func(string, int) ([]pivnet.ReleaseUpgradePath, error)
+	releaseUpgradePathsMutex       sync.RWMutex
+	releaseUpgradePathsArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	releaseUpgradePathsReturns struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}
+	releaseUpgradePathsReturnsOnCall map[int]struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}
+	ReleasesForProductSlugStub        func(string) ([]pivnet.Release, error)
+	releasesForProductSlugMutex       sync.RWMutex
+	releasesForProductSlugArgsForCall []struct {
+		arg1 string
+	}
+	releasesForProductSlugReturns struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	releasesForProductSlugReturnsOnCall map[int]struct {
+		result1 []pivnet.Release
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePaths(arg1 string, arg2 int) ([]pivnet.ReleaseUpgradePath, error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	ret, specificReturn := fake.releaseUpgradePathsReturnsOnCall[len(fake.releaseUpgradePathsArgsForCall)]
+	fake.releaseUpgradePathsArgsForCall = append(fake.releaseUpgradePathsArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ReleaseUpgradePathsStub
+	fakeReturns := fake.releaseUpgradePathsReturns
+	fake.recordInvocation("ReleaseUpgradePaths", []interface{}{arg1, arg2})
+	fake.releaseUpgradePathsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsCallCount() int {
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
+	return len(fake.releaseUpgradePathsArgsForCall)
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsCalls(stub func(string, int) ([]pivnet.ReleaseUpgradePath, error)) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = stub
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsArgsForCall(i int) (string, int) {
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
+	argsForCall := fake.releaseUpgradePathsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsReturns(result1 []pivnet.ReleaseUpgradePath, result2 error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = nil
+	fake.releaseUpgradePathsReturns = struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ReleaseUpgradePathsReturnsOnCall(i int, result1 []pivnet.ReleaseUpgradePath, result2 error) {
+	fake.releaseUpgradePathsMutex.Lock()
+	defer fake.releaseUpgradePathsMutex.Unlock()
+	fake.ReleaseUpgradePathsStub = nil
+	if fake.releaseUpgradePathsReturnsOnCall == nil {
+		fake.releaseUpgradePathsReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.ReleaseUpgradePath
+			result2 error
+		})
+	}
+	fake.releaseUpgradePathsReturnsOnCall[i] = struct {
+		result1 []pivnet.ReleaseUpgradePath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlug(arg1 string) ([]pivnet.Release, error) {
+	fake.releasesForProductSlugMutex.Lock()
+	ret, specificReturn := fake.releasesForProductSlugReturnsOnCall[len(fake.releasesForProductSlugArgsForCall)]
+	fake.releasesForProductSlugArgsForCall = append(fake.releasesForProductSlugArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ReleasesForProductSlugStub
+	fakeReturns := fake.releasesForProductSlugReturns
+	fake.recordInvocation("ReleasesForProductSlug", []interface{}{arg1})
+	fake.releasesForProductSlugMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlugCallCount() int {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	return len(fake.releasesForProductSlugArgsForCall)
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlugCalls(stub func(string) ([]pivnet.Release, error)) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = stub
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlugArgsForCall(i int) string {
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	argsForCall := fake.releasesForProductSlugArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlugReturns(result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	fake.releasesForProductSlugReturns = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) ReleasesForProductSlugReturnsOnCall(i int, result1 []pivnet.Release, result2 error) {
+	fake.releasesForProductSlugMutex.Lock()
+	defer fake.releasesForProductSlugMutex.Unlock()
+	fake.ReleasesForProductSlugStub = nil
+	if fake.releasesForProductSlugReturnsOnCall == nil {
+		fake.releasesForProductSlugReturnsOnCall = make(map[int]struct {
+			result1 []pivnet.Release
+			result2 error
+		})
+	}
+	fake.releasesForProductSlugReturnsOnCall[i] = struct {
+		result1 []pivnet.Release
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePivnetClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.releaseUpgradePathsMutex.RLock()
+	defer fake.releaseUpgradePathsMutex.RUnlock()
+	fake.releasesForProductSlugMutex.RLock()
+	defer fake.releasesForProductSlugMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePivnetClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
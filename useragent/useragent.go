@@ -7,44 +7,64 @@ import (
 	"strings"
 )
 
-func UserAgent(version, containerType, productSlug string) string {
+// Build is the Concourse resource or build that the resource is running for,
+// read from the environment of the container.
+type Build struct {
+	ExternalURL string
+	Pipeline    string
+	Resource    string
+	Job         string
+	Name        string
+}
+
+func CurrentBuild() Build {
 	// check containers
-	externalURL := os.Getenv("EXTERNAL_URL")
-	resourceName := os.Getenv("RESOURCE_NAME")
-	pipelineName := os.Getenv("PIPELINE_NAME")
+	if resourceName := os.Getenv("RESOURCE_NAME"); resourceName != "" {
+		return Build{
+			ExternalURL: os.Getenv("EXTERNAL_URL"),
+			Pipeline:    os.Getenv("PIPELINE_NAME"),
+			Resource:    resourceName,
+		}
+	}
+
+	// in/out containers
+	return Build{
+		ExternalURL: os.Getenv("ATC_EXTERNAL_URL"),
+		Pipeline:    os.Getenv("BUILD_PIPELINE_NAME"),
+		Job:         os.Getenv("BUILD_JOB_NAME"),
+		Name:        os.Getenv("BUILD_NAME"),
+	}
+}
+
+func UserAgent(version, containerType, productSlug string) string {
+	build := CurrentBuild()
 
 	// check container
-	if resourceName != "" {
+	if build.Resource != "" {
 		return strings.Trim(
 			strconv.QuoteToASCII(
 				fmt.Sprintf(
 					"pivnet-resource/%s (%s/pipelines/%s/resources/%s -- %s/%s)",
 					version,
-					externalURL,
-					pipelineName,
-					resourceName,
-					resourceName,
+					build.ExternalURL,
+					build.Pipeline,
+					build.Resource,
+					build.Resource,
 					containerType,
 				),
 			), "\"")
 	}
 
 	// in/out containers
-	atcExternalURL := os.Getenv("ATC_EXTERNAL_URL")
-
-	buildPipelineName := os.Getenv("BUILD_PIPELINE_NAME")
-	buildJobName := os.Getenv("BUILD_JOB_NAME")
-	buildName := os.Getenv("BUILD_NAME")
-
 	return strings.Trim(
 		strconv.QuoteToASCII(
 			fmt.Sprintf(
 				"pivnet-resource/%s (%s/pipelines/%s/jobs/%s/builds/%s -- %s/%s)",
 				version,
-				atcExternalURL,
-				buildPipelineName,
-				buildJobName,
-				buildName,
+				build.ExternalURL,
+				build.Pipeline,
+				build.Job,
+				build.Name,
 				productSlug,
 				containerType,
 			),
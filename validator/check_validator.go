@@ -2,8 +2,12 @@ package validator
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
 )
 
 type CheckValidator struct {
@@ -24,5 +28,64 @@ func (v CheckValidator) Validate() error {
 	if v.input.Source.ProductSlug == "" {
 		return fmt.Errorf("%s must be provided", "product_slug")
 	}
+
+	err := semver.ValidateNormalization(v.input.Source.VersionNormalization, v.input.Source.VersionPattern)
+	if err != nil {
+		return err
+	}
+
+	err = validateLogFormat(v.input.Source.LogFormat)
+	if err != nil {
+		return err
+	}
+
+	_, err = transport.New(v.input.Source)
+	if err != nil {
+		return err
+	}
+
+	if v.input.Source.CacheTTL != "" {
+		ttl, err := time.ParseDuration(v.input.Source.CacheTTL)
+		if err != nil {
+			return fmt.Errorf("cache_ttl: '%s' is invalid: %s", v.input.Source.CacheTTL, err)
+		}
+
+		if ttl < 0 {
+			return fmt.Errorf("cache_ttl: '%s' must not be negative", v.input.Source.CacheTTL)
+		}
+	}
+
+	err = validateSortBy(v.input.Source.SortBy)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range v.input.Source.RequireFiles {
+		_, err := filepath.Match(g, "")
+		if err != nil {
+			return fmt.Errorf("require_files glob: '%s' is invalid: %s", g, err)
+		}
+	}
+
 	return nil
 }
+
+// validateSortBy does not reject unknown keys, which check ignores with a
+// warning as unknown values have always been ignored.
+func validateSortBy(sortBy concourse.SortBy) error {
+	keys := sortBy.Keys()
+	if len(keys) > 1 && sortBy.Includes(concourse.SortByNone) {
+		return fmt.Errorf("sort_by: '%s' cannot be combined with other keys", concourse.SortByNone)
+	}
+
+	return nil
+}
+
+func validateLogFormat(logFormat concourse.LogFormat) error {
+	switch logFormat {
+	case "", concourse.LogFormatText, concourse.LogFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("log_format: '%s' is not one of: %s, %s", logFormat, concourse.LogFormatText, concourse.LogFormatJSON)
+	}
+}
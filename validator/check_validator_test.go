@@ -13,20 +13,48 @@ var _ = Describe("Check Validator", func() {
 		checkRequest concourse.CheckRequest
 		v            *validator.CheckValidator
 
-		apiToken    string
-		productSlug string
+		apiToken     string
+		productSlug  string
+		requireFiles []string
+		cacheTTL     string
+		sortBy       concourse.SortBy
+
+		versionNormalization concourse.VersionNormalization
+		logFormat            concourse.LogFormat
+
+		caCerts    string
+		clientCert string
+		clientKey  string
 	)
 
 	BeforeEach(func() {
 		apiToken = "some-api-token"
 		productSlug = "some-productSlug"
+		requireFiles = nil
+		cacheTTL = ""
+		sortBy = ""
+		versionNormalization = ""
+		logFormat = ""
+		caCerts = ""
+		clientCert = ""
+		clientKey = ""
 	})
 
 	JustBeforeEach(func() {
 		checkRequest = concourse.CheckRequest{
 			Source: concourse.Source{
-				APIToken:    apiToken,
-				ProductSlug: productSlug,
+				APIToken:     apiToken,
+				ProductSlug:  productSlug,
+				RequireFiles: requireFiles,
+				CacheTTL:     cacheTTL,
+				SortBy:       sortBy,
+
+				VersionNormalization: versionNormalization,
+				LogFormat:            logFormat,
+
+				CACerts:    caCerts,
+				ClientCert: clientCert,
+				ClientKey:  clientKey,
 			},
 		}
 		v = validator.NewCheckValidator(checkRequest)
@@ -37,6 +65,160 @@ var _ = Describe("Check Validator", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	Context("when cache_ttl is not a duration", func() {
+		BeforeEach(func() {
+			cacheTTL = "five minutes"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cache_ttl: 'five minutes' is invalid"))
+		})
+	})
+
+	Context("when cache_ttl is negative", func() {
+		BeforeEach(func() {
+			cacheTTL = "-1m"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not be negative"))
+		})
+	})
+
+	Context("when a require_files glob is invalid", func() {
+		BeforeEach(func() {
+			requireFiles = []string{"*.pivotal", "[a-"}
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require_files glob: '[a-' is invalid"))
+		})
+	})
+
+	Context("when sort_by lists several keys", func() {
+		BeforeEach(func() {
+			sortBy = "semver,release_date,id"
+		})
+
+		It("returns without error", func() {
+			err := v.Validate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when sort_by has an unknown key", func() {
+		BeforeEach(func() {
+			sortBy = "semver,size"
+		})
+
+		It("returns without error, as check ignores it", func() {
+			err := v.Validate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when sort_by combines none with other keys", func() {
+		BeforeEach(func() {
+			sortBy = "none,semver"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be combined"))
+		})
+	})
+
+	Context("when version_normalization is unknown", func() {
+		BeforeEach(func() {
+			versionNormalization = "loose"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("version_normalization: 'loose' is not one of"))
+		})
+	})
+
+	Context("when log_format is json", func() {
+		BeforeEach(func() {
+			logFormat = concourse.LogFormatJSON
+		})
+
+		It("returns without error", func() {
+			err := v.Validate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when log_format is unknown", func() {
+		BeforeEach(func() {
+			logFormat = "xml"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("log_format: 'xml' is not one of: text, json"))
+		})
+	})
+
+	Context("when ca_certs is not PEM", func() {
+		BeforeEach(func() {
+			caCerts = "not a certificate"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ca_certs: no PEM certificates found"))
+		})
+	})
+
+	Context("when ca_certs holds an invalid certificate", func() {
+		BeforeEach(func() {
+			caCerts = "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ca_certs: certificate 1 is invalid"))
+		})
+	})
+
+	Context("when client_cert is provided without client_key", func() {
+		BeforeEach(func() {
+			clientCert = "some-client-cert"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("client_cert and client_key must be provided together"))
+		})
+	})
+
+	Context("when client_cert and client_key are not a key pair", func() {
+		BeforeEach(func() {
+			clientCert = "some-client-cert"
+			clientKey = "some-client-key"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("client_cert and client_key are invalid"))
+		})
+	})
+
 	Context("when neither legacy API token nor UAA refresh token are provided", func() {
 		BeforeEach(func() {
 			apiToken = ""
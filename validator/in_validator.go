@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
 )
 
 type InValidator struct {
@@ -25,9 +27,52 @@ func (v InValidator) Validate() error {
 		return fmt.Errorf("%s must be provided", "product_slug")
 	}
 
+	err := semver.ValidateNormalization(v.input.Source.VersionNormalization, v.input.Source.VersionPattern)
+	if err != nil {
+		return err
+	}
+
+	err = validateLogFormat(v.input.Source.LogFormat)
+	if err != nil {
+		return err
+	}
+
+	_, err = transport.New(v.input.Source)
+	if err != nil {
+		return err
+	}
+
 	if v.input.Version.ProductVersion == "" {
 		return fmt.Errorf("%s must be provided", "product_version")
 	}
 
+	params := v.input.Params
+
+	if params.UpgradePathFrom != "" && !params.UpgradeGraph {
+		return fmt.Errorf("upgrade_graph must be true when upgrade_path_from is provided")
+	}
+
+	if params.UpgradePathTo != "" && params.UpgradePathFrom == "" {
+		return fmt.Errorf("upgrade_path_from must be provided when upgrade_path_to is provided")
+	}
+
+	if params.OutputFormat != "" && params.OutputFormat != concourse.OutputFormatOm {
+		return fmt.Errorf("output_format: '%s' is not one of: %s", params.OutputFormat, concourse.OutputFormatOm)
+	}
+
+	if params.StemcellIaaS != "" && params.OutputFormat != concourse.OutputFormatOm {
+		return fmt.Errorf("output_format must be '%s' when stemcell_iaas is provided", concourse.OutputFormatOm)
+	}
+
+	for i, p := range params.Bundle {
+		if p.ProductSlug == "" {
+			return fmt.Errorf("bundle[%d].product_slug must be provided", i)
+		}
+
+		if p.Version == "" {
+			return fmt.Errorf("bundle[%d].version must be provided", i)
+		}
+	}
+
 	return nil
 }
@@ -15,12 +15,14 @@ var _ = Describe("In Validator", func() {
 		apiToken    string
 		productSlug string
 		version     string
+		params      concourse.InParams
 	)
 
 	BeforeEach(func() {
 		apiToken = "some-api-token"
 		productSlug = "some-productSlug"
 		version = "some-product-version"
+		params = concourse.InParams{}
 	})
 
 	JustBeforeEach(func() {
@@ -29,7 +31,7 @@ var _ = Describe("In Validator", func() {
 				APIToken:    apiToken,
 				ProductSlug: productSlug,
 			},
-			Params: concourse.InParams{},
+			Params: params,
 			Version: concourse.Version{
 				ProductVersion: version,
 			},
@@ -44,6 +46,70 @@ var _ = Describe("In Validator", func() {
 	})
 
 
+	Context("when upgrade_path_from is provided without upgrade_graph", func() {
+		BeforeEach(func() {
+			params.UpgradePathFrom = "1.2.3"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp("upgrade_graph must be true"))
+		})
+	})
+
+	Context("when upgrade_path_to is provided without upgrade_path_from", func() {
+		BeforeEach(func() {
+			params.UpgradeGraph = true
+			params.UpgradePathTo = "1.2.3"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp("upgrade_path_from must be provided"))
+		})
+	})
+
+	Context("when output_format is unknown", func() {
+		BeforeEach(func() {
+			params.OutputFormat = "tas"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp("output_format: 'tas' is not one of: om"))
+		})
+	})
+
+	Context("when stemcell_iaas is provided without output_format om", func() {
+		BeforeEach(func() {
+			params.StemcellIaaS = "vsphere"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp("output_format must be 'om'"))
+		})
+	})
+
+	Context("when a bundle product has no version", func() {
+		BeforeEach(func() {
+			params.Bundle = []concourse.BundleProduct{
+				{ProductSlug: "stemcells", Version: "~> 621.0"},
+				{ProductSlug: "companion"},
+			}
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(MatchRegexp(`bundle\[1\]\.version must be provided`))
+		})
+	})
+
 	Context("when neither UAA refresh token nor legacy API token are provided", func() {
 		BeforeEach(func() {
 			apiToken = ""
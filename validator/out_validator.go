@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/pivotal-cf/pivnet-resource/v3/concourse"
+	"github.com/pivotal-cf/pivnet-resource/v3/semver"
+	"github.com/pivotal-cf/pivnet-resource/v3/transport"
 )
 
 type OutValidator struct {
@@ -25,5 +27,28 @@ func (v OutValidator) Validate() error {
 		return fmt.Errorf("%s must be provided", "product_slug")
 	}
 
+	if v.input.Params.VersionFile != "" && !v.input.Params.DeriveMetadata {
+		return fmt.Errorf("%s can only be provided when %s is true", "version_file", "derive_metadata")
+	}
+
+	if v.input.Params.DropReadOnlyMetadata && !v.input.Params.MigrateMetadata {
+		return fmt.Errorf("%s can only be provided when %s is true", "drop_read_only_metadata", "migrate_metadata")
+	}
+
+	err := semver.ValidateNormalization(v.input.Source.VersionNormalization, v.input.Source.VersionPattern)
+	if err != nil {
+		return err
+	}
+
+	err = validateLogFormat(v.input.Source.LogFormat)
+	if err != nil {
+		return err
+	}
+
+	_, err = transport.New(v.input.Source)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
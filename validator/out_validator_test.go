@@ -13,6 +13,10 @@ var _ = Describe("Out Validator", func() {
 		apiToken         string
 		productSlug      string
 		fileGlob         string
+		deriveMetadata   bool
+		versionFile      string
+		migrateMetadata  bool
+		dropReadOnly     bool
 
 		outRequest concourse.OutRequest
 		v          *validator.OutValidator
@@ -23,6 +27,10 @@ var _ = Describe("Out Validator", func() {
 		productSlug = "some-product"
 
 		fileGlob = ""
+		deriveMetadata = false
+		versionFile = ""
+		migrateMetadata = false
+		dropReadOnly = false
 	})
 
 	JustBeforeEach(func() {
@@ -33,6 +41,11 @@ var _ = Describe("Out Validator", func() {
 			},
 			Params: concourse.OutParams{
 				FileGlob:       fileGlob,
+				DeriveMetadata: deriveMetadata,
+				VersionFile:    versionFile,
+
+				MigrateMetadata:      migrateMetadata,
+				DropReadOnlyMetadata: dropReadOnly,
 			},
 		}
 
@@ -86,4 +99,52 @@ var _ = Describe("Out Validator", func() {
 		})
 	})
 
+	Context("when a version file is provided", func() {
+		BeforeEach(func() {
+			versionFile = "version/version"
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("version_file can only be provided when derive_metadata is true"))
+		})
+
+		Context("when derive metadata is true", func() {
+			BeforeEach(func() {
+				deriveMetadata = true
+			})
+
+			It("returns without error", func() {
+				err := v.Validate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when drop read-only metadata is true", func() {
+		BeforeEach(func() {
+			dropReadOnly = true
+		})
+
+		It("returns an error", func() {
+			err := v.Validate()
+			Expect(err).To(HaveOccurred())
+
+			Expect(err.Error()).To(ContainSubstring("drop_read_only_metadata can only be provided when migrate_metadata is true"))
+		})
+
+		Context("when migrate metadata is true", func() {
+			BeforeEach(func() {
+				migrateMetadata = true
+			})
+
+			It("returns without error", func() {
+				err := v.Validate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
 })